@@ -33,7 +33,7 @@ func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(Account)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +126,7 @@ func (s *GetAccountSnapshotService) Do(ctx context.Context, opts ...RequestOptio
 		return &Snapshot{}, err
 	}
 	res = new(Snapshot)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return &Snapshot{}, err
 	}
@@ -210,7 +210,7 @@ func (s *GetAPIKeyPermission) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(APIKeyPermission)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,706 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/adshao/go-binance/v2/futures"
+)
+
+// CreateFuturesAlgoTwapOrderService place a TWAP algo order on USDⓈ-M
+// futures, which executes the quantity evenly over the duration
+type CreateFuturesAlgoTwapOrderService struct {
+	c            *Client
+	symbol       string
+	side         SideType
+	positionSide *futures.PositionSideType
+	quantity     string
+	duration     int64
+	clientAlgoID *string
+	reduceOnly   *bool
+	limitPrice   *string
+}
+
+// Symbol set symbol
+func (s *CreateFuturesAlgoTwapOrderService) Symbol(symbol string) *CreateFuturesAlgoTwapOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateFuturesAlgoTwapOrderService) Side(side SideType) *CreateFuturesAlgoTwapOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set positionSide, required in hedge mode
+func (s *CreateFuturesAlgoTwapOrderService) PositionSide(positionSide futures.PositionSideType) *CreateFuturesAlgoTwapOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateFuturesAlgoTwapOrderService) Quantity(quantity string) *CreateFuturesAlgoTwapOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Duration set duration in seconds, from 300 to 86400
+func (s *CreateFuturesAlgoTwapOrderService) Duration(duration int64) *CreateFuturesAlgoTwapOrderService {
+	s.duration = duration
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateFuturesAlgoTwapOrderService) ClientAlgoID(clientAlgoID string) *CreateFuturesAlgoTwapOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CreateFuturesAlgoTwapOrderService) ReduceOnly(reduceOnly bool) *CreateFuturesAlgoTwapOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// LimitPrice set limitPrice, the sub orders are placed as LIMIT orders at
+// this price instead of MARKET orders
+func (s *CreateFuturesAlgoTwapOrderService) LimitPrice(limitPrice string) *CreateFuturesAlgoTwapOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// Do send request
+func (s *CreateFuturesAlgoTwapOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"duration": s.duration,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	return s.c.createAlgoOrder(ctx, "/sapi/v1/algo/futures/newOrderTwap", m, opts...)
+}
+
+// CreateFuturesAlgoVpOrderService place a VP (volume participation) algo
+// order on USDⓈ-M futures, which follows the traded volume of the market
+type CreateFuturesAlgoVpOrderService struct {
+	c            *Client
+	symbol       string
+	side         SideType
+	positionSide *futures.PositionSideType
+	quantity     string
+	urgency      AlgoUrgencyType
+	clientAlgoID *string
+	reduceOnly   *bool
+	limitPrice   *string
+}
+
+// Symbol set symbol
+func (s *CreateFuturesAlgoVpOrderService) Symbol(symbol string) *CreateFuturesAlgoVpOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateFuturesAlgoVpOrderService) Side(side SideType) *CreateFuturesAlgoVpOrderService {
+	s.side = side
+	return s
+}
+
+// PositionSide set positionSide, required in hedge mode
+func (s *CreateFuturesAlgoVpOrderService) PositionSide(positionSide futures.PositionSideType) *CreateFuturesAlgoVpOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateFuturesAlgoVpOrderService) Quantity(quantity string) *CreateFuturesAlgoVpOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Urgency set urgency, the higher the urgency, the larger the share of the
+// market volume
+func (s *CreateFuturesAlgoVpOrderService) Urgency(urgency AlgoUrgencyType) *CreateFuturesAlgoVpOrderService {
+	s.urgency = urgency
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateFuturesAlgoVpOrderService) ClientAlgoID(clientAlgoID string) *CreateFuturesAlgoVpOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// ReduceOnly set reduceOnly
+func (s *CreateFuturesAlgoVpOrderService) ReduceOnly(reduceOnly bool) *CreateFuturesAlgoVpOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// LimitPrice set limitPrice, the sub orders are placed as LIMIT orders at
+// this price instead of MARKET orders
+func (s *CreateFuturesAlgoVpOrderService) LimitPrice(limitPrice string) *CreateFuturesAlgoVpOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// Do send request
+func (s *CreateFuturesAlgoVpOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"urgency":  s.urgency,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	return s.c.createAlgoOrder(ctx, "/sapi/v1/algo/futures/newOrderVp", m, opts...)
+}
+
+// CreateSpotAlgoTwapOrderService place a TWAP algo order on spot, which
+// executes the quantity evenly over the duration
+type CreateSpotAlgoTwapOrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	quantity                string
+	duration                int64
+	clientAlgoID            *string
+	limitPrice              *string
+	selfTradePreventionMode *SelfTradePreventionModeType
+}
+
+// Symbol set symbol
+func (s *CreateSpotAlgoTwapOrderService) Symbol(symbol string) *CreateSpotAlgoTwapOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateSpotAlgoTwapOrderService) Side(side SideType) *CreateSpotAlgoTwapOrderService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateSpotAlgoTwapOrderService) Quantity(quantity string) *CreateSpotAlgoTwapOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Duration set duration in seconds, from 300 to 86400
+func (s *CreateSpotAlgoTwapOrderService) Duration(duration int64) *CreateSpotAlgoTwapOrderService {
+	s.duration = duration
+	return s
+}
+
+// ClientAlgoID set clientAlgoId
+func (s *CreateSpotAlgoTwapOrderService) ClientAlgoID(clientAlgoID string) *CreateSpotAlgoTwapOrderService {
+	s.clientAlgoID = &clientAlgoID
+	return s
+}
+
+// LimitPrice set limitPrice
+func (s *CreateSpotAlgoTwapOrderService) LimitPrice(limitPrice string) *CreateSpotAlgoTwapOrderService {
+	s.limitPrice = &limitPrice
+	return s
+}
+
+// SelfTradePreventionMode set stpMode
+func (s *CreateSpotAlgoTwapOrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateSpotAlgoTwapOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// Do send request
+func (s *CreateSpotAlgoTwapOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+		"duration": s.duration,
+	}
+	if s.clientAlgoID != nil {
+		m["clientAlgoId"] = *s.clientAlgoID
+	}
+	if s.limitPrice != nil {
+		m["limitPrice"] = *s.limitPrice
+	}
+	if s.selfTradePreventionMode != nil {
+		m["stpMode"] = *s.selfTradePreventionMode
+	}
+	return s.c.createAlgoOrder(ctx, "/sapi/v1/algo/spot/newOrderTwap", m, opts...)
+}
+
+func (c *Client) createAlgoOrder(ctx context.Context, endpoint string, m params, opts ...RequestOption) (res *CreateAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(m)
+	data, _, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateAlgoOrderResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateAlgoOrderResponse define create algo order response
+type CreateAlgoOrderResponse struct {
+	ClientAlgoID string `json:"clientAlgoId"`
+	Success      bool   `json:"success"`
+	Code         int64  `json:"code"`
+	Message      string `json:"msg"`
+}
+
+// CancelFuturesAlgoOrderService cancel an open futures algo order
+type CancelFuturesAlgoOrderService struct {
+	c      *Client
+	algoID int64
+}
+
+// AlgoID set algoId
+func (s *CancelFuturesAlgoOrderService) AlgoID(algoID int64) *CancelFuturesAlgoOrderService {
+	s.algoID = algoID
+	return s
+}
+
+// Do send request
+func (s *CancelFuturesAlgoOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/sapi/v1/algo/futures/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("algoId", s.algoID)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CancelAlgoOrderResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelAlgoOrderResponse define cancel algo order response
+type CancelAlgoOrderResponse struct {
+	AlgoID  int64  `json:"algoId"`
+	Success bool   `json:"success"`
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// ListFuturesAlgoOpenOrdersService list the open futures algo orders
+type ListFuturesAlgoOpenOrdersService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ListFuturesAlgoOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/futures/openOrders",
+		secType:  secTypeSigned,
+	}
+	return s.c.listAlgoOrders(ctx, r, opts...)
+}
+
+// ListFuturesAlgoHistoricalOrdersService list the finished or canceled
+// futures algo orders
+type ListFuturesAlgoHistoricalOrdersService struct {
+	c         *Client
+	symbol    *string
+	side      *SideType
+	startTime *int64
+	endTime   *int64
+	page      *int
+	pageSize  *int
+}
+
+// Symbol set symbol
+func (s *ListFuturesAlgoHistoricalOrdersService) Symbol(symbol string) *ListFuturesAlgoHistoricalOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// Side set side
+func (s *ListFuturesAlgoHistoricalOrdersService) Side(side SideType) *ListFuturesAlgoHistoricalOrdersService {
+	s.side = &side
+	return s
+}
+
+// StartTime set startTime
+func (s *ListFuturesAlgoHistoricalOrdersService) StartTime(startTime int64) *ListFuturesAlgoHistoricalOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListFuturesAlgoHistoricalOrdersService) EndTime(endTime int64) *ListFuturesAlgoHistoricalOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListFuturesAlgoHistoricalOrdersService) Page(page int) *ListFuturesAlgoHistoricalOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, from 1 to 100
+func (s *ListFuturesAlgoHistoricalOrdersService) PageSize(pageSize int) *ListFuturesAlgoHistoricalOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListFuturesAlgoHistoricalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/futures/historicalOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.side != nil {
+		r.setParam("side", *s.side)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	return s.c.listAlgoOrders(ctx, r, opts...)
+}
+
+func (c *Client) listAlgoOrders(ctx context.Context, r *request, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	data, _, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoOrderList)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AlgoOrderList define a page of algo orders
+type AlgoOrderList struct {
+	Total  int64        `json:"total"`
+	Orders []*AlgoOrder `json:"orders"`
+}
+
+// AlgoOrder define algo order info
+type AlgoOrder struct {
+	AlgoID           int64           `json:"algoId"`
+	Symbol           string          `json:"symbol"`
+	Side             SideType        `json:"side"`
+	PositionSide     string          `json:"positionSide"`
+	TotalQuantity    string          `json:"totalQty"`
+	ExecutedQuantity string          `json:"executedQty"`
+	ExecutedAmount   string          `json:"executedAmt"`
+	AvgPrice         string          `json:"avgPrice"`
+	ClientAlgoID     string          `json:"clientAlgoId"`
+	BookTime         int64           `json:"bookTime"`
+	EndTime          int64           `json:"endTime"`
+	AlgoStatus       AlgoStatusType  `json:"algoStatus"`
+	AlgoType         AlgoType        `json:"algoType"`
+	Urgency          AlgoUrgencyType `json:"urgency"`
+}
+
+// ListFuturesAlgoSubOrdersService list the sub orders placed by a futures
+// algo order
+type ListFuturesAlgoSubOrdersService struct {
+	c        *Client
+	algoID   int64
+	page     *int
+	pageSize *int
+}
+
+// AlgoID set algoId
+func (s *ListFuturesAlgoSubOrdersService) AlgoID(algoID int64) *ListFuturesAlgoSubOrdersService {
+	s.algoID = algoID
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListFuturesAlgoSubOrdersService) Page(page int) *ListFuturesAlgoSubOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, from 1 to 100
+func (s *ListFuturesAlgoSubOrdersService) PageSize(pageSize int) *ListFuturesAlgoSubOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListFuturesAlgoSubOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoSubOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/futures/subOrders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("algoId", s.algoID)
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoSubOrderList)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AlgoSubOrderList define a page of the sub orders of an algo order
+type AlgoSubOrderList struct {
+	Total            int64           `json:"total"`
+	ExecutedQuantity string          `json:"executedQty"`
+	ExecutedAmount   string          `json:"executedAmt"`
+	SubOrders        []*AlgoSubOrder `json:"subOrders"`
+}
+
+// AlgoSubOrder define a sub order of an algo order
+type AlgoSubOrder struct {
+	AlgoID           int64           `json:"algoId"`
+	OrderID          int64           `json:"orderId"`
+	OrderStatus      OrderStatusType `json:"orderStatus"`
+	ExecutedQuantity string          `json:"executedQty"`
+	ExecutedAmount   string          `json:"executedAmt"`
+	FeeAmount        string          `json:"feeAmt"`
+	FeeAsset         string          `json:"feeAsset"`
+	BookTime         int64           `json:"bookTime"`
+	AvgPrice         string          `json:"avgPrice"`
+	Side             SideType        `json:"side"`
+	Symbol           string          `json:"symbol"`
+	SubID            int64           `json:"subId"`
+	TimeInForce      string          `json:"timeInForce"`
+	OrigQuantity     string          `json:"origQty"`
+}
+
+// CancelSpotAlgoOrderService cancel an open spot algo order
+type CancelSpotAlgoOrderService struct {
+	c      *Client
+	algoID int64
+}
+
+// AlgoID set algoId
+func (s *CancelSpotAlgoOrderService) AlgoID(algoID int64) *CancelSpotAlgoOrderService {
+	s.algoID = algoID
+	return s
+}
+
+// Do send request
+func (s *CancelSpotAlgoOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelAlgoOrderResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/sapi/v1/algo/spot/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("algoId", s.algoID)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CancelAlgoOrderResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListSpotAlgoOpenOrdersService list the open spot algo orders
+type ListSpotAlgoOpenOrdersService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ListSpotAlgoOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/spot/openOrders",
+		secType:  secTypeSigned,
+	}
+	return s.c.listAlgoOrders(ctx, r, opts...)
+}
+
+// ListSpotAlgoHistoricalOrdersService list the finished or canceled spot
+// algo orders
+type ListSpotAlgoHistoricalOrdersService struct {
+	c         *Client
+	symbol    *string
+	side      *SideType
+	startTime *int64
+	endTime   *int64
+	page      *int
+	pageSize  *int
+}
+
+// Symbol set symbol
+func (s *ListSpotAlgoHistoricalOrdersService) Symbol(symbol string) *ListSpotAlgoHistoricalOrdersService {
+	s.symbol = &symbol
+	return s
+}
+
+// Side set side
+func (s *ListSpotAlgoHistoricalOrdersService) Side(side SideType) *ListSpotAlgoHistoricalOrdersService {
+	s.side = &side
+	return s
+}
+
+// StartTime set startTime
+func (s *ListSpotAlgoHistoricalOrdersService) StartTime(startTime int64) *ListSpotAlgoHistoricalOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListSpotAlgoHistoricalOrdersService) EndTime(endTime int64) *ListSpotAlgoHistoricalOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListSpotAlgoHistoricalOrdersService) Page(page int) *ListSpotAlgoHistoricalOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, from 1 to 100
+func (s *ListSpotAlgoHistoricalOrdersService) PageSize(pageSize int) *ListSpotAlgoHistoricalOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListSpotAlgoHistoricalOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/spot/historicalOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.side != nil {
+		r.setParam("side", *s.side)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	return s.c.listAlgoOrders(ctx, r, opts...)
+}
+
+// ListSpotAlgoSubOrdersService list the sub orders placed by a spot algo
+// order
+type ListSpotAlgoSubOrdersService struct {
+	c        *Client
+	algoID   int64
+	page     *int
+	pageSize *int
+}
+
+// AlgoID set algoId
+func (s *ListSpotAlgoSubOrdersService) AlgoID(algoID int64) *ListSpotAlgoSubOrdersService {
+	s.algoID = algoID
+	return s
+}
+
+// Page set page, starting from 1
+func (s *ListSpotAlgoSubOrdersService) Page(page int) *ListSpotAlgoSubOrdersService {
+	s.page = &page
+	return s
+}
+
+// PageSize set pageSize, from 1 to 100
+func (s *ListSpotAlgoSubOrdersService) PageSize(pageSize int) *ListSpotAlgoSubOrdersService {
+	s.pageSize = &pageSize
+	return s
+}
+
+// Do send request
+func (s *ListSpotAlgoSubOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *AlgoSubOrderList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/algo/spot/subOrders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("algoId", s.algoID)
+	if s.page != nil {
+		r.setParam("page", *s.page)
+	}
+	if s.pageSize != nil {
+		r.setParam("pageSize", *s.pageSize)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AlgoSubOrderList)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
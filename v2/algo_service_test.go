@@ -0,0 +1,445 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/stretchr/testify/suite"
+)
+
+type algoServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestAlgoService(t *testing.T) {
+	suite.Run(t, new(algoServiceTestSuite))
+}
+
+func (s *algoServiceTestSuite) TestCreateFuturesAlgoTwapOrder() {
+	data := []byte(`{
+		"clientAlgoId": "65ce1630101a480b85915d7e11fd5078",
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":       "BTCUSDT",
+			"side":         SideTypeBuy,
+			"positionSide": futures.PositionSideTypeLong,
+			"quantity":     "0.5",
+			"duration":     3600,
+			"limitPrice":   "30000",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateFuturesAlgoTwapOrderService().Symbol("BTCUSDT").
+		Side(SideTypeBuy).PositionSide(futures.PositionSideTypeLong).Quantity("0.5").
+		Duration(3600).LimitPrice("30000").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CreateAlgoOrderResponse{
+		ClientAlgoID: "65ce1630101a480b85915d7e11fd5078",
+		Success:      true,
+		Code:         0,
+		Message:      "OK",
+	}, res)
+}
+
+func (s *algoServiceTestSuite) TestCreateFuturesAlgoVpOrder() {
+	data := []byte(`{
+		"clientAlgoId": "myAlgo",
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":       "ETHUSDT",
+			"side":         SideTypeSell,
+			"quantity":     "5",
+			"urgency":      AlgoUrgencyTypeLow,
+			"clientAlgoId": "myAlgo",
+			"reduceOnly":   true,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateFuturesAlgoVpOrderService().Symbol("ETHUSDT").
+		Side(SideTypeSell).Quantity("5").Urgency(AlgoUrgencyTypeLow).
+		ClientAlgoID("myAlgo").ReduceOnly(true).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("myAlgo", res.ClientAlgoID)
+	r.True(res.Success)
+}
+
+func (s *algoServiceTestSuite) TestCreateSpotAlgoTwapOrder() {
+	data := []byte(`{
+		"clientAlgoId": "65ce1630101a480b85915d7e11fd5078",
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeSell,
+			"quantity": "0.012",
+			"duration": 86400,
+			"stpMode":  SelfTradePreventionModeTypeExpireMaker,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateSpotAlgoTwapOrderService().Symbol("BTCUSDT").
+		Side(SideTypeSell).Quantity("0.012").Duration(86400).
+		SelfTradePreventionMode(SelfTradePreventionModeTypeExpireMaker).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.True(res.Success)
+}
+
+func (s *algoServiceTestSuite) TestCancelFuturesAlgoOrder() {
+	data := []byte(`{
+		"algoId": 14511,
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"algoId": 14511,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCancelFuturesAlgoOrderService().AlgoID(14511).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CancelAlgoOrderResponse{AlgoID: 14511, Success: true, Code: 0, Message: "OK"}, res)
+}
+
+func (s *algoServiceTestSuite) TestListFuturesAlgoOpenOrders() {
+	data := []byte(`{
+		"total": 1,
+		"orders": [
+			{
+				"algoId": 14517,
+				"symbol": "ETHUSDT",
+				"side": "SELL",
+				"positionSide": "SHORT",
+				"totalQty": "5.000",
+				"executedQty": "0.000",
+				"executedAmt": "0.00000000",
+				"avgPrice": "0.00",
+				"clientAlgoId": "d7096549481642f8a0bb69e9e2e31f2e",
+				"bookTime": 1649756817004,
+				"endTime": 0,
+				"algoStatus": "WORKING",
+				"algoType": "VP",
+				"urgency": "LOW"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListFuturesAlgoOpenOrdersService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&AlgoOrderList{
+		Total: 1,
+		Orders: []*AlgoOrder{
+			{
+				AlgoID:           14517,
+				Symbol:           "ETHUSDT",
+				Side:             SideTypeSell,
+				PositionSide:     "SHORT",
+				TotalQuantity:    "5.000",
+				ExecutedQuantity: "0.000",
+				ExecutedAmount:   "0.00000000",
+				AvgPrice:         "0.00",
+				ClientAlgoID:     "d7096549481642f8a0bb69e9e2e31f2e",
+				BookTime:         1649756817004,
+				EndTime:          0,
+				AlgoStatus:       AlgoStatusTypeWorking,
+				AlgoType:         AlgoTypeVp,
+				Urgency:          AlgoUrgencyTypeLow,
+			},
+		},
+	}, res)
+}
+
+func (s *algoServiceTestSuite) TestListFuturesAlgoHistoricalOrders() {
+	data := []byte(`{
+		"total": 1,
+		"orders": [
+			{
+				"algoId": 14518,
+				"symbol": "BNBUSDT",
+				"side": "BUY",
+				"positionSide": "BOTH",
+				"totalQty": "100.00",
+				"executedQty": "0.00",
+				"executedAmt": "0.00000000",
+				"avgPrice": "0.000",
+				"clientAlgoId": "acacab56b3c44bef9f6a8f8ebd2a8408",
+				"bookTime": 1649757019503,
+				"endTime": 1649757088101,
+				"algoStatus": "CANCELLED",
+				"algoType": "VP",
+				"urgency": "LOW"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":    "BNBUSDT",
+			"side":      SideTypeBuy,
+			"startTime": 1649756817004,
+			"page":      1,
+			"pageSize":  100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListFuturesAlgoHistoricalOrdersService().Symbol("BNBUSDT").
+		Side(SideTypeBuy).StartTime(1649756817004).Page(1).PageSize(100).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(14518), res.Orders[0].AlgoID)
+	r.Equal(AlgoStatusTypeCancelled, res.Orders[0].AlgoStatus)
+	r.Equal(int64(1649757088101), res.Orders[0].EndTime)
+}
+
+func (s *algoServiceTestSuite) TestListFuturesAlgoSubOrders() {
+	data := []byte(`{
+		"total": 1,
+		"executedQty": "1.000",
+		"executedAmt": "3229.44000000",
+		"subOrders": [
+			{
+				"algoId": 13723,
+				"orderId": 8389765519993908929,
+				"orderStatus": "FILLED",
+				"executedQty": "1.000",
+				"executedAmt": "3229.44000000",
+				"feeAmt": "-1.61471999",
+				"feeAsset": "USDT",
+				"bookTime": 1649319001964,
+				"avgPrice": "3229.44",
+				"side": "SELL",
+				"symbol": "ETHUSDT",
+				"subId": 1,
+				"timeInForce": "IMMEDIATE_OR_CANCEL",
+				"origQty": "1.000"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"algoId": 13723,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListFuturesAlgoSubOrdersService().AlgoID(13723).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&AlgoSubOrderList{
+		Total:            1,
+		ExecutedQuantity: "1.000",
+		ExecutedAmount:   "3229.44000000",
+		SubOrders: []*AlgoSubOrder{
+			{
+				AlgoID:           13723,
+				OrderID:          8389765519993908929,
+				OrderStatus:      OrderStatusTypeFilled,
+				ExecutedQuantity: "1.000",
+				ExecutedAmount:   "3229.44000000",
+				FeeAmount:        "-1.61471999",
+				FeeAsset:         "USDT",
+				BookTime:         1649319001964,
+				AvgPrice:         "3229.44",
+				Side:             SideTypeSell,
+				Symbol:           "ETHUSDT",
+				SubID:            1,
+				TimeInForce:      "IMMEDIATE_OR_CANCEL",
+				OrigQuantity:     "1.000",
+			},
+		},
+	}, res)
+}
+
+func (s *algoServiceTestSuite) TestCancelSpotAlgoOrder() {
+	data := []byte(`{
+		"algoId": 14511,
+		"success": true,
+		"code": 0,
+		"msg": "OK"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"algoId": 14511,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCancelSpotAlgoOrderService().AlgoID(14511).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CancelAlgoOrderResponse{AlgoID: 14511, Success: true, Code: 0, Message: "OK"}, res)
+}
+
+func (s *algoServiceTestSuite) TestListSpotAlgoOpenOrders() {
+	data := []byte(`{
+		"total": 1,
+		"orders": [
+			{
+				"algoId": 14517,
+				"symbol": "ETHUSDT",
+				"side": "SELL",
+				"totalQty": "5.000",
+				"executedQty": "0.000",
+				"executedAmt": "0.00000000",
+				"avgPrice": "0.00",
+				"clientAlgoId": "d7096549481642f8a0bb69e9e2e31f2e",
+				"bookTime": 1649756817004,
+				"endTime": 0,
+				"algoStatus": "WORKING",
+				"algoType": "TWAP",
+				"urgency": "LOW"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListSpotAlgoOpenOrdersService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&AlgoOrderList{
+		Total: 1,
+		Orders: []*AlgoOrder{
+			{
+				AlgoID:           14517,
+				Symbol:           "ETHUSDT",
+				Side:             SideTypeSell,
+				TotalQuantity:    "5.000",
+				ExecutedQuantity: "0.000",
+				ExecutedAmount:   "0.00000000",
+				AvgPrice:         "0.00",
+				ClientAlgoID:     "d7096549481642f8a0bb69e9e2e31f2e",
+				BookTime:         1649756817004,
+				EndTime:          0,
+				AlgoStatus:       AlgoStatusTypeWorking,
+				AlgoType:         AlgoTypeTwap,
+				Urgency:          AlgoUrgencyTypeLow,
+			},
+		},
+	}, res)
+}
+
+func (s *algoServiceTestSuite) TestListSpotAlgoHistoricalOrders() {
+	data := []byte(`{
+		"total": 1,
+		"orders": [
+			{
+				"algoId": 14518,
+				"symbol": "BNBUSDT",
+				"side": "BUY",
+				"totalQty": "100.00",
+				"executedQty": "0.00",
+				"executedAmt": "0.00000000",
+				"avgPrice": "0.000",
+				"clientAlgoId": "acacab56b3c44bef9f6a8f8ebd2a8408",
+				"bookTime": 1649757019503,
+				"endTime": 1649757088101,
+				"algoStatus": "CANCELLED",
+				"algoType": "TWAP",
+				"urgency": "LOW"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":    "BNBUSDT",
+			"side":      SideTypeBuy,
+			"startTime": 1649756817004,
+			"endTime":   1649757088101,
+			"page":      1,
+			"pageSize":  100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListSpotAlgoHistoricalOrdersService().Symbol("BNBUSDT").
+		Side(SideTypeBuy).StartTime(1649756817004).EndTime(1649757088101).Page(1).PageSize(100).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(14518), res.Orders[0].AlgoID)
+	r.Equal(AlgoStatusTypeCancelled, res.Orders[0].AlgoStatus)
+	r.Equal(int64(1649757088101), res.Orders[0].EndTime)
+}
+
+func (s *algoServiceTestSuite) TestListSpotAlgoSubOrders() {
+	data := []byte(`{
+		"total": 1,
+		"executedQty": "1.000",
+		"executedAmt": "3229.44000000",
+		"subOrders": [
+			{
+				"algoId": 13723,
+				"orderId": 8389765519993908929,
+				"orderStatus": "FILLED",
+				"executedQty": "1.000",
+				"executedAmt": "3229.44000000",
+				"feeAmt": "0.001",
+				"feeAsset": "BNB",
+				"bookTime": 1649319001964,
+				"avgPrice": "3229.44",
+				"side": "SELL",
+				"symbol": "ETHUSDT",
+				"subId": 1,
+				"timeInForce": "IMMEDIATE_OR_CANCEL",
+				"origQty": "1.000"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"algoId":   13723,
+			"page":     1,
+			"pageSize": 50,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListSpotAlgoSubOrdersService().AlgoID(13723).Page(1).PageSize(50).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.EqualValues(1, res.Total)
+	r.Len(res.SubOrders, 1)
+	r.Equal(int64(8389765519993908929), res.SubOrders[0].OrderID)
+	r.Equal(OrderStatusTypeFilled, res.SubOrders[0].OrderStatus)
+	r.Equal("BNB", res.SubOrders[0].FeeAsset)
+}
@@ -34,7 +34,7 @@ func (s *GetAssetDetailService) Do(ctx context.Context) (res map[string]AssetDet
 		return
 	}
 	res = make(map[string]AssetDetail)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -58,7 +58,7 @@ func (s *GetAllCoinsInfoService) Do(ctx context.Context) (res []*CoinInfo, err e
 		return []*CoinInfo{}, err
 	}
 	res = make([]*CoinInfo, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*CoinInfo{}, err
 	}
@@ -157,6 +157,6 @@ func (s *GetUserAssetService) Do(ctx context.Context) (res []UserAssetRecord, er
 	if err != nil {
 		return
 	}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	return
 }
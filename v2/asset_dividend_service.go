@@ -66,7 +66,7 @@ func (s *AssetDividendService) Do(ctx context.Context) (*DividendResponseWrapper
 		return nil, err
 	}
 	res := new(DividendResponseWrapper)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
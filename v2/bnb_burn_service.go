@@ -22,7 +22,7 @@ func (s *GetBNBBurnService) Do(ctx context.Context, opts ...RequestOption) (*BNB
 		return nil, err
 	}
 	res := BNBBurn{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -73,7 +73,7 @@ func (s *ToggleBNBBurnService) Do(ctx context.Context, opts ...RequestOption) (*
 		return nil, err
 	}
 	res := BNBBurn{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
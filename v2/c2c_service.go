@@ -70,7 +70,7 @@ func (s *C2CTradeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res := C2CTradeHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -3,8 +3,7 @@ package binance
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
+	"errors"
 	"fmt"
 	"github.com/adshao/go-binance/v2/portfolio"
 	jsoniter "github.com/json-iterator/go"
@@ -31,6 +30,16 @@ type SideType string
 // OrderType define order type
 type OrderType string
 
+// isAlgo return true for the stop and take profit orders, counted by the
+// MAX_NUM_ALGO_ORDERS filter
+func (t OrderType) isAlgo() bool {
+	switch t {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit, OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		return true
+	}
+	return false
+}
+
 // TimeInForceType define time in force type of order
 type TimeInForceType string
 
@@ -104,6 +113,33 @@ type RateLimitInterval string
 // AccountType define the account types
 type AccountType string
 
+// CancelReplaceModeType define whether the new order of a cancel-replace is
+// placed when the cancel fails
+type CancelReplaceModeType string
+
+// CancelReplaceResultType define the result of the cancel or of the new order
+// of a cancel-replace
+type CancelReplaceResultType string
+
+// CancelRestrictionsType define the status an order must have to be canceled
+type CancelRestrictionsType string
+
+// OrderRateLimitExceededModeType define whether the cancel of a cancel-replace
+// is done when the order rate limit is exceeded
+type OrderRateLimitExceededModeType string
+
+// SelfTradePreventionModeType define the self trade prevention mode of an order
+type SelfTradePreventionModeType string
+
+// AlgoUrgencyType define the urgency of a VP algo order
+type AlgoUrgencyType string
+
+// AlgoStatusType define the status of an algo order
+type AlgoStatusType string
+
+// AlgoType define the type of an algo order
+type AlgoType string
+
 // Endpoints
 var (
 	BaseAPIMainURL    = "https://api.binance.com"
@@ -158,6 +194,7 @@ const (
 
 	SymbolFilterTypeLotSize            SymbolFilterType = "LOT_SIZE"
 	SymbolFilterTypePriceFilter        SymbolFilterType = "PRICE_FILTER"
+	SymbolFilterTypePercentPrice       SymbolFilterType = "PERCENT_PRICE"
 	SymbolFilterTypePercentPriceBySide SymbolFilterType = "PERCENT_PRICE_BY_SIDE"
 	SymbolFilterTypeMinNotional        SymbolFilterType = "MIN_NOTIONAL"
 	SymbolFilterTypeNotional           SymbolFilterType = "NOTIONAL"
@@ -171,6 +208,7 @@ const (
 	UserDataEventTypeBalanceUpdate           UserDataEventType = "balanceUpdate"
 	UserDataEventTypeExecutionReport         UserDataEventType = "executionReport"
 	UserDataEventTypeListStatus              UserDataEventType = "ListStatus"
+	UserDataEventTypeListenKeyExpired        UserDataEventType = "listenKeyExpired"
 
 	MarginTransferTypeToMargin MarginTransferType = 1
 	MarginTransferTypeToMain   MarginTransferType = 2
@@ -242,6 +280,35 @@ const (
 	AccountTypeIsolatedMargin AccountType = "ISOLATED_MARGIN"
 	AccountTypeUSDTFuture     AccountType = "USDT_FUTURE"
 	AccountTypeCoinFuture     AccountType = "COIN_FUTURE"
+
+	CancelReplaceModeTypeStopOnFailure CancelReplaceModeType = "STOP_ON_FAILURE"
+	CancelReplaceModeTypeAllowFailure  CancelReplaceModeType = "ALLOW_FAILURE"
+
+	CancelReplaceResultTypeSuccess      CancelReplaceResultType = "SUCCESS"
+	CancelReplaceResultTypeFailure      CancelReplaceResultType = "FAILURE"
+	CancelReplaceResultTypeNotAttempted CancelReplaceResultType = "NOT_ATTEMPTED"
+
+	CancelRestrictionsTypeOnlyNew             CancelRestrictionsType = "ONLY_NEW"
+	CancelRestrictionsTypeOnlyPartiallyFilled CancelRestrictionsType = "ONLY_PARTIALLY_FILLED"
+
+	OrderRateLimitExceededModeTypeDoNothing  OrderRateLimitExceededModeType = "DO_NOTHING"
+	OrderRateLimitExceededModeTypeCancelOnly OrderRateLimitExceededModeType = "CANCEL_ONLY"
+
+	SelfTradePreventionModeTypeNone        SelfTradePreventionModeType = "NONE"
+	SelfTradePreventionModeTypeExpireTaker SelfTradePreventionModeType = "EXPIRE_TAKER"
+	SelfTradePreventionModeTypeExpireMaker SelfTradePreventionModeType = "EXPIRE_MAKER"
+	SelfTradePreventionModeTypeExpireBoth  SelfTradePreventionModeType = "EXPIRE_BOTH"
+
+	AlgoUrgencyTypeLow    AlgoUrgencyType = "LOW"
+	AlgoUrgencyTypeMedium AlgoUrgencyType = "MEDIUM"
+	AlgoUrgencyTypeHigh   AlgoUrgencyType = "HIGH"
+
+	AlgoStatusTypeWorking   AlgoStatusType = "WORKING"
+	AlgoStatusTypeFinished  AlgoStatusType = "FINISHED"
+	AlgoStatusTypeCancelled AlgoStatusType = "CANCELLED"
+
+	AlgoTypeTwap AlgoType = "TWAP"
+	AlgoTypeVp   AlgoType = "VP"
 )
 
 type RateLimits struct {
@@ -301,38 +368,31 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getAPIEndpoint return the base endpoint of the Rest API according the UseTestnet flag
-func getAPIEndpoint() string {
-	if UseTestnet {
-		return BaseAPITestnetURL
-	}
-	return BaseAPIMainURL
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewClient(apiKey, secretKey string) *Client {
-	wsState := WsInit
-	c := makeConn(nil, nil)
-	if c != nil {
-		wsState = WsConnected
-	}
+	return NewClientWithEnvironment(apiKey, secretKey, DefaultEnvironment())
+}
 
+// NewClientWithEnvironment initialize an API client instance with the
+// endpoints of the environment instead of the ones of the UseTestnet flag
+func NewClientWithEnvironment(apiKey, secretKey string, env Environment) *Client {
 	client := &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getAPIEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
-		WsURL:      getWsAPIEndpoint(),
-		WsConn:     c,
-		wsState:    wsState,
-	}
-	if c != nil {
-		client.handleDisconnected(c.Done, nil, nil)
+		APIKey:            apiKey,
+		SecretKey:         secretKey,
+		BaseURL:           env.BaseURL,
+		UserAgent:         "Binance/golang",
+		HTTPClient:        http.DefaultClient,
+		Logger:            log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		WsURL:             env.WsAPIURL,
+		WsStreamURL:       env.WsStreamURL,
+		CombinedStreamURL: env.CombinedStreamURL,
+		wsState:           WsInit,
+		wsStopC:           make(chan struct{}),
 	}
+	// the errors of the connection are logged by the logger of the client
+	client.Connect()
 
 	return client
 }
@@ -364,26 +424,100 @@ type Client struct {
 	sync.Mutex
 	APIKey     string
 	SecretKey  string
+	Signer     common.Signer
 	BaseURL    string
 	UserAgent  string
 	HTTPClient *http.Client
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	timeLock   sync.RWMutex
 	do         doFunc
 	WsURL      string
 	WsConn     *WsConnection
-	wsState    WsClientState // init/connecting/connected
+	// WsStreamURL and CombinedStreamURL are the base endpoints of the
+	// websocket streams of the client, the ones of the UseTestnet flag if
+	// they are empty
+	WsStreamURL       string
+	CombinedStreamURL string
+	wsLock            sync.RWMutex
+	wsState           WsClientState // init/connecting/connected
+	wsSession         bool          // logon the session again after reconnecting
+	wsStopC           chan struct{} // closed by Close to stop reconnecting
+
+	reconnectPolicy *common.ReconnectPolicy
+
+	// OrderValidator checks the orders against the symbol filters before they
+	// are sent, nil to let the server check them
+	OrderValidator *common.OrderValidator
+	// RateLimiter keeps the REST and WebSocket API calls under the rate
+	// limits, nil to let the server enforce them
+	RateLimiter *common.RateLimitGovernor
+	// RetryPolicy sends the failed requests again when it is safe, nil to
+	// send them once
+	RetryPolicy *common.RetryPolicy
+	// Interceptors wrap every REST and WebSocket API call, the first one is
+	// the outermost
+	Interceptors []common.Interceptor
+	// Instrumentation receives the spans of the REST and WebSocket API
+	// calls, nil to ignore them
+	Instrumentation common.Instrumentation
+	// StructuredLogger receives the leveled logs of the client, with the
+	// secrets redacted. Logger is used if it is nil, from the debug level if
+	// Debug is set.
+	StructuredLogger common.Logger
+	// TimeSync, if set and synced, gives the time offset of the signed
+	// requests instead of TimeOffset. It is synced again when a request is
+	// rejected for its timestamp, and the request is sent once again.
+	TimeSync *common.TimeSync
+	// ResilientStreams, if set, reconnects the market data streams of the
+	// client when they drop and signals the gaps, nil to end them on the
+	// first error
+	ResilientStreams *common.ResilientStreams
 }
 
 func (c *Client) WsConnected() bool {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
 	return c.wsState == WsConnected
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
+// timeOffset return the offset of the local time to the server time in
+// milliseconds
+func (c *Client) timeOffset() int64 {
+	if c.TimeSync != nil && c.TimeSync.Synced() {
+		return c.TimeSync.Offset().Milliseconds()
+	}
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.TimeOffset
+}
+
+// setTimeOffset set TimeOffset, which the requests may be reading
+func (c *Client) setTimeOffset(offset int64) {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+	c.TimeOffset = offset
+}
+
+// logger return the logger of the client, which redacts the secrets
+func (c *Client) logger() common.Logger {
+	if c.StructuredLogger != nil {
+		return common.NewRedactingLogger(c.StructuredLogger)
+	}
+	level := common.LogLevelInfo
 	if c.Debug {
-		c.Logger.Printf(format, v...)
+		level = common.LogLevelDebug
 	}
+	return common.NewRedactingLogger(common.NewStdLogger(c.Logger, level))
+}
+
+// signer return the signer of SIGNED requests, HMAC with SecretKey by default
+func (c *Client) signer() common.Signer {
+	if c.Signer != nil {
+		return c.Signer
+	}
+	return common.NewHMACSigner(c.SecretKey)
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
@@ -401,7 +535,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		r.setParam(recvWindowKey, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+		r.setParam(timestampKey, currentTimestamp()-c.timeOffset())
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -420,13 +554,12 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, sig)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -436,7 +569,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.logger().Debug("request url", "url", fullURL, "body", bodyString)
 
 	r.fullURL = fullURL
 	r.header = header
@@ -444,14 +577,182 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	return nil
 }
 
-func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
+// callAPI send the request, and send it once again after syncing TimeSync
+// if its timestamp is outside of the recvWindow
+func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	if c.TimeSync == nil || r.secType != secTypeSigned {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	rc := r.clone()
+	data, limits, err = c.retryAPI(ctx, rc, opts...)
+	r.ws, r.statusCode = rc.ws, rc.statusCode
+	if errors.Is(err, common.ErrTimestampOutsideRecvWindow) && c.TimeSync.Resync(ctx) == nil {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	return data, limits, err
+}
+
+// retryAPI send the request, and send it again according to RetryPolicy
+func (c *Client) retryAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	if c.RetryPolicy == nil {
+		return c.callAPIOnce(ctx, r, opts...)
+	}
+	err = c.RetryPolicy.Do(ctx, &common.RetryRequest{
+		Idempotent:    r.idempotent(),
+		PlacesOrder:   r.orderCount() > 0,
+		ClientOrderID: r.param("newClientOrderId"),
+		Send: func() (err error) {
+			rc := r.clone()
+			data, limits, err = c.callAPIOnce(ctx, rc, opts...)
+			r.ws, r.statusCode = rc.ws, rc.statusCode
+			return err
+		},
+		LookupOrder: c.lookupOrder(ctx, r, &data),
+	})
+	return data, limits, err
+}
+
+// lookupOrder return a function which finds the order placed by the request
+// by its client order id, nil if the order cannot be queried
+func (c *Client) lookupOrder(ctx context.Context, r *request, data *[]byte) func() (bool, error) {
+	if r.endpoint != "/api/v3/order" && r.endpoint != "/api/v3/sor/order" {
+		return nil
+	}
+	return func() (bool, error) {
+		order, err := c.NewGetOrderService().Symbol(r.param("symbol")).OrigClientOrderID(r.param("newClientOrderId")).Do(ctx)
+		if errors.Is(err, common.ErrUnknownOrder) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		*data, err = json.Marshal(reconciledOrderResponse(r, order))
+		return err == nil, err
+	}
+}
+
+// reconciledOrderResponse return the response of the order placed by the
+// request, made of the order found by its client order id. Unlike the
+// response of the server, it has no fills, TransactTime is the time of the
+// order and WorkingTime is unknown.
+func reconciledOrderResponse(r *request, order *Order) interface{} {
+	if r.endpoint != "/api/v3/sor/order" {
+		return &CreateOrderResponse{
+			Symbol:                   order.Symbol,
+			OrderID:                  order.OrderID,
+			ClientOrderID:            order.ClientOrderID,
+			TransactTime:             order.Time,
+			Price:                    order.Price,
+			OrigQuantity:             order.OrigQuantity,
+			ExecutedQuantity:         order.ExecutedQuantity,
+			CummulativeQuoteQuantity: order.CummulativeQuoteQuantity,
+			IsIsolated:               order.IsIsolated,
+			Status:                   order.Status,
+			TimeInForce:              order.TimeInForce,
+			Type:                     order.Type,
+			Side:                     order.Side,
+			Fills:                    []*Fill{},
+		}
+	}
+	res := &CreateSOROrderResponse{
+		Symbol:                   order.Symbol,
+		OrderID:                  order.OrderID,
+		OrderListID:              order.OrderListId,
+		ClientOrderID:            order.ClientOrderID,
+		TransactTime:             order.Time,
+		Price:                    order.Price,
+		OrigQuantity:             order.OrigQuantity,
+		ExecutedQuantity:         order.ExecutedQuantity,
+		CummulativeQuoteQuantity: order.CummulativeQuoteQuantity,
+		Status:                   order.Status,
+		TimeInForce:              order.TimeInForce,
+		Type:                     order.Type,
+		Side:                     order.Side,
+		Fills:                    []*SORFill{},
+		UsedSor:                  true,
+	}
+	// the WS API returns the order in an array
+	if r.ws {
+		return []*CreateSOROrderResponse{res}
+	}
+	return res
+}
+
+// callAPIOnce send the request without retrying it
+func (c *Client) callAPIOnce(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
 	var err error
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Acquire(ctx, r.method, r.endpoint, r.weight(), r.orderCount()); err != nil {
+			return nil, nil, err
+		}
+	}
 	// prefer to WS API
-	if c.WsConnected() && r.wsMethod != "" {
-		return c.callWsAPI(ctx, r, opts...)
+	ws := c.WsConnected() && r.wsMethod != ""
+	return c.invoke(ctx, r, ws, opts...)
+}
+
+// invoke send the request through the instrumentation and the Interceptors,
+// over the WebSocket API if ws is true
+func (c *Client) invoke(ctx context.Context, r *request, ws bool, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	ctx, span := c.startSpan(ctx, r, ws)
+	if len(c.Interceptors) > 0 {
+		data, limits, err = c.intercept(ctx, r, ws, opts...)
+	} else {
+		data, limits, err = c.sendAPI(ctx, r, ws, opts...)
 	}
+	span.Finish(common.NewSpanEnd(r.statusCode, r.rateLimits, err))
+	return data, limits, err
+}
 
-	err = c.parseRequest(r, opts...)
+func (c *Client) instrumentation() common.Instrumentation {
+	return common.InstrumentationOrNoop(c.Instrumentation)
+}
+
+// startSpan start the span of the request, sent over the WebSocket API if
+// ws is true
+func (c *Client) startSpan(ctx context.Context, r *request, ws bool) (context.Context, common.Span) {
+	start := &common.SpanStart{BaseURL: c.BaseURL, Method: r.method, Endpoint: r.endpoint, WebSocket: ws, Time: time.Now()}
+	if ws {
+		start.BaseURL = c.WsURL
+		start.WsMethod = r.wsMethod
+	}
+	return c.instrumentation().StartSpan(ctx, start)
+}
+
+// intercept send the request through the Interceptors
+func (c *Client) intercept(ctx context.Context, r *request, ws bool, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	// the interceptors see the request options
+	for _, opt := range opts {
+		opt(r)
+	}
+	var limits *RateLimits
+	invoker := common.ChainInterceptors(c.Interceptors, func(ctx context.Context, req *common.APIRequest) (*common.APIResponse, error) {
+		r.setAPIRequest(req)
+		data, l, err := c.sendAPI(ctx, r, req.WebSocket)
+		if err != nil {
+			return nil, err
+		}
+		limits = l
+		return &common.APIResponse{Data: data, StatusCode: r.statusCode, RateLimits: r.rateLimits}, nil
+	})
+	res, err := invoker(ctx, r.apiRequest(ws))
+	if err != nil {
+		return nil, nil, err
+	}
+	if res == nil {
+		return nil, limits, nil
+	}
+	r.statusCode, r.rateLimits = res.StatusCode, res.RateLimits
+	return res.Data, limits, nil
+}
+
+// sendAPI send the request over the WebSocket API, or the REST API
+func (c *Client) sendAPI(ctx context.Context, r *request, ws bool, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	r.ws = ws
+	if ws {
+		return c.callWsAPI(ctx, r, opts...)
+	}
+	err := c.parseRequest(r, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -461,18 +762,18 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.logger().Debug("request", "method", req.Method, "url", req.URL.String(), "header", req.Header)
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
 	res, err := f(req)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	defer func() {
 		cerr := res.Body.Close()
@@ -482,17 +783,30 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 			err = cerr
 		}
 	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
+	c.logger().Debug("response", "status", res.StatusCode, "header", res.Header, "body", string(data))
+
+	r.statusCode = res.StatusCode
+	r.rateLimits = common.RateLimitsFromHeader(res.Header)
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(r.rateLimits...)
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			c.RateLimiter.Backoff(common.RetryAfterFromHeader(res.Header))
+		}
+	}
 	if res.StatusCode >= http.StatusBadRequest {
 		apiErr := new(common.APIError)
 		e := json.Unmarshal(data, apiErr)
 		if e != nil {
 			apiErr.Code = int64(res.StatusCode)
 			apiErr.Message = string(data)
-			c.debug("failed to unmarshal json: %s", e)
+			c.logger().Debug("failed to unmarshal json", "error", e)
+		}
+		apiErr.StatusCode = res.StatusCode
+		apiErr.Method = r.method
+		apiErr.Endpoint = r.endpoint
+		apiErr.RateLimits = r.rateLimits
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			apiErr.RetryAfter = common.RetryAfterFromHeader(res.Header)
 		}
 		return nil, nil, apiErr
 	}
@@ -520,6 +834,21 @@ func (c *Client) NewSetServerTimeService() *SetServerTimeService {
 	return &SetServerTimeService{c: c}
 }
 
+// NewSessionLogonService init session logon service of WebSocket API
+func (c *Client) NewSessionLogonService() *SessionLogonService {
+	return &SessionLogonService{c: c}
+}
+
+// NewSessionStatusService init session status service of WebSocket API
+func (c *Client) NewSessionStatusService() *SessionStatusService {
+	return &SessionStatusService{c: c}
+}
+
+// NewSessionLogoutService init session logout service of WebSocket API
+func (c *Client) NewSessionLogoutService() *SessionLogoutService {
+	return &SessionLogoutService{c: c}
+}
+
 // NewDepthService init depth service
 func (c *Client) NewDepthService() *DepthService {
 	return &DepthService{c: c}
@@ -575,6 +904,26 @@ func (c *Client) NewCancelOCOService() *CancelOCOService {
 	return &CancelOCOService{c: c}
 }
 
+// NewCreateOrderListOCOService init creating OCO order list service
+func (c *Client) NewCreateOrderListOCOService() *CreateOrderListOCOService {
+	return &CreateOrderListOCOService{c: c}
+}
+
+// NewCreateOrderListOTOService init creating OTO order list service
+func (c *Client) NewCreateOrderListOTOService() *CreateOrderListOTOService {
+	return &CreateOrderListOTOService{c: c}
+}
+
+// NewCreateOrderListOTOCOService init creating OTOCO order list service
+func (c *Client) NewCreateOrderListOTOCOService() *CreateOrderListOTOCOService {
+	return &CreateOrderListOTOCOService{c: c}
+}
+
+// NewCreateSOROrderService init creating SOR order service
+func (c *Client) NewCreateSOROrderService() *CreateSOROrderService {
+	return &CreateSOROrderService{c: c}
+}
+
 // NewGetOrderService init get order service
 func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
@@ -585,6 +934,11 @@ func (c *Client) NewCancelOrderService() *CancelOrderService {
 	return &CancelOrderService{c: c}
 }
 
+// NewCancelReplaceOrderService init cancel replace order service
+func (c *Client) NewCancelReplaceOrderService() *CancelReplaceOrderService {
+	return &CancelReplaceOrderService{c: c}
+}
+
 // NewCancelOpenOrdersService init cancel open orders service
 func (c *Client) NewCancelOpenOrdersService() *CancelOpenOrdersService {
 	return &CancelOpenOrdersService{c: c}
@@ -868,6 +1222,61 @@ func (c *Client) NewListFuturesTransferService() *ListFuturesTransferService {
 	return &ListFuturesTransferService{c: c}
 }
 
+// NewCreateFuturesAlgoTwapOrderService init creating futures TWAP algo order service
+func (c *Client) NewCreateFuturesAlgoTwapOrderService() *CreateFuturesAlgoTwapOrderService {
+	return &CreateFuturesAlgoTwapOrderService{c: c}
+}
+
+// NewCreateFuturesAlgoVpOrderService init creating futures VP algo order service
+func (c *Client) NewCreateFuturesAlgoVpOrderService() *CreateFuturesAlgoVpOrderService {
+	return &CreateFuturesAlgoVpOrderService{c: c}
+}
+
+// NewCancelFuturesAlgoOrderService init canceling futures algo order service
+func (c *Client) NewCancelFuturesAlgoOrderService() *CancelFuturesAlgoOrderService {
+	return &CancelFuturesAlgoOrderService{c: c}
+}
+
+// NewListFuturesAlgoOpenOrdersService init listing futures open algo orders service
+func (c *Client) NewListFuturesAlgoOpenOrdersService() *ListFuturesAlgoOpenOrdersService {
+	return &ListFuturesAlgoOpenOrdersService{c: c}
+}
+
+// NewListFuturesAlgoHistoricalOrdersService init listing futures historical algo orders service
+func (c *Client) NewListFuturesAlgoHistoricalOrdersService() *ListFuturesAlgoHistoricalOrdersService {
+	return &ListFuturesAlgoHistoricalOrdersService{c: c}
+}
+
+// NewListFuturesAlgoSubOrdersService init listing futures algo sub orders service
+func (c *Client) NewListFuturesAlgoSubOrdersService() *ListFuturesAlgoSubOrdersService {
+	return &ListFuturesAlgoSubOrdersService{c: c}
+}
+
+// NewCreateSpotAlgoTwapOrderService init creating spot TWAP algo order service
+func (c *Client) NewCreateSpotAlgoTwapOrderService() *CreateSpotAlgoTwapOrderService {
+	return &CreateSpotAlgoTwapOrderService{c: c}
+}
+
+// NewCancelSpotAlgoOrderService init canceling spot algo order service
+func (c *Client) NewCancelSpotAlgoOrderService() *CancelSpotAlgoOrderService {
+	return &CancelSpotAlgoOrderService{c: c}
+}
+
+// NewListSpotAlgoOpenOrdersService init listing spot open algo orders service
+func (c *Client) NewListSpotAlgoOpenOrdersService() *ListSpotAlgoOpenOrdersService {
+	return &ListSpotAlgoOpenOrdersService{c: c}
+}
+
+// NewListSpotAlgoHistoricalOrdersService init listing spot historical algo orders service
+func (c *Client) NewListSpotAlgoHistoricalOrdersService() *ListSpotAlgoHistoricalOrdersService {
+	return &ListSpotAlgoHistoricalOrdersService{c: c}
+}
+
+// NewListSpotAlgoSubOrdersService init listing spot algo sub orders service
+func (c *Client) NewListSpotAlgoSubOrdersService() *ListSpotAlgoSubOrdersService {
+	return &ListSpotAlgoSubOrdersService{c: c}
+}
+
 // NewListDustLogService init list dust log service
 func (c *Client) NewListDustLogService() *ListDustLogService {
 	return &ListDustLogService{c: c}
@@ -1088,6 +1497,11 @@ func (c *Client) NewSubAccountFuturesSummaryV1Service() *SubAccountFuturesSummar
 	return &SubAccountFuturesSummaryV1Service{c: c}
 }
 
+// NewSubAccountFuturesTransferV1Service Futures Transfer for Sub-account (For Master Account)
+func (c *Client) NewSubAccountFuturesTransferV1Service() *SubAccountFuturesTransferV1Service {
+	return &SubAccountFuturesTransferV1Service{c: c}
+}
+
 // NewSimpleEarnAccountService init simple-earn account service
 func (c *Client) NewSimpleEarnAccountService() *SimpleEarnAccountService {
 	return &SimpleEarnAccountService{c: c}
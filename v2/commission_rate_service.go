@@ -31,7 +31,7 @@ func (s *CommissionRateService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(CommissionRate)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,311 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point decimal number, used for the prices and
+// quantities which Binance sends as strings. The value is coef * 10^-scale.
+// The zero value is 0, and a Decimal is immutable: all the operations
+// return a new one.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+var bigTen = big.NewInt(10)
+
+// maxDecimalScale bounds the exponent and the number of decimals of a parsed
+// decimal, far above the precision of Binance, so that a string such as
+// "1e2000000000" is not expanded in memory
+const maxDecimalScale = 1000
+
+// NewDecimal create the decimal value * 10^-scale, e.g. NewDecimal(123, 2) is 1.23
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(value), scale: scale}
+}
+
+// ParseDecimal parse a decimal string such as "-0.00100000" or "1.5e-8".
+// The number of decimals is kept, String returns "-0.00100000" again. The
+// exponent and the number of decimals are limited to 1000.
+func ParseDecimal(s string) (Decimal, error) {
+	str := s
+	exp := int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		str = str[:i]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := intPart + fracPart
+	if strings.TrimLeft(digits, "+-") == "" || strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale := int64(len(fracPart)) - exp
+	if exp > maxDecimalScale || exp < -maxDecimalScale || scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q is out of range", s)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is invalid
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromString parse s, an empty or invalid string is 0. It is used by
+// the decimal accessors of the response types.
+func DecimalFromString(s string) Decimal {
+	d, _ := ParseDecimal(s)
+	return d
+}
+
+// NewDecimalFromFloat convert f with the shortest representation which
+// parses back to f. Prefer ParseDecimal for values received from Binance.
+func NewDecimalFromFloat(f float64) Decimal {
+	return DecimalFromString(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) bigInt() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale return the coefficient of d with the given scale, which must not
+// be lower than the scale of d
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.bigInt()
+	}
+	return new(big.Int).Mul(d.bigInt(), pow10(scale-d.scale))
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// Scale return the number of decimals
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Add return d + d2
+func (d Decimal) Add(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return Decimal{coef: new(big.Int).Add(d.rescale(scale), d2.rescale(scale)), scale: scale}
+}
+
+// Sub return d - d2
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	scale := maxScale(d, d2)
+	return Decimal{coef: new(big.Int).Sub(d.rescale(scale), d2.rescale(scale)), scale: scale}
+}
+
+// Mul return d * d2
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.bigInt(), d2.bigInt()), scale: d.scale + d2.scale}
+}
+
+// Div return d / d2 rounded half away from zero to scale decimals, it
+// panics if d2 is 0
+func (d Decimal) Div(d2 Decimal, scale int32) Decimal {
+	if d2.IsZero() {
+		panic("decimal division by zero")
+	}
+	// d / d2 * 10^scale = d.coef * 10^(scale - d.scale + d2.scale) / d2.coef
+	num := new(big.Int).Set(d.bigInt())
+	den := new(big.Int).Set(d2.bigInt())
+	if shift := scale - d.scale + d2.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: divRound(num, den), scale: scale}
+}
+
+// divRound return num / den rounded half away from zero
+func divRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	r.Abs(r).Mul(r, big.NewInt(2))
+	if r.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Neg return -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.bigInt()), scale: d.scale}
+}
+
+// Abs return |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.bigInt()), scale: d.scale}
+}
+
+// Sign return -1, 0 or 1
+func (d Decimal) Sign() int {
+	return d.bigInt().Sign()
+}
+
+// IsZero return true if d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp return -1, 0 or 1 if d is lower than, equal to or greater than d2
+func (d Decimal) Cmp(d2 Decimal) int {
+	scale := maxScale(d, d2)
+	return d.rescale(scale).Cmp(d2.rescale(scale))
+}
+
+// Equal return true if d and d2 are the same number, whatever their scale
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// LessThan return true if d < d2
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// GreaterThan return true if d > d2
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// Round round d half away from zero to scale decimals
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+	return Decimal{coef: divRound(d.bigInt(), pow10(d.scale-scale)), scale: scale}
+}
+
+// Truncate round d toward zero to scale decimals
+func (d Decimal) Truncate(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+	return Decimal{coef: new(big.Int).Quo(d.bigInt(), pow10(d.scale-scale)), scale: scale}
+}
+
+// FloorToStep round d down to a multiple of step, as the tick size of the
+// PRICE_FILTER or the step size of the LOT_SIZE filter. The result has the
+// decimals of step.
+func (d Decimal) FloorToStep(step Decimal) Decimal {
+	return d.toStep(step, -1)
+}
+
+// CeilToStep round d up to a multiple of step
+func (d Decimal) CeilToStep(step Decimal) Decimal {
+	return d.toStep(step, 1)
+}
+
+// RoundToStep round d to the nearest multiple of step, half away from zero
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	return d.toStep(step, 0)
+}
+
+func (d Decimal) toStep(step Decimal, mode int) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	scale := maxScale(d, step)
+	num, den := d.rescale(scale), step.rescale(scale)
+	var n *big.Int
+	switch mode {
+	case 0:
+		n = divRound(num, den)
+	default:
+		var m *big.Int
+		n, m = new(big.Int).DivMod(num, den, new(big.Int))
+		if mode > 0 && m.Sign() != 0 {
+			n.Add(n, big.NewInt(1))
+		}
+	}
+	// the result has the decimals of the step
+	return Decimal{coef: n.Mul(n, step.bigInt()), scale: step.scale}
+}
+
+// Float64 return the nearest float64, which may lose precision
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String return d with Scale decimals, without exponent
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.bigInt()).String()
+	if d.scale < 0 && s != "0" {
+		s += strings.Repeat("0", int(-d.scale))
+	}
+	if d.scale > 0 {
+		if len(s) <= int(d.scale) {
+			s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// MarshalJSON encode d as a string, as Binance does
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decode a string or a number, null and "" are 0
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for s, e := range map[string]string{
+		"0":           "0",
+		"-0.00100000": "-0.00100000",
+		"123.45":      "123.45",
+		"+1.5":        "1.5",
+		".5":          "0.5",
+		"-.5":         "-0.5",
+		"10.":         "10",
+		"1.5e-8":      "0.000000015",
+		"1.5E3":       "1500",
+		"100000000000000000000.000000000000000001": "100000000000000000000.000000000000000001",
+	} {
+		d, err := ParseDecimal(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, e, d.String(), s)
+	}
+	for _, s := range []string{"", ".", "-", "abc", "1.2.3", "1.-2", "1e", "1-2"} {
+		_, err := ParseDecimal(s)
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{"1e2000000000", "1e-2000000000", "1e1001", "1e-1001", "0." + strings.Repeat("1", 1001), "1.5e-1000"} {
+		_, err := ParseDecimal(s)
+		assert.EqualError(t, err, fmt.Sprintf("decimal %q is out of range", s))
+	}
+	d, err := ParseDecimal("1e1000")
+	require.NoError(t, err)
+	assert.Equal(t, "1"+strings.Repeat("0", 1000), d.String())
+	assert.True(t, DecimalFromString("").IsZero())
+	assert.Panics(t, func() { MustParseDecimal("x") })
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.33333333", a.Div(MustParseDecimal("0.3"), 8).String())
+	assert.Equal(t, "-0.66666667", b.Neg().Div(MustParseDecimal("0.3"), 8).String())
+	assert.Equal(t, "2", NewDecimal(4, 0).Div(NewDecimal(2, 0), 0).String())
+	assert.Equal(t, "0.1", a.Neg().Abs().String())
+	assert.Equal(t, "1.23", NewDecimal(123, 2).String())
+	assert.Equal(t, "1200", NewDecimal(12, -2).String())
+	assert.Equal(t, "0.1", NewDecimalFromFloat(0.1).String())
+	assert.Equal(t, 0.3, a.Add(b).Float64())
+	assert.Panics(t, func() { a.Div(Decimal{}, 2) })
+
+	var zero Decimal
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, "0.1", zero.Add(a).String())
+}
+
+func TestDecimalCompare(t *testing.T) {
+	a := MustParseDecimal("1.50")
+	b := MustParseDecimal("1.5")
+	assert.True(t, a.Equal(b))
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.True(t, a.LessThan(MustParseDecimal("1.51")))
+	assert.True(t, a.GreaterThan(MustParseDecimal("-2")))
+	assert.Equal(t, -1, a.Neg().Sign())
+	assert.Equal(t, int32(2), a.Scale())
+}
+
+func TestDecimalRound(t *testing.T) {
+	d := MustParseDecimal("-1.2350")
+	assert.Equal(t, "-1.24", d.Round(2).String())
+	assert.Equal(t, "-1.23", d.Truncate(2).String())
+	assert.Equal(t, "-1.235000", d.Round(6).String())
+	assert.Equal(t, "1.24", d.Neg().Round(2).String())
+
+	tick := MustParseDecimal("0.01000000")
+	price := MustParseDecimal("123.4567")
+	assert.Equal(t, "123.45000000", price.FloorToStep(tick).String())
+	assert.Equal(t, "123.46000000", price.CeilToStep(tick).String())
+	assert.Equal(t, "123.46000000", price.RoundToStep(tick).String())
+	assert.Equal(t, "-123.46000000", price.Neg().FloorToStep(tick).String())
+
+	step := MustParseDecimal("0.5")
+	assert.Equal(t, "1.5", MustParseDecimal("1.5").CeilToStep(step).String())
+	assert.Equal(t, "2.0", MustParseDecimal("1.75").RoundToStep(step).String())
+	assert.Equal(t, "1.7", MustParseDecimal("1.7").FloorToStep(Decimal{}).String())
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Price    Decimal `json:"price"`
+		Quantity Decimal `json:"qty"`
+		Empty    Decimal `json:"empty"`
+		Null     Decimal `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"price":"0.00100000","qty":12.5,"empty":"","null":null}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00100000", v.Price.String())
+	assert.Equal(t, "12.5", v.Quantity.String())
+	assert.True(t, v.Empty.IsZero())
+	assert.True(t, v.Null.IsZero())
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"price":"0.00100000","qty":"12.5","empty":"0","null":"0"}`, string(data))
+	assert.Error(t, json.Unmarshal([]byte(`{"price":"x"}`), &v))
+}
+
+func TestPriceLevelParseDecimal(t *testing.T) {
+	p := PriceLevel{Price: "0.10000000", Quantity: "1.5"}
+	price, quantity, err := p.ParseDecimal()
+	require.NoError(t, err)
+	assert.Equal(t, "0.10000000", price.String())
+	assert.Equal(t, "1.5", quantity.String())
+
+	p.Quantity = "x"
+	_, _, err = p.ParseDecimal()
+	assert.Error(t, err)
+}
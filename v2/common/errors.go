@@ -1,13 +1,56 @@
 package common
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The categories of the errors, to check with errors.Is. They match the
+// *APIError classified by Category, the *RateLimitError of a rate limit
+// governor and the *OrderFilterError of an order validator.
+var (
+	// ErrRateLimited means too many requests or orders (429, -1003, -1015)
+	ErrRateLimited = errors.New("rate limited")
+	// ErrIPBanned means the IP is banned after ignoring 429 responses (418)
+	ErrIPBanned = errors.New("ip banned")
+	// ErrTimestampOutsideRecvWindow means the timestamp of the request is
+	// ahead of the server time or outside of recvWindow (-1021)
+	ErrTimestampOutsideRecvWindow = errors.New("timestamp outside of recvWindow")
+	// ErrInsufficientBalance means the balance or the margin is insufficient
+	// for the order (-2010, -2018, -2019)
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrFilterFailure means the order is rejected by a filter of the symbol
+	// (-1013, -4013, -4014, -4023, -4164)
+	ErrFilterFailure = errors.New("filter failure")
+	// ErrUnknownOrder means the order does not exist (-2011, -2013)
+	ErrUnknownOrder = errors.New("unknown order")
+	// ErrUnknownExecutionStatus means the request timed out in the server and
+	// may have been executed, it should be checked before retrying (-1007)
+	ErrUnknownExecutionStatus = errors.New("unknown execution status")
 )
 
 // APIError define API error when response status is 4xx or 5xx
 type APIError struct {
 	Code    int64  `json:"code"`
 	Message string `json:"msg"`
+	// Data is the detail of the error, e.g. the results of a partially
+	// failed cancel-replace
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// StatusCode is the HTTP status, or the status of the WebSocket API
+	// response
+	StatusCode int `json:"-"`
+	// Method is the HTTP method, or the WebSocket API method
+	Method   string `json:"-"`
+	Endpoint string `json:"-"`
+	// RateLimits is the usage reported by the response
+	RateLimits []RateLimit `json:"-"`
+	// RetryAfter is the backoff required by a 429 or 418 response
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error return error code and message
@@ -15,8 +58,184 @@ func (e APIError) Error() string {
 	return fmt.Sprintf("<APIError> code=%d, msg=%s", e.Code, e.Message)
 }
 
+// Category return the category of the error, one of the ErrXxx variables,
+// or nil if the code is not classified
+func (e APIError) Category() error {
+	switch {
+	case e.StatusCode == http.StatusTeapot || e.Code == -1003 && strings.Contains(e.Message, "banned"):
+		return ErrIPBanned
+	case e.StatusCode == http.StatusTooManyRequests || e.Code == -1003 || e.Code == -1015:
+		return ErrRateLimited
+	case e.Code == -1021:
+		return ErrTimestampOutsideRecvWindow
+	case e.Code == -1007:
+		return ErrUnknownExecutionStatus
+	case e.Code == -2018 || e.Code == -2019 ||
+		e.Code == -2010 && strings.Contains(strings.ToLower(e.Message), "insufficient"):
+		return ErrInsufficientBalance
+	case e.Code == -1013 || e.Code == -4013 || e.Code == -4014 || e.Code == -4023 || e.Code == -4164:
+		return ErrFilterFailure
+	case e.Code == -2013 || e.Code == -2011 && strings.Contains(strings.ToLower(e.Message), "unknown order"):
+		return ErrUnknownOrder
+	}
+	return nil
+}
+
+// Is return true if target is the category of the error
+func (e APIError) Is(target error) bool {
+	category := e.Category()
+	return category != nil && category == target
+}
+
 // IsAPIError check if e is an API error
 func IsAPIError(e error) bool {
-	_, ok := e.(*APIError)
-	return ok
+	var target *APIError
+	return errors.As(e, &target)
+}
+
+// RequestError is returned when a request fails without an error response
+// of the server, e.g. a transport failure or a timeout of the WebSocket API.
+// Err is the cause, errors.Is(err, context.DeadlineExceeded) tells a timeout.
+type RequestError struct {
+	// Method is the HTTP method, or the WebSocket API method
+	Method   string
+	Endpoint string
+	Err      error
+}
+
+// Error return the request and the cause
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("<RequestError> method=%s, endpoint=%s, %v", e.Method, e.Endpoint, e.Err)
+}
+
+// Unwrap return the cause
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// IsRequestError check if e is a request error
+func IsRequestError(e error) bool {
+	var target *RequestError
+	return errors.As(e, &target)
+}
+
+// DecodeError is returned when the response of a request cannot be decoded.
+// Err is the cause, e.g. a *json.UnmarshalTypeError.
+type DecodeError struct {
+	// Method is the HTTP method, or the WebSocket API method
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Err        error
+}
+
+// Error return the request and the cause
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("<DecodeError> method=%s, endpoint=%s, status=%d, %v", e.Method, e.Endpoint, e.StatusCode, e.Err)
+}
+
+// Unwrap return the cause
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsDecodeError check if e is a decode error
+func IsDecodeError(e error) bool {
+	var target *DecodeError
+	return errors.As(e, &target)
+}
+
+// WsConnectionClosedError define the error of a pending WebSocket API request
+// whose connection is closed before the response arrives
+type WsConnectionClosedError struct {
+	Method string
+	// Err is the read error which closed the connection, nil if it is closed by the client
+	Err error
 }
+
+// Error return the request method and the reason
+func (e *WsConnectionClosedError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("<WsConnectionClosedError> method=%s, connection closed", e.Method)
+	}
+	return fmt.Sprintf("<WsConnectionClosedError> method=%s, %v", e.Method, e.Err)
+}
+
+// Unwrap return the read error of the connection
+func (e *WsConnectionClosedError) Unwrap() error {
+	return e.Err
+}
+
+// IsWsConnectionClosedError check if e is a WebSocket API connection closed error
+func IsWsConnectionClosedError(e error) bool {
+	var target *WsConnectionClosedError
+	return errors.As(e, &target)
+}
+
+// OrderFilterError is returned when an order would be rejected by a filter
+// of the symbol
+type OrderFilterError struct {
+	Symbol string
+	// Filter is PRICE_FILTER, LOT_SIZE, MARKET_LOT_SIZE, NOTIONAL (also for
+	// MIN_NOTIONAL), PERCENT_PRICE (also for PERCENT_PRICE_BY_SIDE),
+	// MAX_NUM_ORDERS or MAX_NUM_ALGO_ORDERS
+	Filter string
+	// Field is the checked order field: price, stopPrice, quantity or
+	// notional, or the count of open orders or algoOrders
+	Field  string
+	Value  string
+	Reason string
+}
+
+// Error return the filter and the reason
+func (e *OrderFilterError) Error() string {
+	return fmt.Sprintf("<OrderFilterError> symbol=%s, filter=%s, %s %s %s", e.Symbol, e.Filter, e.Field, e.Value, e.Reason)
+}
+
+// Is return true for ErrFilterFailure
+func (e *OrderFilterError) Is(target error) bool {
+	return target == ErrFilterFailure
+}
+
+// IsOrderFilterError check if e is an order filter error
+func IsOrderFilterError(e error) bool {
+	var target *OrderFilterError
+	return errors.As(e, &target)
+}
+
+// RateLimitError is returned by a RateLimitGovernor with FailFast when a
+// call would exceed a limit, or during the backoff after a 429 or 418
+// response. Type is empty for the backoff.
+type RateLimitError struct {
+	Type       string
+	Interval   time.Duration
+	Limit      int
+	Count      int
+	RetryAfter time.Duration
+}
+
+// Error return the exceeded limit and the delay before retrying
+func (e *RateLimitError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("<RateLimitError> backing off after 429/418, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("<RateLimitError> %s %s limit %d would be exceeded, count=%d, retry after %s",
+		e.Type, e.Interval, e.Limit, e.Count, e.RetryAfter)
+}
+
+// Is return true for ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// IsRateLimitError check if e is a rate limit error
+func IsRateLimitError(e error) bool {
+	var target *RateLimitError
+	return errors.As(e, &target)
+}
+
+// ErrReconnectStopped is returned when reconnecting is stopped by closing the client
+var ErrReconnectStopped = errors.New("reconnect stopped by client")
+
+// ErrStreamDisconnected is given to OnDisconnect when a resilient stream drops
+var ErrStreamDisconnected = errors.New("stream disconnected")
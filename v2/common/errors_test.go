@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorCategory(t *testing.T) {
+	tests := []struct {
+		err      *APIError
+		category error
+	}{
+		{&APIError{Code: -1003, Message: "Too many requests.", StatusCode: http.StatusTooManyRequests}, ErrRateLimited},
+		{&APIError{Code: -1015, Message: "Too many new orders."}, ErrRateLimited},
+		{&APIError{Code: -1003, Message: "Way too many requests; IP banned until 1659146400000.", StatusCode: http.StatusTeapot}, ErrIPBanned},
+		{&APIError{Code: -1003, Message: "Way too many requests; IP banned until 1659146400000."}, ErrIPBanned},
+		{&APIError{Code: -1021, Message: "Timestamp for this request is outside of the recvWindow."}, ErrTimestampOutsideRecvWindow},
+		{&APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, ErrInsufficientBalance},
+		{&APIError{Code: -2019, Message: "Margin is insufficient."}, ErrInsufficientBalance},
+		{&APIError{Code: -1013, Message: "Filter failure: LOT_SIZE"}, ErrFilterFailure},
+		{&APIError{Code: -4014, Message: "Price not increased by tick size."}, ErrFilterFailure},
+		{&APIError{Code: -2011, Message: "Unknown order sent."}, ErrUnknownOrder},
+		{&APIError{Code: -2013, Message: "Order does not exist."}, ErrUnknownOrder},
+		{&APIError{Code: -1007, Message: "Timeout waiting for response from backend server. Send status unknown; execution status unknown."}, ErrUnknownExecutionStatus},
+		{&APIError{Code: -2010, Message: "Order would immediately match and take."}, nil},
+		{&APIError{Code: -2011, Message: "Order was canceled or expired"}, nil},
+		{&APIError{Code: -1121, Message: "Invalid symbol."}, nil},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.category, test.err.Category(), test.err.Message)
+		if test.category != nil {
+			var err error = fmt.Errorf("create order: %w", test.err)
+			assert.True(t, errors.Is(err, test.category), test.err.Message)
+		}
+	}
+}
+
+func TestErrorCategories(t *testing.T) {
+	assert.True(t, errors.Is(&RateLimitError{RetryAfter: 1}, ErrRateLimited))
+	assert.True(t, errors.Is(&OrderFilterError{Filter: "LOT_SIZE"}, ErrFilterFailure))
+	assert.False(t, errors.Is(&OrderFilterError{Filter: "LOT_SIZE"}, ErrRateLimited))
+}
+
+func TestRequestError(t *testing.T) {
+	err := error(&RequestError{Method: "order.place", Endpoint: "/api/v3/order", Err: context.DeadlineExceeded})
+	assert.Equal(t, "<RequestError> method=order.place, endpoint=/api/v3/order, context deadline exceeded", err.Error())
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, IsRequestError(fmt.Errorf("wrapped: %w", err)))
+	assert.False(t, IsRequestError(&APIError{}))
+}
+
+func TestIsAPIError(t *testing.T) {
+	err := &APIError{Code: -1021}
+	assert.True(t, IsAPIError(err))
+	assert.True(t, IsAPIError(fmt.Errorf("create order: %w", err)))
+	assert.False(t, IsAPIError(&RequestError{Err: context.Canceled}))
+	assert.True(t, IsOrderFilterError(fmt.Errorf("wrapped: %w", &OrderFilterError{})))
+	assert.True(t, IsRateLimitError(fmt.Errorf("wrapped: %w", &RateLimitError{})))
+}
+
+func TestDecodeError(t *testing.T) {
+	cause := json.Unmarshal([]byte(`{"orderId": "1"}`), &struct {
+		OrderID int64 `json:"orderId"`
+	}{})
+	err := error(&DecodeError{Method: "GET", Endpoint: "/api/v3/order", StatusCode: http.StatusOK, Err: cause})
+	assert.Equal(t, "<DecodeError> method=GET, endpoint=/api/v3/order, status=200, "+cause.Error(), err.Error())
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr))
+	assert.True(t, IsDecodeError(fmt.Errorf("wrapped: %w", err)))
+	assert.False(t, IsDecodeError(&APIError{}))
+}
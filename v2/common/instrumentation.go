@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Instrumentation receives the measures of the clients and of the websocket
+// streams, e.g. to export metrics or traces. The methods are called
+// concurrently.
+type Instrumentation interface {
+	// StartSpan is called before an API call is sent, the returned span is
+	// finished with the result of the call. The returned context is used to
+	// send the call, e.g. to propagate a trace.
+	StartSpan(ctx context.Context, start *SpanStart) (context.Context, Span)
+	// WsConnect is called when a websocket is dialed, err is the dial error
+	WsConnect(endpoint string, err error)
+	// WsDisconnect is called when a connected websocket is closed, err is
+	// nil if it is closed by the client
+	WsDisconnect(endpoint string, err error)
+	// WsReconnect is called after each attempt to reconnect the WebSocket
+	// API, err is nil if the attempt succeeded
+	WsReconnect(endpoint string, attempt int, err error)
+}
+
+// Span is an API call started by Instrumentation.StartSpan
+type Span interface {
+	Finish(end *SpanEnd)
+}
+
+// SpanStart describe an API call
+type SpanStart struct {
+	// BaseURL is the base URL of the API, the one of the WebSocket API if the
+	// call is sent over it
+	BaseURL  string
+	Method   string
+	Endpoint string
+	// WsMethod is set if the call is sent over the WebSocket API
+	WsMethod  string
+	WebSocket bool
+	Time      time.Time
+}
+
+// SpanEnd is the result of an API call
+type SpanEnd struct {
+	// StatusCode is the HTTP status, or the status of the WebSocket API
+	// response. It is 0 if no response is received.
+	StatusCode int
+	// ErrorCode is the code of the *APIError, 0 on success
+	ErrorCode int64
+	// RateLimits are the usage reported by the response
+	RateLimits []RateLimit
+	Err        error
+	Time       time.Time
+}
+
+// NewSpanEnd return the result of a call, the status and the usage of an
+// *APIError are used if they are known
+func NewSpanEnd(statusCode int, limits []RateLimit, err error) *SpanEnd {
+	end := &SpanEnd{StatusCode: statusCode, RateLimits: limits, Err: err, Time: time.Now()}
+	apiErr := new(APIError)
+	if errors.As(err, &apiErr) {
+		end.ErrorCode = apiErr.Code
+		if apiErr.StatusCode != 0 {
+			end.StatusCode = apiErr.StatusCode
+		}
+		if len(apiErr.RateLimits) > 0 {
+			end.RateLimits = apiErr.RateLimits
+		}
+	}
+	return end
+}
+
+// NoopInstrumentation ignore all the measures, it is the default
+type NoopInstrumentation struct{}
+
+type noopSpan struct{}
+
+func (noopSpan) Finish(end *SpanEnd) {}
+
+// StartSpan implements Instrumentation
+func (NoopInstrumentation) StartSpan(ctx context.Context, start *SpanStart) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// WsConnect implements Instrumentation
+func (NoopInstrumentation) WsConnect(endpoint string, err error) {}
+
+// WsDisconnect implements Instrumentation
+func (NoopInstrumentation) WsDisconnect(endpoint string, err error) {}
+
+// WsReconnect implements Instrumentation
+func (NoopInstrumentation) WsReconnect(endpoint string, attempt int, err error) {}
+
+// InstrumentationOrNoop return i, or NoopInstrumentation if it is nil
+func InstrumentationOrNoop(i Instrumentation) Instrumentation {
+	if i == nil {
+		return NoopInstrumentation{}
+	}
+	return i
+}
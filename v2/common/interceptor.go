@@ -0,0 +1,67 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SecType define the security type of an APIRequest
+type SecType string
+
+// SecType values
+const (
+	SecTypeNone   SecType = "NONE"
+	SecTypeAPIKey SecType = "API_KEY"
+	SecTypeSigned SecType = "SIGNED"
+)
+
+// APIRequest is the logical request seen by the interceptors, before it is
+// signed. The changes of an interceptor are sent, Query and Form are shared
+// with the request of the client.
+type APIRequest struct {
+	Method   string
+	Endpoint string
+	// WsMethod is the method of the WebSocket API, empty if the endpoint is
+	// not available over the WebSocket API
+	WsMethod string
+	// WebSocket is true if the request is sent over the WebSocket API
+	WebSocket  bool
+	SecType    SecType
+	Query      url.Values
+	Form       url.Values
+	Header     http.Header
+	RecvWindow int64
+}
+
+// APIResponse is the response of an APIRequest
+type APIResponse struct {
+	// Data is the body of the response, or the result of a WebSocket API
+	// response
+	Data []byte
+	// StatusCode is the HTTP status, or the status of the WebSocket API
+	// response
+	StatusCode int
+	// RateLimits are the usage reported by the response
+	RateLimits []RateLimit
+}
+
+// Invoker send an APIRequest
+type Invoker func(ctx context.Context, req *APIRequest) (*APIResponse, error)
+
+// Interceptor wraps the calls of a client, e.g. for tracing, metrics or
+// auditing. It may change the request before calling next, and may return
+// its own response or error without calling next.
+type Interceptor func(ctx context.Context, req *APIRequest, next Invoker) (*APIResponse, error)
+
+// ChainInterceptors return an invoker which calls the interceptors in order,
+// the first one is the outermost
+func ChainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+			return interceptor(ctx, req, next)
+		}
+	}
+	return invoker
+}
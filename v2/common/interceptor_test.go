@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, req *APIRequest, next Invoker) (*APIResponse, error) {
+			calls = append(calls, name)
+			req.Endpoint += "/" + name
+			return next(ctx, req)
+		}
+	}
+	invoker := ChainInterceptors([]Interceptor{record("a"), record("b")}, func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+		calls = append(calls, "invoker")
+		return &APIResponse{Data: []byte(req.Endpoint)}, nil
+	})
+	res, err := invoker(context.Background(), &APIRequest{Endpoint: "/api"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "invoker"}, calls)
+	assert.Equal(t, "/api/a/b", string(res.Data))
+}
+
+func TestChainInterceptorsShortCircuit(t *testing.T) {
+	errFault := errors.New("fault")
+	invoker := ChainInterceptors([]Interceptor{
+		func(ctx context.Context, req *APIRequest, next Invoker) (*APIResponse, error) {
+			return nil, errFault
+		},
+	}, func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+		t.Fatal("invoker called")
+		return nil, nil
+	})
+	_, err := invoker(context.Background(), &APIRequest{})
+	assert.ErrorIs(t, err, errFault)
+}
+
+func TestChainInterceptorsEmpty(t *testing.T) {
+	invoker := ChainInterceptors(nil, func(ctx context.Context, req *APIRequest) (*APIResponse, error) {
+		return &APIResponse{Data: []byte("ok")}, nil
+	})
+	res, err := invoker(context.Background(), &APIRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(res.Data))
+}
@@ -0,0 +1,237 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults of ListenKeyStream
+const (
+	DefaultListenKeyKeepalive = 30 * time.Minute
+	// connections are cut by Binance after 24h, renew them a bit earlier
+	DefaultMaxConnectionAge = 23*time.Hour + 30*time.Minute
+)
+
+// ErrListenKeyStreamStarted is returned when a ListenKeyStream is started twice
+var ErrListenKeyStreamStarted = errors.New("listen key stream is already started")
+
+// ListenKeyStream keep a user data stream alive. It owns the listen key:
+// creates it, extends it periodically and deletes it when stopped. The
+// websocket connection is re-established when it drops, when the listen key
+// expires and before Binance cuts it after 24h. These planned renewals
+// connect the new connection before closing the old one, so no event is lost
+// but the events received in between may be delivered twice.
+//
+// It is product agnostic, the user data stream types of each client fill
+// the functions with their own services.
+type ListenKeyStream struct {
+	// StartListenKey create a listen key, or return the active one
+	StartListenKey func(ctx context.Context) (string, error)
+	// KeepaliveListenKey extend the validity of the listen key
+	KeepaliveListenKey func(ctx context.Context, listenKey string) error
+	// CloseListenKey delete the listen key
+	CloseListenKey func(ctx context.Context, listenKey string) error
+	// Serve connect the user data stream of the listen key, expired must be
+	// called when a listenKeyExpired event is received
+	Serve func(listenKey string, expired func()) (doneC, stopC chan struct{}, err error)
+
+	// KeepaliveInterval defaults to DefaultListenKeyKeepalive
+	KeepaliveInterval time.Duration
+	// MaxConnectionAge defaults to DefaultMaxConnectionAge
+	MaxConnectionAge time.Duration
+	// ReconnectPolicy defaults to DefaultReconnectPolicy
+	ReconnectPolicy *ReconnectPolicy
+	// ErrHandler receives the errors of keepalive and reconnect, may be nil
+	ErrHandler func(err error)
+
+	lock      sync.Mutex
+	listenKey string
+	started   bool
+	stopC     chan struct{}
+	doneC     chan struct{}
+	expiredC  chan struct{}
+	stopOnce  sync.Once
+}
+
+// Start create the listen key and connect, the stream is kept alive in
+// background until Stop is called or reconnecting is given up
+func (s *ListenKeyStream) Start(ctx context.Context) error {
+	s.lock.Lock()
+	if s.started {
+		s.lock.Unlock()
+		return ErrListenKeyStreamStarted
+	}
+	s.started = true
+	s.stopC = make(chan struct{})
+	s.doneC = make(chan struct{})
+	s.expiredC = make(chan struct{}, 1)
+	s.lock.Unlock()
+
+	connDoneC, connStopC, err := s.connect(ctx)
+	if err != nil {
+		close(s.doneC)
+		return err
+	}
+	go s.run(connDoneC, connStopC)
+	return nil
+}
+
+// Stop close the connection and delete the listen key
+func (s *ListenKeyStream) Stop() {
+	s.lock.Lock()
+	stopC, doneC := s.stopC, s.doneC
+	s.lock.Unlock()
+	if stopC == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(stopC) })
+	<-doneC
+}
+
+// Done is closed when the stream is stopped or reconnecting is given up
+func (s *ListenKeyStream) Done() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.doneC
+}
+
+// ListenKey return the current listen key
+func (s *ListenKeyStream) ListenKey() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.listenKey
+}
+
+func (s *ListenKeyStream) connect(ctx context.Context) (doneC, stopC chan struct{}, err error) {
+	listenKey, err := s.StartListenKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.lock.Lock()
+	s.listenKey = listenKey
+	s.lock.Unlock()
+	return s.Serve(listenKey, s.expired)
+}
+
+func (s *ListenKeyStream) expired() {
+	select {
+	case s.expiredC <- struct{}{}:
+	default:
+	}
+}
+
+func (s *ListenKeyStream) run(connDoneC, connStopC chan struct{}) {
+	defer close(s.doneC)
+
+	keepaliveInterval := s.KeepaliveInterval
+	if keepaliveInterval <= 0 {
+		keepaliveInterval = DefaultListenKeyKeepalive
+	}
+	maxConnectionAge := s.MaxConnectionAge
+	if maxConnectionAge <= 0 {
+		maxConnectionAge = DefaultMaxConnectionAge
+	}
+	policy := s.ReconnectPolicy
+	if policy == nil {
+		policy = DefaultReconnectPolicy()
+	}
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	age := time.NewTimer(maxConnectionAge)
+	defer age.Stop()
+
+	for {
+		var reason error
+		planned := false
+		select {
+		case <-s.stopC:
+			close(connStopC)
+			<-connDoneC
+			err := s.CloseListenKey(context.Background(), s.ListenKey())
+			if err != nil {
+				s.handleErr(err)
+			}
+			return
+		case <-keepalive.C:
+			err := s.KeepaliveListenKey(context.Background(), s.ListenKey())
+			if err != nil {
+				s.handleErr(err)
+			}
+			continue
+		case <-connDoneC:
+			reason = errors.New("user data stream disconnected")
+			if policy.OnDisconnect != nil {
+				policy.OnDisconnect(reason)
+			}
+		case <-s.expiredC:
+			planned = true
+		case <-age.C:
+			planned = true
+		}
+		if planned {
+			// renew without backoff, the old connection is closed only once
+			// the new one is connected
+			doneC, stopC, err := s.connect(context.Background())
+			close(connStopC)
+			<-connDoneC
+			if err == nil {
+				connDoneC, connStopC = doneC, stopC
+				resetTimer(age, maxConnectionAge)
+				continue
+			}
+			s.handleErr(err)
+		}
+
+		// connect again, a new listen key is created if the old one expired
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = policy.Wait(attempt, s.stopC)
+			if err != nil {
+				break
+			}
+			connDoneC, connStopC, err = s.connect(context.Background())
+			if err == nil {
+				if reason != nil && policy.OnReconnect != nil {
+					policy.OnReconnect(attempt)
+				}
+				break
+			}
+			s.handleErr(err)
+			if !policy.ShouldRetry(attempt) {
+				break
+			}
+		}
+		if err != nil {
+			if !policy.Stopped(err) && policy.OnGiveUp != nil {
+				policy.OnGiveUp(err)
+			}
+			// the connection is already closed, only the listen key is left
+			err = s.CloseListenKey(context.Background(), s.ListenKey())
+			if err != nil {
+				s.handleErr(err)
+			}
+			return
+		}
+		resetTimer(age, maxConnectionAge)
+	}
+}
+
+// resetTimer reset the timer, dropping its expiration if it was not received
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
+
+func (s *ListenKeyStream) handleErr(err error) {
+	if s.ErrHandler != nil {
+		s.ErrHandler(err)
+	}
+}
@@ -0,0 +1,248 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeListenKeyAPI struct {
+	mu         sync.Mutex
+	started    int
+	keepalives []string
+	closed     []string
+	startErr   error
+	conns      []*fakeUserDataConn
+	connC      chan *fakeUserDataConn
+}
+
+type fakeUserDataConn struct {
+	listenKey string
+	expired   func()
+	doneC     chan struct{}
+	stopC     chan struct{}
+	// overlapped is true if the previous connection was still open
+	overlapped bool
+	closeOnce  sync.Once
+}
+
+// drop simulate the server closing the connection
+func (c *fakeUserDataConn) drop() {
+	c.closeOnce.Do(func() { close(c.doneC) })
+}
+
+func newFakeListenKeyAPI() *fakeListenKeyAPI {
+	return &fakeListenKeyAPI{connC: make(chan *fakeUserDataConn, 10)}
+}
+
+func (a *fakeListenKeyAPI) stream() *ListenKeyStream {
+	return &ListenKeyStream{
+		StartListenKey: func(ctx context.Context) (string, error) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if a.startErr != nil {
+				return "", a.startErr
+			}
+			a.started++
+			return fmt.Sprintf("key-%d", a.started), nil
+		},
+		KeepaliveListenKey: func(ctx context.Context, listenKey string) error {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			a.keepalives = append(a.keepalives, listenKey)
+			return nil
+		},
+		CloseListenKey: func(ctx context.Context, listenKey string) error {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			a.closed = append(a.closed, listenKey)
+			return nil
+		},
+		Serve: func(listenKey string, expired func()) (doneC, stopC chan struct{}, err error) {
+			conn := &fakeUserDataConn{
+				listenKey: listenKey,
+				expired:   expired,
+				doneC:     make(chan struct{}),
+				stopC:     make(chan struct{}),
+			}
+			go func() {
+				select {
+				case <-conn.stopC:
+					conn.drop()
+				case <-conn.doneC:
+				}
+			}()
+			a.mu.Lock()
+			if n := len(a.conns); n > 0 {
+				select {
+				case <-a.conns[n-1].doneC:
+				default:
+					conn.overlapped = true
+				}
+			}
+			a.conns = append(a.conns, conn)
+			a.mu.Unlock()
+			a.connC <- conn
+			return conn.doneC, conn.stopC, nil
+		},
+		ReconnectPolicy: &ReconnectPolicy{InitialBackoff: time.Millisecond},
+	}
+}
+
+func (a *fakeListenKeyAPI) nextConn(t *testing.T) *fakeUserDataConn {
+	select {
+	case conn := <-a.connC:
+		return conn
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for connection")
+		return nil
+	}
+}
+
+func TestListenKeyStreamStartStop(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	require.NoError(t, s.Start(context.Background()))
+	conn := api.nextConn(t)
+	assert.Equal(t, "key-1", conn.listenKey)
+	assert.Equal(t, "key-1", s.ListenKey())
+
+	s.Stop()
+	<-s.Done()
+	<-conn.doneC
+	assert.Equal(t, []string{"key-1"}, api.closed)
+	assert.Equal(t, ErrListenKeyStreamStarted, s.Start(context.Background()))
+}
+
+func TestListenKeyStreamStartError(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	api.startErr = errors.New("dummy error")
+	s := api.stream()
+	assert.Equal(t, api.startErr, s.Start(context.Background()))
+	<-s.Done()
+}
+
+func TestListenKeyStreamKeepalive(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	s.KeepaliveInterval = 10 * time.Millisecond
+	require.NoError(t, s.Start(context.Background()))
+	defer s.Stop()
+	api.nextConn(t)
+
+	assert.Eventually(t, func() bool {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		return len(api.keepalives) >= 2 && api.keepalives[0] == "key-1"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestListenKeyStreamReconnect(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	var disconnected, reconnected int
+	s.ReconnectPolicy.OnDisconnect = func(err error) { disconnected++ }
+	s.ReconnectPolicy.OnReconnect = func(attempts int) { reconnected++ }
+	require.NoError(t, s.Start(context.Background()))
+	api.nextConn(t).drop()
+
+	conn := api.nextConn(t)
+	assert.Equal(t, "key-2", conn.listenKey)
+	s.Stop()
+	assert.Equal(t, 1, disconnected)
+	assert.Equal(t, 1, reconnected)
+	assert.Equal(t, []string{"key-2"}, api.closed)
+}
+
+func TestListenKeyStreamExpired(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	require.NoError(t, s.Start(context.Background()))
+	first := api.nextConn(t)
+	first.expired()
+
+	second := api.nextConn(t)
+	<-first.doneC
+	assert.Equal(t, "key-2", second.listenKey)
+	assert.Equal(t, "key-2", s.ListenKey())
+	s.Stop()
+}
+
+func TestListenKeyStreamMaxConnectionAge(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	s.MaxConnectionAge = 20 * time.Millisecond
+	require.NoError(t, s.Start(context.Background()))
+	first := api.nextConn(t)
+
+	api.nextConn(t)
+	<-first.doneC
+	s.Stop()
+}
+
+func TestListenKeyStreamPlannedRenewalOverlaps(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	// a backoff would fail the test by timing out
+	s.ReconnectPolicy.InitialBackoff = time.Hour
+	var disconnected int
+	s.ReconnectPolicy.OnDisconnect = func(err error) { disconnected++ }
+	require.NoError(t, s.Start(context.Background()))
+	first := api.nextConn(t)
+	first.expired()
+
+	second := api.nextConn(t)
+	// the old connection is still open when the new one is connected
+	assert.True(t, second.overlapped)
+	<-first.doneC
+	assert.Equal(t, "key-2", second.listenKey)
+	s.Stop()
+	assert.Zero(t, disconnected)
+}
+
+func TestListenKeyStreamGiveUp(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	s.ReconnectPolicy.MaxAttempts = 2
+	var gaveUp error
+	s.ReconnectPolicy.OnGiveUp = func(err error) { gaveUp = err }
+	require.NoError(t, s.Start(context.Background()))
+	conn := api.nextConn(t)
+
+	api.mu.Lock()
+	api.startErr = errors.New("dummy error")
+	api.mu.Unlock()
+	conn.drop()
+
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for giving up")
+	}
+	assert.Equal(t, api.startErr, gaveUp)
+	s.Stop()
+}
+
+func TestListenKeyStreamStopWhileReconnecting(t *testing.T) {
+	api := newFakeListenKeyAPI()
+	s := api.stream()
+	s.ReconnectPolicy.InitialBackoff = time.Hour
+	gaveUp := make(chan error, 1)
+	s.ReconnectPolicy.OnGiveUp = func(err error) { gaveUp <- err }
+	require.NoError(t, s.Start(context.Background()))
+	api.nextConn(t).drop()
+
+	s.Stop()
+	select {
+	case err := <-gaveUp:
+		t.Fatalf("OnGiveUp called after Stop: %v", err)
+	default:
+	}
+	assert.Equal(t, []string{"key-1"}, api.closed)
+}
@@ -0,0 +1,255 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Logger is a leveled and structured logger, args are alternating keys and
+// values. *slog.Logger implements it.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// LogLevel define the level of a log, the values are the ones of slog.Level
+type LogLevel int
+
+// LogLevel values
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+func (l LogLevel) String() string {
+	switch {
+	case l <= LogLevelDebug:
+		return "DEBUG"
+	case l <= LogLevelInfo:
+		return "INFO"
+	case l <= LogLevelWarn:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// DefaultRedactedKeys are the params, headers and fields whose values are
+// redacted from the logs of the clients
+var DefaultRedactedKeys = []string{"apiKey", "signature", "listenKey", "X-MBX-APIKEY"}
+
+const redactedValue = "[REDACTED]"
+
+// redactPatterns caches the patterns of the redacted keys
+var redactPatterns sync.Map
+
+// listen keys are the last segment of the user data stream endpoints
+var listenKeyPath = regexp.MustCompile(`(/ws/|/stream\?streams=)[A-Za-z0-9]{60,}`)
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+// NewStdLogger adapt a *log.Logger, the logs below the level are dropped.
+// They are printed in the logfmt format of the slog text handler.
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	return &stdLogger{logger: logger, level: level}
+}
+
+// DefaultLogger return the logger of the clients without logger, which
+// prints the logs from the level to stderr
+func DefaultLogger(level LogLevel) Logger {
+	return NewStdLogger(log.New(os.Stderr, "Binance-golang ", log.LstdFlags), level)
+}
+
+func (l *stdLogger) Debug(msg string, args ...interface{}) { l.log(LogLevelDebug, msg, args) }
+func (l *stdLogger) Info(msg string, args ...interface{})  { l.log(LogLevelInfo, msg, args) }
+func (l *stdLogger) Warn(msg string, args ...interface{})  { l.log(LogLevelWarn, msg, args) }
+func (l *stdLogger) Error(msg string, args ...interface{}) { l.log(LogLevelError, msg, args) }
+
+func (l *stdLogger) enabled(level LogLevel) bool {
+	return l.logger != nil && level >= l.level
+}
+
+func (l *stdLogger) log(level LogLevel, msg string, args []interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	var b strings.Builder
+	b.WriteString("level=")
+	b.WriteString(level.String())
+	b.WriteString(" msg=")
+	b.WriteString(logfmtValue(msg))
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			b.WriteString(" !BADKEY=")
+			b.WriteString(logfmtValue(fmt.Sprint(args[i])))
+			break
+		}
+		b.WriteByte(' ')
+		b.WriteString(fmt.Sprint(args[i]))
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(fmt.Sprint(args[i+1])))
+	}
+	l.logger.Print(b.String())
+}
+
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+type redactingLogger struct {
+	next    Logger
+	keys    map[string]bool
+	pattern *regexp.Regexp
+}
+
+// NewRedactingLogger wraps a logger to redact the values of the keys from
+// the args, and from the query strings, maps, headers and errors logged.
+// The keys are DefaultRedactedKeys if none is given.
+func NewRedactingLogger(next Logger, keys ...string) Logger {
+	if len(keys) == 0 {
+		keys = DefaultRedactedKeys
+	}
+	l := &redactingLogger{next: next, keys: make(map[string]bool, len(keys))}
+	quoted := make([]string, 0, len(keys))
+	for _, k := range keys {
+		l.keys[strings.ToLower(k)] = true
+		quoted = append(quoted, regexp.QuoteMeta(k))
+	}
+	if len(quoted) == 0 {
+		return l
+	}
+	expr := `(?i)\b(` + strings.Join(quoted, "|") + `)("?\s*[:=]\s*\[?"?)([^&\s",}\]\[]+)`
+	if p, ok := redactPatterns.Load(expr); ok {
+		l.pattern = p.(*regexp.Regexp)
+	} else {
+		l.pattern = regexp.MustCompile(expr)
+		redactPatterns.Store(expr, l.pattern)
+	}
+	return l
+}
+
+func (l *redactingLogger) Debug(msg string, args ...interface{}) {
+	if l.enabled(LogLevelDebug) {
+		l.next.Debug(msg, l.redact(args)...)
+	}
+}
+
+func (l *redactingLogger) Info(msg string, args ...interface{}) {
+	if l.enabled(LogLevelInfo) {
+		l.next.Info(msg, l.redact(args)...)
+	}
+}
+
+func (l *redactingLogger) Warn(msg string, args ...interface{}) {
+	if l.enabled(LogLevelWarn) {
+		l.next.Warn(msg, l.redact(args)...)
+	}
+}
+
+func (l *redactingLogger) Error(msg string, args ...interface{}) {
+	if l.enabled(LogLevelError) {
+		l.next.Error(msg, l.redact(args)...)
+	}
+}
+
+// enabled skip the redaction of the logs dropped by a std logger
+func (l *redactingLogger) enabled(level LogLevel) bool {
+	if std, ok := l.next.(*stdLogger); ok {
+		return std.enabled(level)
+	}
+	return true
+}
+
+func (l *redactingLogger) redact(args []interface{}) []interface{} {
+	res := make([]interface{}, len(args))
+	for i := 0; i < len(args); i++ {
+		res[i] = args[i]
+		if i%2 == 0 {
+			continue
+		}
+		if key, ok := args[i-1].(string); ok && l.keys[strings.ToLower(key)] {
+			res[i] = redactedValue
+			continue
+		}
+		res[i] = l.redactValue(args[i])
+	}
+	return res
+}
+
+func (l *redactingLogger) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return l.redactString(v)
+	case []byte:
+		return l.redactString(string(v))
+	case url.Values:
+		res := make(url.Values, len(v))
+		for k, values := range v {
+			if l.keys[strings.ToLower(k)] {
+				values = []string{redactedValue}
+			}
+			res[k] = values
+		}
+		return res
+	case http.Header:
+		res := make(http.Header, len(v))
+		for k, values := range v {
+			if l.keys[strings.ToLower(k)] {
+				values = []string{redactedValue}
+			}
+			res[k] = values
+		}
+		return res
+	case error:
+		if s := l.redactString(v.Error()); s != v.Error() {
+			return errors.New(s)
+		}
+		return v
+	}
+	// maps of params, e.g. the params of the WebSocket API
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+		res := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			if l.keys[strings.ToLower(k)] {
+				res[k] = redactedValue
+			} else {
+				res[k] = l.redactValue(iter.Value().Interface())
+			}
+		}
+		return res
+	}
+	return v
+}
+
+func (l *redactingLogger) redactString(s string) string {
+	if l.pattern != nil {
+		s = l.pattern.ReplaceAllString(s, "${1}${2}"+redactedValue)
+	}
+	if l.keys["listenkey"] {
+		s = listenKeyPath.ReplaceAllString(s, "${1}"+redactedValue)
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logRecord struct {
+	level string
+	msg   string
+	args  []interface{}
+}
+
+type recordingLogger struct {
+	records []logRecord
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	l.records = append(l.records, logRecord{level: level, msg: msg, args: args})
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0), LogLevelInfo)
+	logger.Debug("dropped")
+	logger.Info("request", "method", "GET", "body", "a=1 b=2", "status", 200)
+	logger.Error("odd", "key")
+	assert.Equal(t, "level=INFO msg=request method=GET body=\"a=1 b=2\" status=200\nlevel=ERROR msg=odd !BADKEY=key\n", buf.String())
+}
+
+func TestRedactingLogger(t *testing.T) {
+	next := &recordingLogger{}
+	logger := NewRedactingLogger(next)
+	listenKey := strings.Repeat("x", 60)
+	header := http.Header{}
+	header.Set("X-MBX-APIKEY", "key")
+	header.Set("Content-Type", "application/json")
+
+	logger.Debug("request",
+		"url", "https://api.binance.com/api/v3/order?symbol=BTCUSDT&timestamp=1&signature=abcdef",
+		"query", url.Values{"listenKey": {"lk"}, "symbol": {"BTCUSDT"}},
+		"header", header,
+		"params", map[string]interface{}{"apiKey": "key", "signature": "sig", "symbol": "BTCUSDT"},
+		"apiKey", "key",
+		"endpoint", "wss://stream.binance.com:9443/ws/"+listenKey,
+		"error", fmt.Errorf("Post \"https://api.binance.com/api/v3/order?signature=abcdef\": EOF"),
+	)
+	require.Len(t, next.records, 1)
+	args := next.records[0].args
+	assert.Equal(t, "https://api.binance.com/api/v3/order?symbol=BTCUSDT&timestamp=1&signature=[REDACTED]", args[1])
+	assert.Equal(t, url.Values{"listenKey": {"[REDACTED]"}, "symbol": {"BTCUSDT"}}, args[3])
+	assert.Equal(t, []string{"[REDACTED]"}, args[5].(http.Header)["X-Mbx-Apikey"])
+	assert.Equal(t, "application/json", args[5].(http.Header).Get("Content-Type"))
+	assert.Equal(t, map[string]interface{}{"apiKey": "[REDACTED]", "signature": "[REDACTED]", "symbol": "BTCUSDT"}, args[7])
+	assert.Equal(t, "[REDACTED]", args[9])
+	assert.Equal(t, "wss://stream.binance.com:9443/ws/[REDACTED]", args[11])
+	assert.Equal(t, "Post \"https://api.binance.com/api/v3/order?signature=[REDACTED]\": EOF", args[13].(error).Error())
+
+	// the values of the caller are not changed
+	assert.Equal(t, "key", header.Get("X-MBX-APIKEY"))
+}
+
+func TestRedactString(t *testing.T) {
+	l := NewRedactingLogger(&recordingLogger{}).(*redactingLogger)
+	for in, out := range map[string]string{
+		`map[apiKey:key signature:sig symbol:BTCUSDT]`: `map[apiKey:[REDACTED] signature:[REDACTED] symbol:BTCUSDT]`,
+		`{"apiKey":"key","symbol":"BTCUSDT"}`:          `{"apiKey":"[REDACTED]","symbol":"BTCUSDT"}`,
+		`listenKey=lk&symbol=BTCUSDT`:                  `listenKey=[REDACTED]&symbol=BTCUSDT`,
+		`map[X-Mbx-Apikey:[key]]`:                      `map[X-Mbx-Apikey:[[REDACTED]]]`,
+		`Signature for this request is not valid.`:     `Signature for this request is not valid.`,
+	} {
+		assert.Equal(t, out, l.redactString(in))
+	}
+}
+
+func TestRedactingLoggerSkipsDroppedLogs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewRedactingLogger(NewStdLogger(log.New(&buf, "", 0), LogLevelWarn), "secret")
+	logger.Info("dropped", "secret", "s")
+	logger.Warn("kept", "secret", "s", "err", errors.New("secret=s"))
+	assert.Equal(t, "level=WARN msg=kept secret=[REDACTED] err=\"secret=[REDACTED]\"\n", buf.String())
+}
@@ -0,0 +1,231 @@
+package common
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds in seconds of the latency
+// histogram of a MetricsInstrumentation
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MetricsInstrumentation is an in memory Instrumentation which exposes its
+// metrics in the Prometheus text format, it can be served as the /metrics
+// handler of an HTTP server:
+//   - <namespace>_api_request_duration_seconds{method,endpoint}, histogram
+//   - <namespace>_api_requests_total{method,endpoint,status}
+//   - <namespace>_api_errors_total{method,endpoint,code}
+//   - <namespace>_rate_limit_usage{base_url,type,interval}, the last reported
+//     usage of an API
+//   - <namespace>_ws_connects_total{result}
+//   - <namespace>_ws_disconnects_total{result}
+//   - <namespace>_ws_reconnects_total{result}
+type MetricsInstrumentation struct {
+	// Namespace prefix the metric names, binance by default
+	Namespace string
+	// Buckets of the latency histogram, DefaultLatencyBuckets by default
+	Buckets []float64
+
+	lock       sync.Mutex
+	latencies  map[string]*histogram
+	requests   map[string]float64
+	errors     map[string]float64
+	rateLimits map[string]float64
+	wsEvents   map[string]map[string]float64
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+type metricsSpan struct {
+	m     *MetricsInstrumentation
+	start *SpanStart
+}
+
+// NewMetricsInstrumentation create an empty MetricsInstrumentation
+func NewMetricsInstrumentation() *MetricsInstrumentation {
+	return &MetricsInstrumentation{
+		Namespace:  "binance",
+		Buckets:    DefaultLatencyBuckets,
+		latencies:  map[string]*histogram{},
+		requests:   map[string]float64{},
+		errors:     map[string]float64{},
+		rateLimits: map[string]float64{},
+		wsEvents:   map[string]map[string]float64{},
+	}
+}
+
+// StartSpan implements Instrumentation
+func (m *MetricsInstrumentation) StartSpan(ctx context.Context, start *SpanStart) (context.Context, Span) {
+	if start.Time.IsZero() {
+		start.Time = time.Now()
+	}
+	return ctx, &metricsSpan{m: m, start: start}
+}
+
+// Finish record the latency, the status, the error code and the usage of
+// the call
+func (s *metricsSpan) Finish(end *SpanEnd) {
+	finished := end.Time
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+	seconds := finished.Sub(s.start.Time).Seconds()
+	m := s.m
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	call := labels("method", s.start.Method, "endpoint", s.start.Endpoint)
+	h := m.latencies[call]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.Buckets))}
+		m.latencies[call] = h
+	}
+	for i, le := range m.Buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+
+	m.requests[labels("method", s.start.Method, "endpoint", s.start.Endpoint, "status", strconv.Itoa(end.StatusCode))]++
+	if end.ErrorCode != 0 {
+		m.errors[labels("method", s.start.Method, "endpoint", s.start.Endpoint, "code", strconv.FormatInt(end.ErrorCode, 10))]++
+	}
+	for _, l := range end.RateLimits {
+		m.rateLimits[labels("base_url", s.start.BaseURL, "type", l.Type, "interval", formatInterval(l.Interval))] = float64(l.Count)
+	}
+}
+
+// WsConnect implements Instrumentation
+func (m *MetricsInstrumentation) WsConnect(endpoint string, err error) {
+	m.countWsEvent("connects", err)
+}
+
+// WsDisconnect implements Instrumentation
+func (m *MetricsInstrumentation) WsDisconnect(endpoint string, err error) {
+	m.countWsEvent("disconnects", err)
+}
+
+// WsReconnect implements Instrumentation
+func (m *MetricsInstrumentation) WsReconnect(endpoint string, attempt int, err error) {
+	m.countWsEvent("reconnects", err)
+}
+
+func (m *MetricsInstrumentation) countWsEvent(event string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.wsEvents[event] == nil {
+		m.wsEvents[event] = map[string]float64{}
+	}
+	m.wsEvents[event][labels("result", result)]++
+}
+
+// WritePrometheus write the metrics in the Prometheus text format
+func (m *MetricsInstrumentation) WritePrometheus(w io.Writer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	ns := m.Namespace
+	if ns == "" {
+		ns = "binance"
+	}
+	bw := bufio.NewWriter(w)
+
+	name := ns + "_api_request_duration_seconds"
+	fmt.Fprintf(bw, "# HELP %s Latency of the API calls.\n# TYPE %s histogram\n", name, name)
+	keys := make([]string, 0, len(m.latencies))
+	for key := range m.latencies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := m.latencies[key]
+		for i, le := range m.Buckets {
+			fmt.Fprintf(bw, "%s_bucket%s %d\n", name, withLabel(key, "le", strconv.FormatFloat(le, 'g', -1, 64)), h.counts[i])
+		}
+		fmt.Fprintf(bw, "%s_bucket%s %d\n", name, withLabel(key, "le", "+Inf"), h.count)
+		fmt.Fprintf(bw, "%s_sum%s %g\n", name, key, h.sum)
+		fmt.Fprintf(bw, "%s_count%s %d\n", name, key, h.count)
+	}
+	writeFamily(bw, ns+"_api_requests_total", "counter", "API calls by response status, 0 without response.", m.requests)
+	writeFamily(bw, ns+"_api_errors_total", "counter", "API errors by error code.", m.errors)
+	writeFamily(bw, ns+"_rate_limit_usage", "gauge", "Last usage of the rate limits reported by the responses.", m.rateLimits)
+	for _, event := range []string{"connects", "disconnects", "reconnects"} {
+		writeFamily(bw, ns+"_ws_"+event+"_total", "counter", "Websocket "+event+" by result.", m.wsEvents[event])
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP serve the metrics in the Prometheus text format
+func (m *MetricsInstrumentation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+func writeFamily(w io.Writer, name, typ, help string, values map[string]float64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s%s %g\n", name, key, values[key])
+	}
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels format the name/value pairs as the labels of a sample
+func labels(pairs ...string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString(`="`)
+		b.WriteString(labelReplacer.Replace(pairs[i+1]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func withLabel(key, name, value string) string {
+	return strings.TrimSuffix(key, "}") + "," + strings.TrimPrefix(labels(name, value), "{")
+}
+
+// formatInterval format an interval as the exchange does, e.g. 1m or 10s
+func formatInterval(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return strconv.Itoa(int(d/(24*time.Hour))) + "d"
+	case d >= time.Hour && d%time.Hour == 0:
+		return strconv.Itoa(int(d/time.Hour)) + "h"
+	case d >= time.Minute && d%time.Minute == 0:
+		return strconv.Itoa(int(d/time.Minute)) + "m"
+	}
+	return strconv.Itoa(int(d/time.Second)) + "s"
+}
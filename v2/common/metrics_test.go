@@ -0,0 +1,93 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsInstrumentation(t *testing.T) {
+	m := NewMetricsInstrumentation()
+	start := time.Now()
+	_, span := m.StartSpan(context.Background(), &SpanStart{BaseURL: "https://api.binance.com", Method: http.MethodGet, Endpoint: "/api/v3/time", Time: start})
+	span.Finish(&SpanEnd{
+		StatusCode: http.StatusOK,
+		RateLimits: []RateLimit{{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Count: 12}},
+		Time:       start.Add(30 * time.Millisecond),
+	})
+	_, span = m.StartSpan(context.Background(), &SpanStart{BaseURL: "https://fapi.binance.com", Method: http.MethodGet, Endpoint: "/fapi/v1/time", Time: start})
+	span.Finish(&SpanEnd{
+		StatusCode: http.StatusOK,
+		RateLimits: []RateLimit{{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Count: 3}},
+		Time:       start.Add(30 * time.Millisecond),
+	})
+	_, span = m.StartSpan(context.Background(), &SpanStart{Method: http.MethodPost, Endpoint: "/api/v3/order", Time: start})
+	span.Finish(NewSpanEnd(0, nil, &APIError{Code: -2010, Message: "insufficient balance", StatusCode: http.StatusBadRequest}))
+	m.WsConnect("wss://stream", nil)
+	m.WsDisconnect("wss://stream", errors.New("closed"))
+	m.WsReconnect("wss://ws-api", 1, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.WritePrometheus(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE binance_api_request_duration_seconds histogram\n")
+	assert.Contains(t, out, `binance_api_request_duration_seconds_bucket{method="GET",endpoint="/api/v3/time",le="0.025"} 0`+"\n")
+	assert.Contains(t, out, `binance_api_request_duration_seconds_bucket{method="GET",endpoint="/api/v3/time",le="0.05"} 1`+"\n")
+	assert.Contains(t, out, `binance_api_request_duration_seconds_bucket{method="GET",endpoint="/api/v3/time",le="+Inf"} 1`+"\n")
+	assert.Contains(t, out, `binance_api_request_duration_seconds_count{method="GET",endpoint="/api/v3/time"} 1`+"\n")
+	assert.Contains(t, out, `binance_api_requests_total{method="GET",endpoint="/api/v3/time",status="200"} 1`+"\n")
+	assert.Contains(t, out, `binance_api_requests_total{method="POST",endpoint="/api/v3/order",status="400"} 1`+"\n")
+	assert.Contains(t, out, `binance_api_errors_total{method="POST",endpoint="/api/v3/order",code="-2010"} 1`+"\n")
+	assert.Contains(t, out, `binance_rate_limit_usage{base_url="https://api.binance.com",type="REQUEST_WEIGHT",interval="1m"} 12`+"\n")
+	assert.Contains(t, out, `binance_rate_limit_usage{base_url="https://fapi.binance.com",type="REQUEST_WEIGHT",interval="1m"} 3`+"\n")
+	assert.Contains(t, out, `binance_ws_connects_total{result="success"} 1`+"\n")
+	assert.Contains(t, out, `binance_ws_disconnects_total{result="error"} 1`+"\n")
+	assert.Contains(t, out, `binance_ws_reconnects_total{result="success"} 1`+"\n")
+}
+
+func TestMetricsInstrumentationServeHTTP(t *testing.T) {
+	m := NewMetricsInstrumentation()
+	m.Namespace = "test"
+	m.WsConnect("wss://stream", errors.New("refused"))
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, w.Body.String(), `test_ws_connects_total{result="error"} 1`+"\n")
+}
+
+func TestMetricsLabelsEscaped(t *testing.T) {
+	assert.Equal(t, `{a="x\"y\\z\n"}`, labels("a", "x\"y\\z\n"))
+}
+
+func TestNewSpanEnd(t *testing.T) {
+	end := NewSpanEnd(http.StatusOK, []RateLimit{{Type: RateLimitTypeOrders}}, nil)
+	assert.Equal(t, http.StatusOK, end.StatusCode)
+	assert.Zero(t, end.ErrorCode)
+	assert.Len(t, end.RateLimits, 1)
+
+	err := &RequestError{Method: http.MethodGet, Endpoint: "/api/v3/time", Err: errors.New("timeout")}
+	end = NewSpanEnd(0, nil, err)
+	assert.Zero(t, end.StatusCode)
+	assert.Equal(t, err, end.Err)
+
+	end = NewSpanEnd(0, nil, &APIError{Code: -1003, StatusCode: http.StatusTooManyRequests})
+	assert.Equal(t, http.StatusTooManyRequests, end.StatusCode)
+	assert.EqualValues(t, -1003, end.ErrorCode)
+}
+
+func TestNoopInstrumentation(t *testing.T) {
+	i := InstrumentationOrNoop(nil)
+	ctx := context.Background()
+	ctx2, span := i.StartSpan(ctx, &SpanStart{})
+	assert.Equal(t, ctx, ctx2)
+	span.Finish(&SpanEnd{})
+}
@@ -0,0 +1,449 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultOrderBookSnapshotLimit is the depth of the REST snapshot
+const DefaultOrderBookSnapshotLimit = 1000
+
+// ErrOrderBookStarted is returned when an OrderBook is started twice
+var ErrOrderBookStarted = errors.New("order book is already started")
+
+// DepthSnapshot is the REST depth snapshot of an order book
+type DepthSnapshot struct {
+	LastUpdateID int64
+	Bids         []PriceLevel
+	Asks         []PriceLevel
+}
+
+// DepthUpdate is a diff depth event of an order book. PrevLastUpdateID is
+// only set by futures streams.
+type DepthUpdate struct {
+	Time             int64
+	FirstUpdateID    int64
+	LastUpdateID     int64
+	PrevLastUpdateID int64
+	Bids             []PriceLevel
+	Asks             []PriceLevel
+}
+
+// DepthSequenceGapError means some depth updates are missed, the order book
+// is re-synchronized from a new snapshot
+type DepthSequenceGapError struct {
+	LastUpdateID int64
+	Update       *DepthUpdate
+}
+
+func (e DepthSequenceGapError) Error() string {
+	return fmt.Sprintf("depth update gap: last update id %d, received U=%d u=%d pu=%d",
+		e.LastUpdateID, e.Update.FirstUpdateID, e.Update.LastUpdateID, e.Update.PrevLastUpdateID)
+}
+
+// OrderBook is a local order book kept in sync with the diff depth stream,
+// following the algorithm documented by Binance: diff events are buffered,
+// the REST snapshot is fetched, the events older than the snapshot are
+// dropped and the rest applied in sequence. The order book is synchronized
+// again when a gap is detected or the stream reconnects.
+//
+// It is product agnostic, the order books of each client fill the functions
+// with their own depth service and stream.
+type OrderBook struct {
+	Symbol string
+
+	// Snapshot fetch the REST depth snapshot with the given limit
+	Snapshot func(ctx context.Context, limit int) (*DepthSnapshot, error)
+	// Serve connect the diff depth stream
+	Serve func(handler func(update *DepthUpdate), errHandler func(err error)) (doneC, stopC chan struct{}, err error)
+	// UsePrevUpdateID checks the sequence with PrevLastUpdateID (`pu`) as the
+	// futures streams do, instead of FirstUpdateID
+	UsePrevUpdateID bool
+
+	// SnapshotLimit defaults to DefaultOrderBookSnapshotLimit
+	SnapshotLimit int
+	// ReconnectPolicy is used to reconnect the stream and to retry the
+	// snapshot, defaults to DefaultReconnectPolicy
+	ReconnectPolicy *ReconnectPolicy
+	// OnChange is called after the order book is changed by a snapshot or an
+	// update, may be nil
+	OnChange func(book *OrderBook)
+	// ErrHandler receives the errors of the stream, the snapshot and the
+	// sequence gaps, may be nil
+	ErrHandler func(err error)
+
+	lock         sync.RWMutex
+	bids         []bookLevel
+	asks         []bookLevel
+	lastUpdateID int64
+	synced       bool
+	applied      bool
+	fetching     bool
+	buffer       []*DepthUpdate
+	generation   int
+
+	started  bool
+	stopC    chan struct{}
+	doneC    chan struct{}
+	stopOnce sync.Once
+}
+
+type bookLevel struct {
+	price float64
+	level PriceLevel
+}
+
+// Start connect the stream and synchronize the order book in background,
+// until Stop is called or reconnecting is given up
+func (b *OrderBook) Start() error {
+	b.lock.Lock()
+	if b.started {
+		b.lock.Unlock()
+		return ErrOrderBookStarted
+	}
+	b.started = true
+	b.stopC = make(chan struct{})
+	b.doneC = make(chan struct{})
+	b.lock.Unlock()
+
+	connDoneC, connStopC, err := b.serve()
+	if err != nil {
+		close(b.doneC)
+		return err
+	}
+	go b.run(connDoneC, connStopC)
+	return nil
+}
+
+// Stop close the stream
+func (b *OrderBook) Stop() {
+	b.lock.Lock()
+	stopC, doneC := b.stopC, b.doneC
+	b.lock.Unlock()
+	if stopC == nil {
+		return
+	}
+	b.stopOnce.Do(func() { close(stopC) })
+	<-doneC
+}
+
+// Done is closed when the order book is stopped or reconnecting is given up
+func (b *OrderBook) Done() <-chan struct{} {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.doneC
+}
+
+// Synced return true when the order book is in sync with the stream
+func (b *OrderBook) Synced() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.synced
+}
+
+// LastUpdateID return the id of the last applied update
+func (b *OrderBook) LastUpdateID() int64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.lastUpdateID
+}
+
+// BestBid return the highest bid, false if there is no bid
+func (b *OrderBook) BestBid() (PriceLevel, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if len(b.bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.bids[0].level, true
+}
+
+// BestAsk return the lowest ask, false if there is no ask
+func (b *OrderBook) BestAsk() (PriceLevel, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if len(b.asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return b.asks[0].level, true
+}
+
+// Bids return the n best bids, from the highest price, all of them if n <= 0
+func (b *OrderBook) Bids(n int) []PriceLevel {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return topLevels(b.bids, n)
+}
+
+// Asks return the n best asks, from the lowest price, all of them if n <= 0
+func (b *OrderBook) Asks(n int) []PriceLevel {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return topLevels(b.asks, n)
+}
+
+func topLevels(levels []bookLevel, n int) []PriceLevel {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	res := make([]PriceLevel, n)
+	for i := range res {
+		res[i] = levels[i].level
+	}
+	return res
+}
+
+func (b *OrderBook) run(connDoneC, connStopC chan struct{}) {
+	defer close(b.doneC)
+
+	policy := b.policy()
+	for {
+		select {
+		case <-b.stopC:
+			close(connStopC)
+			<-connDoneC
+			return
+		case <-connDoneC:
+		}
+
+		reason := errors.New("depth stream disconnected")
+		if policy.OnDisconnect != nil {
+			policy.OnDisconnect(reason)
+		}
+		b.reset()
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = policy.Wait(attempt, b.stopC)
+			if err != nil {
+				break
+			}
+			connDoneC, connStopC, err = b.serve()
+			if err == nil {
+				if policy.OnReconnect != nil {
+					policy.OnReconnect(attempt)
+				}
+				break
+			}
+			b.handleErr(err)
+			if !policy.ShouldRetry(attempt) {
+				break
+			}
+		}
+		if err != nil {
+			if !policy.Stopped(err) && policy.OnGiveUp != nil {
+				policy.OnGiveUp(err)
+			}
+			return
+		}
+	}
+}
+
+func (b *OrderBook) serve() (doneC, stopC chan struct{}, err error) {
+	b.lock.RLock()
+	generation := b.generation
+	b.lock.RUnlock()
+	return b.Serve(func(update *DepthUpdate) {
+		b.handleUpdate(generation, update)
+	}, b.handleErr)
+}
+
+// reset drop the order book state after a disconnection, the updates of
+// the previous connection are ignored
+func (b *OrderBook) reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.generation++
+	b.synced = false
+	b.buffer = nil
+}
+
+func (b *OrderBook) handleUpdate(generation int, update *DepthUpdate) {
+	b.lock.Lock()
+	if generation != b.generation {
+		b.lock.Unlock()
+		return
+	}
+	if !b.synced {
+		b.bufferUpdate(update)
+		b.lock.Unlock()
+		return
+	}
+	changed, err := b.apply(update)
+	if err != nil {
+		b.synced = false
+		b.bufferUpdate(update)
+	}
+	b.lock.Unlock()
+
+	if err != nil {
+		b.handleErr(err)
+	}
+	if changed && b.OnChange != nil {
+		b.OnChange(b)
+	}
+}
+
+// bufferUpdate keep the update until the snapshot is loaded, lock must be held
+func (b *OrderBook) bufferUpdate(update *DepthUpdate) {
+	b.buffer = append(b.buffer, update)
+	if !b.fetching {
+		b.fetching = true
+		go b.sync()
+	}
+}
+
+// sync fetch the snapshot and apply the buffered updates on it
+func (b *OrderBook) sync() {
+	policy := b.policy()
+	limit := b.SnapshotLimit
+	if limit <= 0 {
+		limit = DefaultOrderBookSnapshotLimit
+	}
+	for attempt := 1; ; attempt++ {
+		snapshot, err := b.Snapshot(context.Background(), limit)
+		if err != nil {
+			b.handleErr(err)
+		} else if b.load(snapshot) {
+			return
+		}
+		if policy.Wait(attempt, b.stopC) != nil {
+			b.lock.Lock()
+			b.fetching = false
+			b.lock.Unlock()
+			return
+		}
+	}
+}
+
+// load apply the buffered updates on the snapshot, return false if the
+// snapshot should be fetched again
+func (b *OrderBook) load(snapshot *DepthSnapshot) bool {
+	b.lock.Lock()
+	// the snapshot is older than the buffered updates, fetch it again
+	if len(b.buffer) > 0 && !b.continues(snapshot.LastUpdateID, b.buffer[0]) {
+		b.lock.Unlock()
+		return false
+	}
+
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	b.updateLevels(snapshot.Bids, snapshot.Asks)
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.applied = false
+
+	var err error
+	buffer := b.buffer
+	b.buffer = nil
+	for i, update := range buffer {
+		if _, err = b.apply(update); err != nil {
+			// keep the remaining updates for the next snapshot
+			b.buffer = buffer[i:]
+			break
+		}
+	}
+	b.synced = err == nil
+	b.fetching = err != nil
+	b.lock.Unlock()
+
+	if err != nil {
+		b.handleErr(err)
+		return false
+	}
+	if b.OnChange != nil {
+		b.OnChange(b)
+	}
+	return true
+}
+
+// apply the update if it is the next one in sequence, lock must be held
+func (b *OrderBook) apply(update *DepthUpdate) (changed bool, err error) {
+	last := b.lastUpdateID
+	if b.UsePrevUpdateID {
+		if update.LastUpdateID < last {
+			return false, nil
+		}
+		if b.applied && update.PrevLastUpdateID != last || !b.applied && !b.continues(last, update) {
+			return false, DepthSequenceGapError{LastUpdateID: last, Update: update}
+		}
+	} else {
+		if update.LastUpdateID <= last {
+			return false, nil
+		}
+		if b.applied && update.FirstUpdateID != last+1 || !b.applied && !b.continues(last, update) {
+			return false, DepthSequenceGapError{LastUpdateID: last, Update: update}
+		}
+	}
+	b.updateLevels(update.Bids, update.Asks)
+	b.lastUpdateID = update.LastUpdateID
+	b.applied = true
+	return true, nil
+}
+
+// continues return true if no update is missing between the snapshot and
+// the first update applied on it
+func (b *OrderBook) continues(snapshotUpdateID int64, update *DepthUpdate) bool {
+	if b.UsePrevUpdateID {
+		return update.FirstUpdateID <= snapshotUpdateID
+	}
+	return update.FirstUpdateID <= snapshotUpdateID+1
+}
+
+func (b *OrderBook) updateLevels(bids, asks []PriceLevel) {
+	for _, level := range bids {
+		b.bids = updateLevel(b.bids, level, true)
+	}
+	for _, level := range asks {
+		b.asks = updateLevel(b.asks, level, false)
+	}
+}
+
+// updateLevel set or remove (zero quantity) the level in levels sorted by
+// price, descending for bids and ascending for asks
+func updateLevel(levels []bookLevel, level PriceLevel, desc bool) []bookLevel {
+	price, err := strconv.ParseFloat(level.Price, 64)
+	if err != nil {
+		return levels
+	}
+	quantity, err := strconv.ParseFloat(level.Quantity, 64)
+	if err != nil {
+		return levels
+	}
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].price <= price
+		}
+		return levels[i].price >= price
+	})
+	found := i < len(levels) && levels[i].price == price
+	switch {
+	case quantity == 0 && found:
+		return append(levels[:i], levels[i+1:]...)
+	case quantity == 0:
+		return levels
+	case found:
+		levels[i].level = level
+		return levels
+	}
+	levels = append(levels, bookLevel{})
+	copy(levels[i+1:], levels[i:])
+	levels[i] = bookLevel{price: price, level: level}
+	return levels
+}
+
+func (b *OrderBook) policy() *ReconnectPolicy {
+	if b.ReconnectPolicy == nil {
+		return DefaultReconnectPolicy()
+	}
+	return b.ReconnectPolicy
+}
+
+func (b *OrderBook) handleErr(err error) {
+	if b.ErrHandler != nil {
+		b.ErrHandler(err)
+	}
+}
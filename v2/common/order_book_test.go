@@ -0,0 +1,250 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDepthAPI struct {
+	mu        sync.Mutex
+	snapshots []*DepthSnapshot
+	fetched   int
+	handlers  []func(update *DepthUpdate)
+	// drops simulate the server closing the connections
+	drops []func()
+}
+
+func (a *fakeDepthAPI) book(usePrevUpdateID bool) *OrderBook {
+	return &OrderBook{
+		Symbol: "BTCUSDT",
+		Snapshot: func(ctx context.Context, limit int) (*DepthSnapshot, error) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			snapshot := a.snapshots[0]
+			if len(a.snapshots) > 1 {
+				a.snapshots = a.snapshots[1:]
+			}
+			a.fetched++
+			return snapshot, nil
+		},
+		Serve: func(handler func(update *DepthUpdate), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+			doneC = make(chan struct{})
+			stopC = make(chan struct{})
+			var once sync.Once
+			drop := func() { once.Do(func() { close(doneC) }) }
+			go func() {
+				select {
+				case <-stopC:
+					drop()
+				case <-doneC:
+				}
+			}()
+			a.mu.Lock()
+			a.handlers = append(a.handlers, handler)
+			a.drops = append(a.drops, drop)
+			a.mu.Unlock()
+			return doneC, stopC, nil
+		},
+		UsePrevUpdateID: usePrevUpdateID,
+		ReconnectPolicy: &ReconnectPolicy{InitialBackoff: time.Millisecond},
+	}
+}
+
+func (a *fakeDepthAPI) push(update *DepthUpdate) {
+	a.mu.Lock()
+	handler := a.handlers[len(a.handlers)-1]
+	a.mu.Unlock()
+	handler(update)
+}
+
+func (a *fakeDepthAPI) fetchCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fetched
+}
+
+func levels(pairs ...string) []PriceLevel {
+	res := make([]PriceLevel, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		res = append(res, PriceLevel{Price: pairs[i], Quantity: pairs[i+1]})
+	}
+	return res
+}
+
+func waitSynced(t *testing.T, book *OrderBook) {
+	require.Eventually(t, book.Synced, time.Second, time.Millisecond)
+}
+
+func TestOrderBookSync(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{{
+		LastUpdateID: 100,
+		Bids:         levels("9.9", "1", "10.0", "2", "9.8", "3"),
+		Asks:         levels("10.2", "1", "10.1", "2"),
+	}}}
+	book := api.book(false)
+	var changes int
+	var changesLock sync.Mutex
+	book.OnChange = func(book *OrderBook) {
+		changesLock.Lock()
+		changes++
+		changesLock.Unlock()
+	}
+	require.NoError(t, book.Start())
+	defer book.Stop()
+
+	// stale, then overlapping the snapshot
+	api.push(&DepthUpdate{FirstUpdateID: 95, LastUpdateID: 99, Bids: levels("9.9", "5")})
+	api.push(&DepthUpdate{FirstUpdateID: 100, LastUpdateID: 102, Bids: levels("9.9", "0"), Asks: levels("10.15", "4")})
+	waitSynced(t, book)
+	api.push(&DepthUpdate{FirstUpdateID: 103, LastUpdateID: 103, Bids: levels("10.05", "1")})
+
+	assert.Equal(t, int64(103), book.LastUpdateID())
+	bid, ok := book.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, PriceLevel{Price: "10.05", Quantity: "1"}, bid)
+	ask, ok := book.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, PriceLevel{Price: "10.1", Quantity: "2"}, ask)
+	assert.Equal(t, levels("10.05", "1", "10.0", "2"), book.Bids(2))
+	assert.Equal(t, levels("10.1", "2", "10.15", "4", "10.2", "1"), book.Asks(0))
+	changesLock.Lock()
+	assert.Equal(t, 2, changes)
+	changesLock.Unlock()
+}
+
+func TestOrderBookSnapshotTooOld(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{
+		{LastUpdateID: 90},
+		{LastUpdateID: 101, Bids: levels("1", "1")},
+	}}
+	book := api.book(false)
+	require.NoError(t, book.Start())
+	defer book.Stop()
+
+	api.push(&DepthUpdate{FirstUpdateID: 100, LastUpdateID: 102, Asks: levels("2", "1")})
+	waitSynced(t, book)
+	assert.Equal(t, 2, api.fetchCount())
+	assert.Equal(t, int64(102), book.LastUpdateID())
+	assert.Equal(t, levels("2", "1"), book.Asks(0))
+}
+
+func TestOrderBookGap(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{
+		{LastUpdateID: 100, Bids: levels("1", "1")},
+		{LastUpdateID: 110, Bids: levels("1", "3")},
+	}}
+	book := api.book(false)
+	var gaps int
+	var gapsLock sync.Mutex
+	book.ErrHandler = func(err error) {
+		if _, ok := err.(DepthSequenceGapError); ok {
+			gapsLock.Lock()
+			gaps++
+			gapsLock.Unlock()
+		}
+	}
+	require.NoError(t, book.Start())
+	defer book.Stop()
+
+	api.push(&DepthUpdate{FirstUpdateID: 101, LastUpdateID: 101})
+	waitSynced(t, book)
+	api.push(&DepthUpdate{FirstUpdateID: 105, LastUpdateID: 111, Bids: levels("1", "4")})
+	waitSynced(t, book)
+
+	assert.Equal(t, 2, api.fetchCount())
+	assert.Equal(t, int64(111), book.LastUpdateID())
+	assert.Equal(t, levels("1", "4"), book.Bids(0))
+	gapsLock.Lock()
+	assert.Equal(t, 1, gaps)
+	gapsLock.Unlock()
+}
+
+func TestOrderBookPrevUpdateID(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{
+		{LastUpdateID: 100},
+		{LastUpdateID: 200},
+	}}
+	book := api.book(true)
+	require.NoError(t, book.Start())
+	defer book.Stop()
+
+	api.push(&DepthUpdate{FirstUpdateID: 90, LastUpdateID: 99, PrevLastUpdateID: 89})
+	api.push(&DepthUpdate{FirstUpdateID: 95, LastUpdateID: 100, PrevLastUpdateID: 99, Bids: levels("5", "1")})
+	waitSynced(t, book)
+	api.push(&DepthUpdate{FirstUpdateID: 101, LastUpdateID: 110, PrevLastUpdateID: 100, Bids: levels("6", "1")})
+	assert.True(t, book.Synced())
+	assert.Equal(t, levels("6", "1", "5", "1"), book.Bids(0))
+
+	// pu does not match the last u
+	api.push(&DepthUpdate{FirstUpdateID: 190, LastUpdateID: 200, PrevLastUpdateID: 150})
+	waitSynced(t, book)
+	assert.Equal(t, 2, api.fetchCount())
+	assert.Equal(t, int64(200), book.LastUpdateID())
+}
+
+func TestOrderBookReconnect(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{
+		{LastUpdateID: 100},
+		{LastUpdateID: 300, Asks: levels("7", "1")},
+	}}
+	book := api.book(false)
+	require.NoError(t, book.Start())
+	defer book.Stop()
+
+	api.push(&DepthUpdate{FirstUpdateID: 101, LastUpdateID: 101})
+	waitSynced(t, book)
+
+	api.mu.Lock()
+	oldHandler := api.handlers[0]
+	api.drops[0]()
+	api.mu.Unlock()
+	require.Eventually(t, func() bool {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		return len(api.handlers) == 2
+	}, time.Second, time.Millisecond)
+	assert.False(t, book.Synced())
+
+	// updates of the dropped connection are ignored
+	oldHandler(&DepthUpdate{FirstUpdateID: 102, LastUpdateID: 102})
+	assert.Equal(t, 1, api.fetchCount())
+
+	api.push(&DepthUpdate{FirstUpdateID: 300, LastUpdateID: 301})
+	waitSynced(t, book)
+	assert.Equal(t, int64(301), book.LastUpdateID())
+	assert.Equal(t, levels("7", "1"), book.Asks(0))
+}
+
+func TestOrderBookStartTwice(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{{}}}
+	book := api.book(false)
+	require.NoError(t, book.Start())
+	assert.Equal(t, ErrOrderBookStarted, book.Start())
+	book.Stop()
+	<-book.Done()
+}
+
+func TestOrderBookStopWhileReconnecting(t *testing.T) {
+	api := &fakeDepthAPI{snapshots: []*DepthSnapshot{{}}}
+	book := api.book(false)
+	book.ReconnectPolicy.InitialBackoff = time.Hour
+	gaveUp := make(chan error, 1)
+	book.ReconnectPolicy.OnGiveUp = func(err error) { gaveUp <- err }
+	require.NoError(t, book.Start())
+
+	api.mu.Lock()
+	api.drops[0]()
+	api.mu.Unlock()
+	book.Stop()
+	<-book.Done()
+	select {
+	case err := <-gaveUp:
+		t.Fatalf("OnGiveUp called after Stop: %v", err)
+	default:
+	}
+}
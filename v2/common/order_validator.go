@@ -0,0 +1,297 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SymbolFilters are the filters of a symbol checked by OrderValidator, the
+// limits which are zero are not checked
+type SymbolFilters struct {
+	Symbol string
+
+	// PRICE_FILTER
+	MinPrice Decimal
+	MaxPrice Decimal
+	TickSize Decimal
+
+	// LOT_SIZE
+	MinQuantity Decimal
+	MaxQuantity Decimal
+	StepSize    Decimal
+
+	// MARKET_LOT_SIZE, checked in addition to LOT_SIZE for market orders
+	MarketMinQuantity Decimal
+	MarketMaxQuantity Decimal
+	MarketStepSize    Decimal
+
+	// NOTIONAL or MIN_NOTIONAL
+	MinNotional      Decimal
+	MaxNotional      Decimal
+	ApplyMinToMarket bool
+	ApplyMaxToMarket bool
+
+	// PERCENT_PRICE or PERCENT_PRICE_BY_SIDE, checked against the reference
+	// price of OrderValidator
+	BidMultiplierUp   Decimal
+	BidMultiplierDown Decimal
+	AskMultiplierUp   Decimal
+	AskMultiplierDown Decimal
+
+	// MAX_NUM_ORDERS and MAX_NUM_ALGO_ORDERS, checked against the open orders
+	// of OrderValidator
+	MaxNumOrders     int
+	MaxNumAlgoOrders int
+}
+
+// OrderParams are the fields of an order checked by OrderValidator, as set
+// on the order services. Nil fields are not set, and the fields which are not
+// valid decimals are left to the server. The prices and the quantity are
+// updated in place when they are rounded.
+type OrderParams struct {
+	Symbol string
+	// Side is BUY or SELL
+	Side string
+	// Market is true for the orders executed at the market price
+	Market bool
+	// Algo is true for the orders counted by MAX_NUM_ALGO_ORDERS, e.g. the
+	// stop and take profit orders
+	Algo bool
+	// Replace is true when the order replaces an open order, e.g. a modified
+	// order, it is not counted by MAX_NUM_ORDERS and MAX_NUM_ALGO_ORDERS
+	Replace       bool
+	Price         *string
+	StopPrice     *string
+	Quantity      *string
+	QuoteQuantity *string
+}
+
+// OrderValidator check orders against the symbol filters before they are
+// sent, so that they are not rejected with -1013 errors. Orders of unknown
+// symbols are not checked.
+type OrderValidator struct {
+	// AutoRound rounds the prices to the nearest tick and floors the
+	// quantity to the step before checking them
+	AutoRound bool
+	// ReferencePrice return the average or mark price of the symbol for the
+	// percent price filters and the notional of market orders, they are not
+	// checked if it is nil or returns false
+	ReferencePrice func(symbol string) (Decimal, bool)
+	// OpenOrders return the number of open orders and open algo orders of
+	// the symbol for the MAX_NUM_ORDERS and MAX_NUM_ALGO_ORDERS filters, they
+	// are not checked if it is nil or returns false
+	OpenOrders func(symbol string) (orders, algoOrders int, ok bool)
+
+	lock    sync.RWMutex
+	filters map[string]*SymbolFilters
+}
+
+// NewOrderValidator create a validator with the filters of the symbols
+func NewOrderValidator(filters ...*SymbolFilters) *OrderValidator {
+	v := &OrderValidator{filters: make(map[string]*SymbolFilters)}
+	v.SetFilters(filters...)
+	return v
+}
+
+// SetFilters add or replace the filters of the symbols
+func (v *OrderValidator) SetFilters(filters ...*SymbolFilters) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.filters == nil {
+		v.filters = make(map[string]*SymbolFilters)
+	}
+	for _, f := range filters {
+		v.filters[f.Symbol] = f
+	}
+}
+
+// RemoveFilters remove the filters of the symbols, their orders are not
+// checked anymore
+func (v *OrderValidator) RemoveFilters(symbols ...string) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for _, symbol := range symbols {
+		delete(v.filters, symbol)
+	}
+}
+
+// Filters return the filters of the symbol, nil if unknown
+func (v *OrderValidator) Filters(symbol string) *SymbolFilters {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.filters[symbol]
+}
+
+// Validate check the order, it returns an *OrderFilterError naming the first
+// violated filter
+func (v *OrderValidator) Validate(order *OrderParams) error {
+	f := v.Filters(order.Symbol)
+	if f == nil {
+		return nil
+	}
+	o := orderDecimals{
+		price:         parseParam(order.Price),
+		stopPrice:     parseParam(order.StopPrice),
+		quantity:      parseParam(order.Quantity),
+		quoteQuantity: parseParam(order.QuoteQuantity),
+	}
+	var refPrice *Decimal
+	if v.ReferencePrice != nil {
+		if p, ok := v.ReferencePrice(order.Symbol); ok {
+			refPrice = &p
+		}
+	}
+
+	for _, p := range []struct {
+		field string
+		value *Decimal
+		param *string
+	}{{"price", o.price, order.Price}, {"stopPrice", o.stopPrice, order.StopPrice}} {
+		if p.value == nil || p.value.IsZero() {
+			continue
+		}
+		if v.AutoRound && f.TickSize.Sign() > 0 {
+			*p.value = p.value.RoundToStep(f.TickSize)
+			*p.param = p.value.String()
+		}
+		if err := checkRange(f, "PRICE_FILTER", p.field, *p.value, f.MinPrice, f.MaxPrice, f.TickSize); err != nil {
+			return err
+		}
+	}
+
+	if q := o.quantity; q != nil {
+		if v.AutoRound && (f.StepSize.Sign() > 0 || order.Market && f.MarketStepSize.Sign() > 0) {
+			if f.StepSize.Sign() > 0 {
+				*q = q.FloorToStep(f.StepSize)
+			}
+			if order.Market && f.MarketStepSize.Sign() > 0 {
+				*q = q.FloorToStep(f.MarketStepSize)
+			}
+			*order.Quantity = q.String()
+		}
+		if err := checkRange(f, "LOT_SIZE", "quantity", *q, f.MinQuantity, f.MaxQuantity, f.StepSize); err != nil {
+			return err
+		}
+		if order.Market {
+			err := checkRange(f, "MARKET_LOT_SIZE", "quantity", *q, f.MarketMinQuantity, f.MarketMaxQuantity, f.MarketStepSize)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := checkNotional(f, order.Market, o, refPrice); err != nil {
+		return err
+	}
+
+	if o.price != nil && !o.price.IsZero() && refPrice != nil {
+		up, down := f.BidMultiplierUp, f.BidMultiplierDown
+		if order.Side == "SELL" {
+			up, down = f.AskMultiplierUp, f.AskMultiplierDown
+		}
+		if up.Sign() > 0 && o.price.GreaterThan(refPrice.Mul(up)) {
+			return newOrderFilterError(f, "PERCENT_PRICE", "price", *o.price, fmt.Sprintf("is above %s * %s", refPrice, up))
+		}
+		if down.Sign() > 0 && o.price.LessThan(refPrice.Mul(down)) {
+			return newOrderFilterError(f, "PERCENT_PRICE", "price", *o.price, fmt.Sprintf("is below %s * %s", refPrice, down))
+		}
+	}
+	if order.Replace {
+		return nil
+	}
+	return v.checkOpenOrders(f, order.Algo)
+}
+
+// checkOpenOrders check that one more order does not exceed MAX_NUM_ORDERS,
+// and MAX_NUM_ALGO_ORDERS for an algo order
+func (v *OrderValidator) checkOpenOrders(f *SymbolFilters, algo bool) error {
+	if v.OpenOrders == nil || f.MaxNumOrders <= 0 && (!algo || f.MaxNumAlgoOrders <= 0) {
+		return nil
+	}
+	orders, algoOrders, ok := v.OpenOrders(f.Symbol)
+	if !ok {
+		return nil
+	}
+	if f.MaxNumOrders > 0 && orders >= f.MaxNumOrders {
+		return newOpenOrdersError(f, "MAX_NUM_ORDERS", "orders", orders, f.MaxNumOrders)
+	}
+	if algo && f.MaxNumAlgoOrders > 0 && algoOrders >= f.MaxNumAlgoOrders {
+		return newOpenOrdersError(f, "MAX_NUM_ALGO_ORDERS", "algoOrders", algoOrders, f.MaxNumAlgoOrders)
+	}
+	return nil
+}
+
+type orderDecimals struct {
+	price         *Decimal
+	stopPrice     *Decimal
+	quantity      *Decimal
+	quoteQuantity *Decimal
+}
+
+func parseParam(param *string) *Decimal {
+	if param == nil {
+		return nil
+	}
+	d, err := ParseDecimal(*param)
+	if err != nil {
+		return nil
+	}
+	return &d
+}
+
+func checkNotional(f *SymbolFilters, market bool, o orderDecimals, refPrice *Decimal) error {
+	var notional Decimal
+	switch {
+	case o.quoteQuantity != nil:
+		notional = *o.quoteQuantity
+	case o.quantity == nil:
+		return nil
+	case !market && o.price != nil && !o.price.IsZero():
+		notional = o.quantity.Mul(*o.price)
+	case refPrice != nil:
+		notional = o.quantity.Mul(*refPrice)
+	default:
+		return nil
+	}
+	if f.MinNotional.Sign() > 0 && (!market || f.ApplyMinToMarket) && notional.LessThan(f.MinNotional) {
+		return newOrderFilterError(f, "NOTIONAL", "notional", notional, "is below "+f.MinNotional.String())
+	}
+	if f.MaxNotional.Sign() > 0 && (!market || f.ApplyMaxToMarket) && notional.GreaterThan(f.MaxNotional) {
+		return newOrderFilterError(f, "NOTIONAL", "notional", notional, "is above "+f.MaxNotional.String())
+	}
+	return nil
+}
+
+func checkRange(f *SymbolFilters, filter, field string, value, min, max, step Decimal) error {
+	if min.Sign() > 0 && value.LessThan(min) {
+		return newOrderFilterError(f, filter, field, value, "is below "+min.String())
+	}
+	if max.Sign() > 0 && value.GreaterThan(max) {
+		return newOrderFilterError(f, filter, field, value, "is above "+max.String())
+	}
+	if step.Sign() > 0 && !value.Sub(min).FloorToStep(step).Equal(value.Sub(min)) {
+		return newOrderFilterError(f, filter, field, value, "is not a multiple of "+step.String())
+	}
+	return nil
+}
+
+func newOpenOrdersError(f *SymbolFilters, filter, field string, open, max int) *OrderFilterError {
+	return &OrderFilterError{
+		Symbol: f.Symbol,
+		Filter: filter,
+		Field:  field,
+		Value:  strconv.Itoa(open),
+		Reason: "reach the maximum of " + strconv.Itoa(max),
+	}
+}
+
+func newOrderFilterError(f *SymbolFilters, filter, field string, value Decimal, reason string) *OrderFilterError {
+	return &OrderFilterError{
+		Symbol: f.Symbol,
+		Filter: filter,
+		Field:  field,
+		Value:  value.String(),
+		Reason: reason,
+	}
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrderValidator() *OrderValidator {
+	return NewOrderValidator(&SymbolFilters{
+		Symbol:            "BTCUSDT",
+		MinPrice:          MustParseDecimal("0.01000000"),
+		MaxPrice:          MustParseDecimal("1000000.00000000"),
+		TickSize:          MustParseDecimal("0.01000000"),
+		MinQuantity:       MustParseDecimal("0.00001000"),
+		MaxQuantity:       MustParseDecimal("9000.00000000"),
+		StepSize:          MustParseDecimal("0.00001000"),
+		MarketMaxQuantity: MustParseDecimal("100.00000000"),
+		MinNotional:       MustParseDecimal("5.00000000"),
+		ApplyMinToMarket:  true,
+		BidMultiplierUp:   MustParseDecimal("5"),
+		BidMultiplierDown: MustParseDecimal("0.2"),
+		AskMultiplierUp:   MustParseDecimal("5"),
+		AskMultiplierDown: MustParseDecimal("0.2"),
+	})
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func assertOrderFilterError(t *testing.T, err error, filter, field string) {
+	require.Error(t, err)
+	require.True(t, IsOrderFilterError(err), err.Error())
+	e := err.(*OrderFilterError)
+	assert.Equal(t, "BTCUSDT", e.Symbol)
+	assert.Equal(t, filter, e.Filter)
+	assert.Equal(t, field, e.Field)
+}
+
+func TestOrderValidator(t *testing.T) {
+	v := newTestOrderValidator()
+	order := func(price, quantity string) *OrderParams {
+		return &OrderParams{Symbol: "BTCUSDT", Side: "BUY", Price: strPtr(price), Quantity: strPtr(quantity)}
+	}
+	assert.NoError(t, v.Validate(order("20000.01", "0.001")))
+	assert.NoError(t, v.Validate(&OrderParams{Symbol: "ETHUSDT", Price: strPtr("0.001")}))
+
+	assertOrderFilterError(t, v.Validate(order("0.001", "1")), "PRICE_FILTER", "price")
+	assertOrderFilterError(t, v.Validate(order("2000000", "1")), "PRICE_FILTER", "price")
+	assertOrderFilterError(t, v.Validate(order("20000.015", "1")), "PRICE_FILTER", "price")
+	assertOrderFilterError(t, v.Validate(order("20000", "0.000001")), "LOT_SIZE", "quantity")
+	assertOrderFilterError(t, v.Validate(order("20000", "9001")), "LOT_SIZE", "quantity")
+	assertOrderFilterError(t, v.Validate(order("20000", "0.000015")), "LOT_SIZE", "quantity")
+	assertOrderFilterError(t, v.Validate(order("100", "0.01")), "NOTIONAL", "notional")
+
+	err := v.Validate(&OrderParams{Symbol: "BTCUSDT", StopPrice: strPtr("1.001"), Price: strPtr("1"), Quantity: strPtr("10")})
+	assertOrderFilterError(t, err, "PRICE_FILTER", "stopPrice")
+	assert.Equal(t, "<OrderFilterError> symbol=BTCUSDT, filter=PRICE_FILTER, stopPrice 1.001 is not a multiple of 0.01000000", err.Error())
+}
+
+func TestOrderValidatorMarket(t *testing.T) {
+	v := newTestOrderValidator()
+	market := &OrderParams{Symbol: "BTCUSDT", Side: "SELL", Market: true, Quantity: strPtr("101")}
+	assertOrderFilterError(t, v.Validate(market), "MARKET_LOT_SIZE", "quantity")
+
+	// the notional of market orders needs a reference price
+	market.Quantity = strPtr("0.0001")
+	assert.NoError(t, v.Validate(market))
+	v.ReferencePrice = func(symbol string) (Decimal, bool) {
+		return MustParseDecimal("20000"), true
+	}
+	assertOrderFilterError(t, v.Validate(market), "NOTIONAL", "notional")
+	assertOrderFilterError(t, v.Validate(&OrderParams{Symbol: "BTCUSDT", Market: true, QuoteQuantity: strPtr("1")}), "NOTIONAL", "notional")
+}
+
+func TestOrderValidatorPercentPrice(t *testing.T) {
+	v := newTestOrderValidator()
+	v.ReferencePrice = func(symbol string) (Decimal, bool) {
+		return MustParseDecimal("20000"), true
+	}
+	assert.NoError(t, v.Validate(&OrderParams{Symbol: "BTCUSDT", Side: "BUY", Price: strPtr("4000"), Quantity: strPtr("1")}))
+	assertOrderFilterError(t, v.Validate(&OrderParams{Symbol: "BTCUSDT", Side: "BUY", Price: strPtr("3999.99"), Quantity: strPtr("1")}), "PERCENT_PRICE", "price")
+	assertOrderFilterError(t, v.Validate(&OrderParams{Symbol: "BTCUSDT", Side: "SELL", Price: strPtr("100000.01"), Quantity: strPtr("1")}), "PERCENT_PRICE", "price")
+}
+
+func TestOrderValidatorAutoRound(t *testing.T) {
+	v := newTestOrderValidator()
+	v.AutoRound = true
+	order := &OrderParams{
+		Symbol:    "BTCUSDT",
+		Side:      "BUY",
+		Price:     strPtr("20000.016"),
+		StopPrice: strPtr("19999.994"),
+		Quantity:  strPtr("0.123456789"),
+	}
+	require.NoError(t, v.Validate(order))
+	assert.Equal(t, "20000.02000000", *order.Price)
+	assert.Equal(t, "19999.99000000", *order.StopPrice)
+	assert.Equal(t, "0.12345000", *order.Quantity)
+
+	// rounded down below the minimum
+	assertOrderFilterError(t, v.Validate(&OrderParams{Symbol: "BTCUSDT", Price: strPtr("20000"), Quantity: strPtr("0.000009")}), "LOT_SIZE", "quantity")
+}
+
+func TestOrderValidatorOpenOrders(t *testing.T) {
+	v := newTestOrderValidator()
+	f := v.Filters("BTCUSDT")
+	f.MaxNumOrders = 200
+	f.MaxNumAlgoOrders = 5
+	order := &OrderParams{Symbol: "BTCUSDT", Side: "BUY", Price: strPtr("20000"), Quantity: strPtr("1")}
+	algo := &OrderParams{Symbol: "BTCUSDT", Side: "BUY", Algo: true, Price: strPtr("20000"), StopPrice: strPtr("20000"), Quantity: strPtr("1")}
+
+	// the open orders are not checked without their count
+	assert.NoError(t, v.Validate(order))
+	orders, algoOrders := 199, 5
+	v.OpenOrders = func(symbol string) (int, int, bool) {
+		return orders, algoOrders, true
+	}
+	assert.NoError(t, v.Validate(order))
+	err := v.Validate(algo)
+	assertOrderFilterError(t, err, "MAX_NUM_ALGO_ORDERS", "algoOrders")
+	assert.Equal(t, "<OrderFilterError> symbol=BTCUSDT, filter=MAX_NUM_ALGO_ORDERS, algoOrders 5 reach the maximum of 5", err.Error())
+
+	orders = 200
+	assertOrderFilterError(t, v.Validate(order), "MAX_NUM_ORDERS", "orders")
+
+	// a replaced order does not add an open order
+	order.Replace = true
+	assert.NoError(t, v.Validate(order))
+}
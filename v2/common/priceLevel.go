@@ -23,3 +23,16 @@ func (p *PriceLevel) Parse() (float64, float64, error) {
 	}
 	return price, quantity, nil
 }
+
+// ParseDecimal is like Parse, but returns exact decimals
+func (p *PriceLevel) ParseDecimal() (Decimal, Decimal, error) {
+	price, err := ParseDecimal(p.Price)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	quantity, err := ParseDecimal(p.Quantity)
+	if err != nil {
+		return price, Decimal{}, err
+	}
+	return price, quantity, nil
+}
@@ -0,0 +1,269 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitBackoff is the backoff after a 429 or 418 response which
+// does not tell when to retry
+const DefaultRateLimitBackoff = time.Minute
+
+// RateLimit types
+const (
+	RateLimitTypeRequestWeight = "REQUEST_WEIGHT"
+	RateLimitTypeOrders        = "ORDERS"
+	RateLimitTypeRawRequests   = "RAW_REQUESTS"
+)
+
+// RateLimit is a limit of the exchange, or its usage reported by a response.
+// Limit is 0 when it is unknown, e.g. from the response headers.
+type RateLimit struct {
+	Type     string
+	Interval time.Duration
+	Limit    int
+	Count    int
+}
+
+// RateLimitInterval convert the interval of the exchange info, e.g. MINUTE
+// and 1, to a duration. It returns 0 for an unknown interval.
+func RateLimitInterval(interval string, num int) time.Duration {
+	var unit time.Duration
+	switch strings.ToUpper(interval) {
+	case "SECOND", "S":
+		unit = time.Second
+	case "MINUTE", "M":
+		unit = time.Minute
+	case "HOUR", "H":
+		unit = time.Hour
+	case "DAY", "D":
+		unit = 24 * time.Hour
+	}
+	return unit * time.Duration(num)
+}
+
+// RateLimitsFromHeader parse the usage from the X-MBX-USED-WEIGHT-<interval>
+// and X-MBX-ORDER-COUNT-<interval> headers of a response
+func RateLimitsFromHeader(header http.Header) []RateLimit {
+	var res []RateLimit
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		key = strings.ToUpper(key)
+		var typ, suffix string
+		switch {
+		case strings.HasPrefix(key, "X-MBX-USED-WEIGHT-"):
+			typ, suffix = RateLimitTypeRequestWeight, strings.TrimPrefix(key, "X-MBX-USED-WEIGHT-")
+		case strings.HasPrefix(key, "X-MBX-ORDER-COUNT-"):
+			typ, suffix = RateLimitTypeOrders, strings.TrimPrefix(key, "X-MBX-ORDER-COUNT-")
+		default:
+			continue
+		}
+		if len(suffix) < 2 {
+			continue
+		}
+		num, err := strconv.Atoi(suffix[:len(suffix)-1])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(values[0])
+		if err != nil {
+			continue
+		}
+		interval := RateLimitInterval(suffix[len(suffix)-1:], num)
+		if interval == 0 {
+			continue
+		}
+		res = append(res, RateLimit{Type: typ, Interval: interval, Count: count})
+	}
+	return res
+}
+
+// RetryAfterFromHeader return the Retry-After delay of a 429 or 418 response,
+// DefaultRateLimitBackoff if it is missing
+func RetryAfterFromHeader(header http.Header) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return DefaultRateLimitBackoff
+}
+
+// RateLimitGovernor keeps the calls of a client under the rate limits of the
+// exchange. The calls are counted in the fixed windows of each limit, and the
+// counts are corrected with the usage reported by the responses. After a 429
+// or 418 response all the calls wait until the Retry-After delay.
+//
+// The limits are set from the exchange info, and are learned from the
+// WebSocket API responses. The calls are not limited until they are known.
+type RateLimitGovernor struct {
+	// FailFast returns a *RateLimitError instead of waiting for the window
+	// of the exceeded limit
+	FailFast bool
+	// Weight overrides the request weight of an endpoint given by the client
+	// when it returns more than 0, it may be nil. The responses correct the
+	// counts anyway.
+	Weight func(method, endpoint string) int
+
+	lock         sync.Mutex
+	counters     []*rateLimitCounter
+	backoffUntil time.Time
+	now          func() time.Time
+}
+
+type rateLimitCounter struct {
+	RateLimit
+	window time.Time
+}
+
+// NewRateLimitGovernor create a governor with the limits of the exchange
+func NewRateLimitGovernor(limits ...RateLimit) *RateLimitGovernor {
+	g := &RateLimitGovernor{}
+	g.Update(limits...)
+	return g
+}
+
+// Update set the limits and the counts of the current windows, a Limit of 0
+// keeps the known limit
+func (g *RateLimitGovernor) Update(limits ...RateLimit) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.timeNow()
+	for _, l := range limits {
+		if l.Interval <= 0 {
+			continue
+		}
+		c := g.counter(l.Type, l.Interval)
+		if c == nil {
+			c = &rateLimitCounter{RateLimit: RateLimit{Type: l.Type, Interval: l.Interval}}
+			g.counters = append(g.counters, c)
+		}
+		if l.Limit > 0 {
+			c.Limit = l.Limit
+		}
+		c.reset(now)
+		// the calls in flight may not be counted by the server yet
+		if l.Count > c.Count {
+			c.Count = l.Count
+		}
+	}
+}
+
+// Backoff block all the calls for d, as required by a 429 or 418 response
+func (g *RateLimitGovernor) Backoff(d time.Duration) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if until := g.timeNow().Add(d); until.After(g.backoffUntil) {
+		g.backoffUntil = until
+	}
+}
+
+// Usage return the limits with the counts of the current windows
+func (g *RateLimitGovernor) Usage() []RateLimit {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.timeNow()
+	res := make([]RateLimit, 0, len(g.counters))
+	for _, c := range g.counters {
+		c.reset(now)
+		res = append(res, c.RateLimit)
+	}
+	return res
+}
+
+// Acquire count a call to the endpoint of the given request weight which
+// places the given number of orders, waiting until the limits allow it unless
+// FailFast is set. A weight under 1 counts 1.
+func (g *RateLimitGovernor) Acquire(ctx context.Context, method, endpoint string, weight, orders int) error {
+	if g.Weight != nil {
+		if w := g.Weight(method, endpoint); w > 0 {
+			weight = w
+		}
+	}
+	if weight < 1 {
+		weight = 1
+	}
+	for {
+		wait, err := g.tryAcquire(weight, orders)
+		if err != nil || wait <= 0 {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (g *RateLimitGovernor) tryAcquire(weight, orders int) (time.Duration, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.timeNow()
+	if wait := g.backoffUntil.Sub(now); wait > 0 {
+		if g.FailFast {
+			return 0, &RateLimitError{RetryAfter: wait}
+		}
+		return wait, nil
+	}
+	for _, c := range g.counters {
+		c.reset(now)
+		n := c.cost(weight, orders)
+		// a call heavier than the limit is let through an empty window
+		if n == 0 || c.Limit <= 0 || c.Count == 0 || c.Count+n <= c.Limit {
+			continue
+		}
+		wait := c.window.Add(c.Interval).Sub(now)
+		if g.FailFast {
+			return 0, &RateLimitError{Type: c.Type, Interval: c.Interval, Limit: c.Limit, Count: c.Count, RetryAfter: wait}
+		}
+		return wait, nil
+	}
+	for _, c := range g.counters {
+		c.Count += c.cost(weight, orders)
+	}
+	return 0, nil
+}
+
+func (g *RateLimitGovernor) counter(typ string, interval time.Duration) *rateLimitCounter {
+	for _, c := range g.counters {
+		if c.Type == typ && c.Interval == interval {
+			return c
+		}
+	}
+	return nil
+}
+
+func (g *RateLimitGovernor) timeNow() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+	return time.Now()
+}
+
+// reset the count when a new window is started, the windows are aligned on
+// the interval as the exchange does
+func (c *rateLimitCounter) reset(now time.Time) {
+	if window := now.Truncate(c.Interval); !window.Equal(c.window) {
+		c.window = window
+		c.Count = 0
+	}
+}
+
+func (c *rateLimitCounter) cost(weight, orders int) int {
+	switch c.Type {
+	case RateLimitTypeRequestWeight:
+		return weight
+	case RateLimitTypeOrders:
+		return orders
+	case RateLimitTypeRawRequests:
+		return 1
+	}
+	return 0
+}
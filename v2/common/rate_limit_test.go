@@ -0,0 +1,158 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestGovernor(clock *fakeClock, limits ...RateLimit) *RateLimitGovernor {
+	g := NewRateLimitGovernor(limits...)
+	g.now = clock.now
+	return g
+}
+
+func TestRateLimitInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, RateLimitInterval("MINUTE", 1))
+	assert.Equal(t, 10*time.Second, RateLimitInterval("SECOND", 10))
+	assert.Equal(t, 24*time.Hour, RateLimitInterval("DAY", 1))
+	assert.Equal(t, 5*time.Minute, RateLimitInterval("m", 5))
+	assert.Equal(t, time.Duration(0), RateLimitInterval("WEEK", 1))
+}
+
+func TestRateLimitsFromHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-MBX-USED-WEIGHT-1M", "120")
+	header.Set("X-MBX-ORDER-COUNT-10S", "3")
+	header.Set("X-MBX-ORDER-COUNT-1D", "42")
+	header.Set("X-MBX-USED-WEIGHT", "120")
+	header.Set("Content-Type", "application/json")
+	limits := RateLimitsFromHeader(header)
+	sort.Slice(limits, func(i, j int) bool { return limits[i].Interval < limits[j].Interval })
+	assert.Equal(t, []RateLimit{
+		{Type: RateLimitTypeOrders, Interval: 10 * time.Second, Count: 3},
+		{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Count: 120},
+		{Type: RateLimitTypeOrders, Interval: 24 * time.Hour, Count: 42},
+	}, limits)
+}
+
+func TestRetryAfterFromHeader(t *testing.T) {
+	header := http.Header{}
+	assert.Equal(t, DefaultRateLimitBackoff, RetryAfterFromHeader(header))
+	header.Set("Retry-After", "30")
+	assert.Equal(t, 30*time.Second, RetryAfterFromHeader(header))
+}
+
+func TestRateLimitGovernorFailFast(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC)}
+	g := newTestGovernor(clock,
+		RateLimit{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Limit: 10},
+		RateLimit{Type: RateLimitTypeOrders, Interval: 10 * time.Second, Limit: 2},
+	)
+	g.FailFast = true
+	g.Weight = func(method, endpoint string) int {
+		if endpoint == "/api/v3/depth" {
+			return 5
+		}
+		return 0
+	}
+	ctx := context.Background()
+
+	require.NoError(t, g.Acquire(ctx, http.MethodPost, "/api/v3/order", 1, 1))
+	require.NoError(t, g.Acquire(ctx, http.MethodPost, "/api/v3/order", 1, 1))
+	err := g.Acquire(ctx, http.MethodPost, "/api/v3/order", 1, 1)
+	require.True(t, IsRateLimitError(err))
+	assert.Equal(t, &RateLimitError{
+		Type:       RateLimitTypeOrders,
+		Interval:   10 * time.Second,
+		Limit:      2,
+		Count:      2,
+		RetryAfter: 10 * time.Second,
+	}, err)
+
+	// the orders are counted again in the next window
+	clock.t = clock.t.Add(10 * time.Second)
+	require.NoError(t, g.Acquire(ctx, http.MethodPost, "/api/v3/order", 1, 1))
+
+	require.NoError(t, g.Acquire(ctx, http.MethodGet, "/api/v3/depth", 1, 0))
+	err = g.Acquire(ctx, http.MethodGet, "/api/v3/depth", 1, 0)
+	require.True(t, IsRateLimitError(err))
+	assert.Equal(t, RateLimitTypeRequestWeight, err.(*RateLimitError).Type)
+	assert.Equal(t, 40*time.Second, err.(*RateLimitError).RetryAfter)
+}
+
+func TestRateLimitGovernorUpdate(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := newTestGovernor(clock, RateLimit{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Limit: 100})
+	g.FailFast = true
+	require.NoError(t, g.Acquire(context.Background(), http.MethodGet, "/api/v3/time", 1, 0))
+
+	// the usage reported by the server is used when it is higher
+	g.Update(RateLimit{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Count: 100})
+	g.Update(RateLimit{Type: RateLimitTypeOrders, Interval: 24 * time.Hour, Count: 7})
+	assert.Equal(t, []RateLimit{
+		{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Limit: 100, Count: 100},
+		{Type: RateLimitTypeOrders, Interval: 24 * time.Hour, Count: 7},
+	}, g.Usage())
+	assert.True(t, IsRateLimitError(g.Acquire(context.Background(), http.MethodGet, "/api/v3/time", 1, 0)))
+
+	// a limit without Limit does not block the calls
+	clock.t = clock.t.Add(time.Minute)
+	g.Update(RateLimit{Type: RateLimitTypeOrders, Interval: 24 * time.Hour, Count: 1000000})
+	require.NoError(t, g.Acquire(context.Background(), http.MethodPost, "/api/v3/order", 1, 1))
+}
+
+func TestRateLimitGovernorBackoff(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := newTestGovernor(clock)
+	g.FailFast = true
+	g.Backoff(30 * time.Second)
+	g.Backoff(10 * time.Second)
+	err := g.Acquire(context.Background(), http.MethodGet, "/api/v3/time", 1, 0)
+	assert.Equal(t, &RateLimitError{RetryAfter: 30 * time.Second}, err)
+	clock.t = clock.t.Add(30 * time.Second)
+	assert.NoError(t, g.Acquire(context.Background(), http.MethodGet, "/api/v3/time", 1, 0))
+}
+
+func TestRateLimitGovernorWait(t *testing.T) {
+	g := NewRateLimitGovernor()
+	g.Backoff(20 * time.Millisecond)
+	start := time.Now()
+	require.NoError(t, g.Acquire(context.Background(), http.MethodGet, "/api/v3/time", 1, 0))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	g.Backoff(time.Minute)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, g.Acquire(ctx, http.MethodGet, "/api/v3/time", 1, 0))
+}
+
+func TestRateLimitGovernorWeight(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := newTestGovernor(clock, RateLimit{Type: RateLimitTypeRequestWeight, Interval: time.Minute, Limit: 300})
+	g.FailFast = true
+	ctx := context.Background()
+
+	// the weight of a depth of 5000 levels given by the client
+	require.NoError(t, g.Acquire(ctx, http.MethodGet, "/api/v3/depth", 250, 0))
+	err := g.Acquire(ctx, http.MethodGet, "/api/v3/depth", 250, 0)
+	require.True(t, IsRateLimitError(err))
+	assert.Equal(t, 250, err.(*RateLimitError).Count)
+
+	// a weight under 1 counts 1
+	require.NoError(t, g.Acquire(ctx, http.MethodGet, "/api/v3/time", 0, 0))
+	assert.Equal(t, 251, g.Usage()[0].Count)
+}
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ReconnectPolicy define how a dropped websocket connection is re-established
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after each failed attempt
+	Multiplier float64
+	// Jitter randomizes the delay by up to the given fraction, in [0, 1]
+	Jitter float64
+	// MaxAttempts gives up after that many failed attempts, 0 for no limit
+	MaxAttempts int
+	// Context stops reconnecting once it is done, nil for no cancellation
+	Context context.Context
+
+	// OnDisconnect is called with the read error when the connection drops
+	OnDisconnect func(err error)
+	// OnReconnect is called with the number of attempts once reconnected
+	OnReconnect func(attempts int)
+	// OnGiveUp is called with the last error when reconnecting is abandoned,
+	// not when it is stopped by closing the client or by Context
+	OnGiveUp func(err error)
+}
+
+// DefaultReconnectPolicy reconnects forever, backing off from 1s up to 1m
+func DefaultReconnectPolicy() *ReconnectPolicy {
+	return &ReconnectPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Backoff return the delay before the given attempt, starting from 1
+func (p *ReconnectPolicy) Backoff(attempt int) time.Duration {
+	return exponentialBackoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter, attempt)
+}
+
+func exponentialBackoff(initial, max time.Duration, multiplier, jitter float64, attempt int) time.Duration {
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	ceiling := float64(max)
+	if max <= 0 {
+		// without MaxBackoff the delay is only kept from overflowing, the
+		// power may be +Inf after many attempts
+		ceiling = float64(math.MaxInt64)
+	}
+	backoff := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if backoff > ceiling {
+		backoff = ceiling
+	}
+	if jitter > 0 {
+		backoff += backoff * jitter * (2*rand.Float64() - 1)
+	}
+	if backoff >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(backoff)
+}
+
+// Wait wait for the backoff of the given attempt, return the error of
+// Context or stopC if reconnecting should be stopped
+func (p *ReconnectPolicy) Wait(attempt int, stopC <-chan struct{}) error {
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(p.Backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-stopC:
+		return ErrReconnectStopped
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Stopped return true if err is returned by Wait because reconnecting is
+// stopped on purpose, by closing the client or by Context
+func (p *ReconnectPolicy) Stopped(err error) bool {
+	if err == ErrReconnectStopped {
+		return true
+	}
+	return err != nil && p.Context != nil && err == p.Context.Err()
+}
+
+// ShouldRetry return false once MaxAttempts is reached
+func (p *ReconnectPolicy) ShouldRetry(attempt int) bool {
+	return p.MaxAttempts <= 0 || attempt < p.MaxAttempts
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	p := &ReconnectPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, time.Second, p.Backoff(1))
+	assert.Equal(t, 2*time.Second, p.Backoff(2))
+	assert.Equal(t, 8*time.Second, p.Backoff(4))
+	assert.Equal(t, 10*time.Second, p.Backoff(5))
+	assert.Equal(t, 10*time.Second, p.Backoff(100))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		backoff := p.Backoff(2)
+		assert.GreaterOrEqual(t, backoff, time.Second)
+		assert.LessOrEqual(t, backoff, 3*time.Second)
+	}
+}
+
+func TestReconnectPolicyBackoffWithoutMax(t *testing.T) {
+	p := &ReconnectPolicy{
+		InitialBackoff: time.Second,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 4*time.Second, p.Backoff(3))
+	assert.Equal(t, time.Duration(math.MaxInt64), p.Backoff(100))
+	assert.Equal(t, time.Duration(math.MaxInt64), p.Backoff(2000))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		assert.Greater(t, p.Backoff(2000), time.Duration(0))
+	}
+}
+
+func TestReconnectPolicyShouldRetry(t *testing.T) {
+	p := DefaultReconnectPolicy()
+	assert.True(t, p.ShouldRetry(1000))
+	p.MaxAttempts = 2
+	assert.True(t, p.ShouldRetry(1))
+	assert.False(t, p.ShouldRetry(2))
+}
+
+func TestReconnectPolicyWait(t *testing.T) {
+	p := &ReconnectPolicy{InitialBackoff: time.Millisecond}
+	assert.NoError(t, p.Wait(1, nil))
+
+	p.InitialBackoff = time.Hour
+	stopC := make(chan struct{})
+	close(stopC)
+	assert.Equal(t, ErrReconnectStopped, p.Wait(1, stopC))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Context = ctx
+	assert.Equal(t, context.Canceled, p.Wait(1, nil))
+}
+
+func TestReconnectPolicyStopped(t *testing.T) {
+	p := DefaultReconnectPolicy()
+	assert.True(t, p.Stopped(ErrReconnectStopped))
+	assert.False(t, p.Stopped(nil))
+	assert.False(t, p.Stopped(context.Canceled))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Context = ctx
+	assert.False(t, p.Stopped(context.Canceled))
+	cancel()
+	assert.True(t, p.Stopped(p.Wait(1, nil)))
+	assert.False(t, p.Stopped(errors.New("dial failed")))
+}
@@ -0,0 +1,171 @@
+package common
+
+import (
+	"fmt"
+	"time"
+)
+
+// StreamGap is an outage of a resilient stream, the events sent by the
+// server between DisconnectedAt and ReconnectedAt are lost
+type StreamGap struct {
+	Endpoint       string
+	DisconnectedAt time.Time
+	ReconnectedAt  time.Time
+	// Duration is the outage, from the disconnection to the reconnection
+	Duration time.Duration
+	// Attempts is the number of connections tried to reconnect
+	Attempts int
+}
+
+// StreamGapError is given to the error handler of a resilient stream once it
+// is reconnected after a gap, so that each stream can resync its own state
+type StreamGapError struct {
+	StreamGap
+}
+
+func (e *StreamGapError) Error() string {
+	return fmt.Sprintf("stream %s reconnected after %s, events may be missed", e.Endpoint, e.Duration)
+}
+
+// ResilientStreams make the market data streams reconnect when they drop,
+// and before Binance cuts them after 24h. The streams are served again from
+// the same endpoint, so the same streams are restored. After each gap the
+// error handler of the stream receives a *StreamGapError, and OnGap is
+// called, to tell that events may have been missed, e.g. to resync an order
+// book.
+//
+// The renewals before 24h connect the new connection before closing the old
+// one, so they make no gap, but the events received in between may be
+// delivered twice.
+//
+// The doneC of a resilient stream is closed only when its stopC is closed or
+// reconnecting is given up.
+type ResilientStreams struct {
+	// ReconnectPolicy defaults to DefaultReconnectPolicy
+	ReconnectPolicy *ReconnectPolicy
+	// MaxConnectionAge defaults to DefaultMaxConnectionAge
+	MaxConnectionAge time.Duration
+	// OnGap is called after each reconnection of all the streams, may be nil
+	OnGap func(gap StreamGap)
+
+	now func() time.Time
+}
+
+// Serve connect a stream with serve, and connect it again when the doneC of
+// serve is closed. The errors of the connections and the gaps are given to
+// errHandler, the reconnections to instrumentation, which may be nil.
+func (r *ResilientStreams) Serve(endpoint string, instrumentation Instrumentation, serve func() (doneC, stopC chan struct{}, err error), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	connDoneC, connStopC, err := serve()
+	if err != nil {
+		return nil, nil, err
+	}
+	doneC = make(chan struct{})
+	stopC = make(chan struct{})
+	s := &resilientStream{
+		ResilientStreams: r,
+		endpoint:         endpoint,
+		instrumentation:  InstrumentationOrNoop(instrumentation),
+		serve:            serve,
+		errHandler:       errHandler,
+	}
+	go s.run(connDoneC, connStopC, doneC, stopC)
+	return doneC, stopC, nil
+}
+
+// resilientStream is a stream served by ResilientStreams
+type resilientStream struct {
+	*ResilientStreams
+	endpoint        string
+	instrumentation Instrumentation
+	serve           func() (doneC, stopC chan struct{}, err error)
+	errHandler      func(err error)
+}
+
+func (s *resilientStream) run(connDoneC, connStopC, doneC, stopC chan struct{}) {
+	defer close(doneC)
+
+	maxConnectionAge := s.MaxConnectionAge
+	if maxConnectionAge <= 0 {
+		maxConnectionAge = DefaultMaxConnectionAge
+	}
+	policy := s.ReconnectPolicy
+	if policy == nil {
+		policy = DefaultReconnectPolicy()
+	}
+	age := time.NewTimer(maxConnectionAge)
+	defer age.Stop()
+
+	for {
+		select {
+		case <-stopC:
+			close(connStopC)
+			<-connDoneC
+			return
+		case <-connDoneC:
+			if policy.OnDisconnect != nil {
+				policy.OnDisconnect(ErrStreamDisconnected)
+			}
+		case <-age.C:
+			// renew without backoff, the old connection is closed only once
+			// the new one is connected
+			newDoneC, newStopC, err := s.serve()
+			s.instrumentation.WsReconnect(s.endpoint, 1, err)
+			close(connStopC)
+			<-connDoneC
+			if err == nil {
+				connDoneC, connStopC = newDoneC, newStopC
+				resetTimer(age, maxConnectionAge)
+				continue
+			}
+			s.errHandler(err)
+		}
+		disconnectedAt := s.time()
+
+		var err error
+		attempt := 1
+		for ; ; attempt++ {
+			err = policy.Wait(attempt, stopC)
+			if err != nil {
+				break
+			}
+			connDoneC, connStopC, err = s.serve()
+			s.instrumentation.WsReconnect(s.endpoint, attempt, err)
+			if err == nil {
+				break
+			}
+			s.errHandler(err)
+			if !policy.ShouldRetry(attempt) {
+				break
+			}
+		}
+		if err != nil {
+			if !policy.Stopped(err) && policy.OnGiveUp != nil {
+				policy.OnGiveUp(err)
+			}
+			return
+		}
+		if policy.OnReconnect != nil {
+			policy.OnReconnect(attempt)
+		}
+		reconnectedAt := s.time()
+		gap := StreamGap{
+			Endpoint:       s.endpoint,
+			DisconnectedAt: disconnectedAt,
+			ReconnectedAt:  reconnectedAt,
+			Duration:       reconnectedAt.Sub(disconnectedAt),
+			Attempts:       attempt,
+		}
+		s.errHandler(&StreamGapError{StreamGap: gap})
+		if s.OnGap != nil {
+			s.OnGap(gap)
+		}
+		resetTimer(age, maxConnectionAge)
+	}
+}
+
+func (r *ResilientStreams) time() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
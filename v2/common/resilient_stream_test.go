@@ -0,0 +1,247 @@
+package common
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMarketConn struct {
+	doneC chan struct{}
+	stopC chan struct{}
+	once  sync.Once
+	// overlapped is true if the previous connection was still open
+	overlapped bool
+}
+
+// drop simulate the server closing the connection
+func (c *fakeMarketConn) drop() {
+	c.once.Do(func() { close(c.doneC) })
+}
+
+type fakeMarketServer struct {
+	mu       sync.Mutex
+	failures int
+	connC    chan *fakeMarketConn
+	last     *fakeMarketConn
+	errs     []error
+}
+
+func newFakeMarketServer() *fakeMarketServer {
+	return &fakeMarketServer{connC: make(chan *fakeMarketConn, 10)}
+}
+
+func (s *fakeMarketServer) serve() (doneC, stopC chan struct{}, err error) {
+	s.mu.Lock()
+	if s.failures > 0 {
+		s.failures--
+		s.mu.Unlock()
+		return nil, nil, errors.New("dial failed")
+	}
+	conn := &fakeMarketConn{doneC: make(chan struct{}), stopC: make(chan struct{})}
+	if s.last != nil {
+		select {
+		case <-s.last.doneC:
+		default:
+			conn.overlapped = true
+		}
+	}
+	s.last = conn
+	s.mu.Unlock()
+	go func() {
+		select {
+		case <-conn.stopC:
+			conn.drop()
+		case <-conn.doneC:
+		}
+	}()
+	s.connC <- conn
+	return conn.doneC, conn.stopC, nil
+}
+
+func (s *fakeMarketServer) errHandler(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+func (s *fakeMarketServer) next(t *testing.T) *fakeMarketConn {
+	select {
+	case conn := <-s.connC:
+		return conn
+	case <-time.After(time.Second):
+		t.Fatal("the stream was not connected")
+		return nil
+	}
+}
+
+// reconnectRecorder record the reconnection attempts
+type reconnectRecorder struct {
+	NoopInstrumentation
+	mu       sync.Mutex
+	attempts []int
+	errs     []error
+}
+
+func (r *reconnectRecorder) WsReconnect(endpoint string, attempt int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, attempt)
+	r.errs = append(r.errs, err)
+}
+
+func fastReconnectPolicy() *ReconnectPolicy {
+	return &ReconnectPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+}
+
+func TestResilientStreamsReconnect(t *testing.T) {
+	server := newFakeMarketServer()
+	gapC := make(chan StreamGap, 1)
+	now := time.Unix(1700000000, 0)
+	var mu sync.Mutex
+	r := &ResilientStreams{
+		ReconnectPolicy: fastReconnectPolicy(),
+		OnGap:           func(gap StreamGap) { gapC <- gap },
+		now: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			now = now.Add(3 * time.Second)
+			return now
+		},
+	}
+	recorder := &reconnectRecorder{}
+	doneC, stopC, err := r.Serve("wss://stream/ws/btcusdt@depth", recorder, server.serve, server.errHandler)
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	server.failures = 2
+	server.mu.Unlock()
+	server.next(t).drop()
+	conn := server.next(t)
+
+	select {
+	case gap := <-gapC:
+		assert.Equal(t, "wss://stream/ws/btcusdt@depth", gap.Endpoint)
+		assert.Equal(t, 3, gap.Attempts)
+		assert.Equal(t, 3*time.Second, gap.Duration)
+		assert.Equal(t, gap.DisconnectedAt.Add(gap.Duration), gap.ReconnectedAt)
+	case <-time.After(time.Second):
+		t.Fatal("the gap was not signaled")
+	}
+	server.mu.Lock()
+	// the dial errors, then the gap for the error handler of the stream
+	require.Len(t, server.errs, 3)
+	var gapErr *StreamGapError
+	require.True(t, errors.As(server.errs[2], &gapErr))
+	assert.Equal(t, "wss://stream/ws/btcusdt@depth", gapErr.Endpoint)
+	assert.Equal(t, 3, gapErr.Attempts)
+	server.mu.Unlock()
+	recorder.mu.Lock()
+	assert.Equal(t, []int{1, 2, 3}, recorder.attempts)
+	assert.Error(t, recorder.errs[0])
+	assert.NoError(t, recorder.errs[2])
+	recorder.mu.Unlock()
+
+	close(stopC)
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		t.Fatal("the stream was not stopped")
+	}
+	select {
+	case <-conn.stopC:
+	default:
+		t.Fatal("the connection was not closed")
+	}
+}
+
+func TestResilientStreamsMaxConnectionAge(t *testing.T) {
+	server := newFakeMarketServer()
+	gapC := make(chan StreamGap, 1)
+	r := &ResilientStreams{
+		ReconnectPolicy:  fastReconnectPolicy(),
+		MaxConnectionAge: 20 * time.Millisecond,
+		OnGap:            func(gap StreamGap) { gapC <- gap },
+	}
+	r.ReconnectPolicy.InitialBackoff = time.Hour
+	recorder := &reconnectRecorder{}
+	_, stopC, err := r.Serve("wss://stream/ws/btcusdt@depth", recorder, server.serve, server.errHandler)
+	require.NoError(t, err)
+	defer close(stopC)
+
+	conn := server.next(t)
+	renewed := server.next(t)
+	// connected before closing the old one, without backoff
+	assert.True(t, renewed.overlapped)
+	select {
+	case <-conn.doneC:
+	case <-time.After(time.Second):
+		t.Fatal("the old connection was not closed")
+	}
+	select {
+	case gap := <-gapC:
+		t.Fatalf("gap signaled for a renewal: %+v", gap)
+	case <-time.After(50 * time.Millisecond):
+	}
+	server.mu.Lock()
+	assert.Empty(t, server.errs)
+	server.mu.Unlock()
+	recorder.mu.Lock()
+	assert.Equal(t, 1, recorder.attempts[0])
+	recorder.mu.Unlock()
+}
+
+func TestResilientStreamsGiveUp(t *testing.T) {
+	server := newFakeMarketServer()
+	policy := fastReconnectPolicy()
+	policy.MaxAttempts = 2
+	giveUpC := make(chan error, 1)
+	policy.OnGiveUp = func(err error) { giveUpC <- err }
+	r := &ResilientStreams{ReconnectPolicy: policy}
+	doneC, _, err := r.Serve("wss://stream/ws/btcusdt@depth", nil, server.serve, server.errHandler)
+	require.NoError(t, err)
+
+	server.mu.Lock()
+	server.failures = 2
+	server.mu.Unlock()
+	server.next(t).drop()
+
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		t.Fatal("the stream did not give up")
+	}
+	assert.EqualError(t, <-giveUpC, "dial failed")
+}
+
+func TestResilientStreamsServeError(t *testing.T) {
+	server := newFakeMarketServer()
+	server.failures = 1
+	r := &ResilientStreams{}
+	_, _, err := r.Serve("wss://stream/ws/btcusdt@depth", nil, server.serve, server.errHandler)
+	assert.EqualError(t, err, "dial failed")
+}
+
+func TestResilientStreamsStopWhileReconnecting(t *testing.T) {
+	server := newFakeMarketServer()
+	policy := fastReconnectPolicy()
+	policy.InitialBackoff = time.Hour
+	giveUpC := make(chan error, 1)
+	policy.OnGiveUp = func(err error) { giveUpC <- err }
+	r := &ResilientStreams{ReconnectPolicy: policy}
+	doneC, stopC, err := r.Serve("wss://stream/ws/btcusdt@depth", nil, server.serve, server.errHandler)
+	require.NoError(t, err)
+	server.next(t).drop()
+
+	close(stopC)
+	<-doneC
+	select {
+	case err := <-giveUpC:
+		t.Fatalf("OnGiveUp called after stopping: %v", err)
+	default:
+	}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RetryPolicy define how the failed requests of a client are sent again.
+//
+// The idempotent requests are retried on the errors accepted by
+// IsRetryableError. An order is only retried when it has a client order id:
+// unless it is rejected by a rate limit, it may have been executed, so it is
+// looked up by its client order id and only sent again if it is unknown.
+// The other requests are never retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after each failed attempt
+	Multiplier float64
+	// Jitter randomizes the delay by up to the given fraction, in [0, 1]
+	Jitter float64
+
+	// OnRetry is called with the number of the failed attempt and its error
+	// before the request is sent again, may be nil
+	OnRetry func(attempt int, err error)
+}
+
+// DefaultRetryPolicy retries 3 times, backing off from 200ms up to 5s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Backoff return the delay after the given failed attempt, starting from 1
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	return exponentialBackoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter, attempt)
+}
+
+// RetryRequest is a request sent by RetryPolicy.Do
+type RetryRequest struct {
+	// Idempotent requests can be sent again safely, e.g. GET requests
+	Idempotent bool
+	// PlacesOrder is true for the requests which create an order
+	PlacesOrder bool
+	// ClientOrderID is the client order id set on the order, if any
+	ClientOrderID string
+
+	// Send send the request once
+	Send func() error
+	// LookupOrder find the order by ClientOrderID, it returns false if the
+	// order is unknown. It is required to retry an order.
+	LookupOrder func() (found bool, err error)
+}
+
+// IsRetryableError return true if the request may succeed when it is sent
+// again: a transport failure or a timeout, a 5xx response, an internal error
+// (-1001), a rate limit (-1003, 429 but not the 418 IP ban) or an unknown
+// execution status (-1007)
+func IsRetryableError(err error) bool {
+	if IsRequestError(err) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Category() {
+	case ErrIPBanned:
+		return false
+	case ErrRateLimited, ErrUnknownExecutionStatus:
+		return true
+	}
+	return apiErr.Code == -1001 || apiErr.StatusCode >= 500
+}
+
+// Do send the request until it succeeds or is not retried, and return the
+// last error. A nil error is also returned when an order which failed is
+// found by LookupOrder.
+func (p *RetryPolicy) Do(ctx context.Context, req *RetryRequest) error {
+	for attempt := 1; ; attempt++ {
+		err := req.Send()
+		if err == nil || attempt > p.MaxRetries || ctx.Err() != nil || !p.retryable(req, err) {
+			return err
+		}
+		if req.PlacesOrder && !errors.Is(err, ErrRateLimited) {
+			found, lookupErr := req.LookupOrder()
+			if lookupErr != nil {
+				// the order may be executed, it must not be sent again
+				return err
+			}
+			if found {
+				return nil
+			}
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err)
+		}
+		backoff := p.Backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > backoff {
+			backoff = apiErr.RetryAfter
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(req *RetryRequest, err error) bool {
+	if !IsRetryableError(err) {
+		return false
+	}
+	if req.PlacesOrder {
+		return req.ClientOrderID != "" && req.LookupOrder != nil
+	}
+	return req.Idempotent
+}
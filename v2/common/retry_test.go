@@ -0,0 +1,153 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, IsRetryableError(&RequestError{Err: errors.New("connection reset")}))
+	assert.True(t, IsRetryableError(&APIError{Code: -1001, Message: "Internal error; unable to process your request. Please try again."}))
+	assert.True(t, IsRetryableError(&APIError{Code: -1003, StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRetryableError(&APIError{Code: -1007}))
+	assert.True(t, IsRetryableError(&APIError{Code: 502, StatusCode: http.StatusBadGateway}))
+	assert.False(t, IsRetryableError(&APIError{Code: -1003, StatusCode: http.StatusTeapot}))
+	assert.False(t, IsRetryableError(&APIError{Code: -1121, StatusCode: http.StatusBadRequest}))
+	assert.False(t, IsRetryableError(&RateLimitError{}))
+	assert.False(t, IsRetryableError(errors.New("invalid character")))
+}
+
+func TestRetryPolicyIdempotent(t *testing.T) {
+	var sent, retried int
+	policy := testRetryPolicy()
+	policy.OnRetry = func(attempt int, err error) { retried = attempt }
+	err := policy.Do(context.Background(), &RetryRequest{
+		Idempotent: true,
+		Send: func() error {
+			sent++
+			if sent < 3 {
+				return &APIError{Code: -1001, StatusCode: http.StatusInternalServerError}
+			}
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, 2, retried)
+}
+
+func TestRetryPolicyMaxRetries(t *testing.T) {
+	sent := 0
+	apiErr := &APIError{Code: -1001, StatusCode: http.StatusInternalServerError}
+	err := testRetryPolicy().Do(context.Background(), &RetryRequest{
+		Idempotent: true,
+		Send: func() error {
+			sent++
+			return apiErr
+		},
+	})
+	assert.Equal(t, apiErr, err)
+	assert.Equal(t, 4, sent)
+}
+
+func TestRetryPolicyNotRetried(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *RetryRequest
+		err  error
+	}{
+		{"not idempotent", &RetryRequest{}, &RequestError{Err: errors.New("EOF")}},
+		{"not retryable", &RetryRequest{Idempotent: true}, &APIError{Code: -1121, StatusCode: http.StatusBadRequest}},
+		{"order without client order id", &RetryRequest{PlacesOrder: true, LookupOrder: func() (bool, error) { return false, nil }}, &APIError{Code: -1007}},
+		{"order without lookup", &RetryRequest{PlacesOrder: true, ClientOrderID: "x1"}, &APIError{Code: -1007}},
+	}
+	for _, test := range tests {
+		sent := 0
+		test.req.Send = func() error {
+			sent++
+			return test.err
+		}
+		assert.Equal(t, test.err, testRetryPolicy().Do(context.Background(), test.req), test.name)
+		assert.Equal(t, 1, sent, test.name)
+	}
+}
+
+func TestRetryPolicyOrder(t *testing.T) {
+	var sent, lookedUp int
+	req := &RetryRequest{
+		PlacesOrder:   true,
+		ClientOrderID: "x1",
+		Send: func() error {
+			sent++
+			return &APIError{Code: -1007}
+		},
+		LookupOrder: func() (bool, error) {
+			lookedUp++
+			// the first attempt was not executed, the second one was
+			return lookedUp == 2, nil
+		},
+	}
+	assert.NoError(t, testRetryPolicy().Do(context.Background(), req))
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, 2, lookedUp)
+}
+
+func TestRetryPolicyOrderLookupFailed(t *testing.T) {
+	sent := 0
+	timeout := &RequestError{Err: context.DeadlineExceeded}
+	err := testRetryPolicy().Do(context.Background(), &RetryRequest{
+		PlacesOrder:   true,
+		ClientOrderID: "x1",
+		Send: func() error {
+			sent++
+			return timeout
+		},
+		LookupOrder: func() (bool, error) {
+			return false, &RequestError{Err: errors.New("connection refused")}
+		},
+	})
+	assert.Equal(t, timeout, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestRetryPolicyOrderRateLimited(t *testing.T) {
+	sent := 0
+	err := testRetryPolicy().Do(context.Background(), &RetryRequest{
+		PlacesOrder:   true,
+		ClientOrderID: "x1",
+		Send: func() error {
+			sent++
+			if sent == 1 {
+				return &APIError{Code: -1003, StatusCode: http.StatusTooManyRequests, RetryAfter: 10 * time.Millisecond}
+			}
+			return nil
+		},
+		LookupOrder: func() (bool, error) {
+			t.Fatal("a rate limited order is not executed")
+			return false, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+}
+
+func TestRetryPolicyContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := &RetryPolicy{MaxRetries: 3, InitialBackoff: time.Hour}
+	policy.OnRetry = func(attempt int, err error) { cancel() }
+	apiErr := &APIError{Code: -1001, StatusCode: http.StatusInternalServerError}
+	err := policy.Do(ctx, &RetryRequest{
+		Idempotent: true,
+		Send:       func() error { return apiErr },
+	})
+	assert.Equal(t, apiErr, err)
+}
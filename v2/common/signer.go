@@ -0,0 +1,149 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// KeyType define the type of API key used to sign requests
+type KeyType string
+
+// Key types supported by Binance
+const (
+	KeyTypeHMAC    KeyType = "HMAC"
+	KeyTypeEd25519 KeyType = "ED25519"
+	KeyTypeRSA     KeyType = "RSA"
+)
+
+// Signer signs the payload of a SIGNED request (the query string and body for
+// REST, the sorted params for WS API) and returns the value of the
+// `signature` parameter.
+type Signer interface {
+	KeyType() KeyType
+	Sign(payload []byte) (string, error)
+}
+
+// HMACSigner signs with HMAC-SHA256, the signature is hex encoded
+type HMACSigner struct {
+	secretKey []byte
+}
+
+// NewHMACSigner create a HMAC-SHA256 signer with the secret key
+func NewHMACSigner(secretKey string) *HMACSigner {
+	return &HMACSigner{secretKey: []byte(secretKey)}
+}
+
+// KeyType return KeyTypeHMAC
+func (s *HMACSigner) KeyType() KeyType {
+	return KeyTypeHMAC
+}
+
+// Sign sign the payload
+func (s *HMACSigner) Sign(payload []byte) (string, error) {
+	mac := hmac.New(sha256.New, s.secretKey)
+	_, err := mac.Write(payload)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", mac.Sum(nil)), nil
+}
+
+// Ed25519Signer signs with an Ed25519 private key, the signature is base64 encoded
+type Ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+}
+
+// NewEd25519Signer create an Ed25519 signer from a PEM encoded PKCS#8 private key
+func NewEd25519Signer(pemData []byte) (*Ed25519Signer, error) {
+	key, err := parsePrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not an ed25519 key", key)
+	}
+	return &Ed25519Signer{privateKey: privateKey}, nil
+}
+
+// NewEd25519SignerFromFile create an Ed25519 signer from a PEM file
+func NewEd25519SignerFromFile(path string) (*Ed25519Signer, error) {
+	pemData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewEd25519Signer(pemData)
+}
+
+// KeyType return KeyTypeEd25519
+func (s *Ed25519Signer) KeyType() KeyType {
+	return KeyTypeEd25519
+}
+
+// Sign sign the payload
+func (s *Ed25519Signer) Sign(payload []byte) (string, error) {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, payload)), nil
+}
+
+// RSASigner signs with a RSA private key (PKCS#1 v1.5, SHA-256), the signature is base64 encoded
+type RSASigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSASigner create a RSA signer from a PEM encoded PKCS#1 or PKCS#8 private key
+func NewRSASigner(pemData []byte) (*RSASigner, error) {
+	key, err := parsePrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is %T, not a rsa key", key)
+	}
+	return &RSASigner{privateKey: privateKey}, nil
+}
+
+// NewRSASignerFromFile create a RSA signer from a PEM file
+func NewRSASignerFromFile(path string) (*RSASigner, error) {
+	pemData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSASigner(pemData)
+}
+
+// KeyType return KeyTypeRSA
+func (s *RSASigner) KeyType() KeyType {
+	return KeyTypeRSA
+}
+
+// Sign sign the payload
+func (s *RSASigner) Sign(payload []byte) (string, error) {
+	hashed := sha256.Sum256(payload)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func parsePrivateKey(pemData []byte) (interface{}, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM block found in private key")
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	return x509.ParsePKCS8PrivateKey(block.Bytes)
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSigner(t *testing.T) {
+	// example from the binance API documentation
+	s := NewHMACSigner("NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j")
+	payload := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+	sig, err := s.Sign([]byte(payload))
+	require.NoError(t, err)
+	assert.Equal(t, "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71", sig)
+	assert.Equal(t, KeyTypeHMAC, s.KeyType())
+}
+
+func TestEd25519Signer(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	s, err := NewEd25519Signer(pemData)
+	require.NoError(t, err)
+	assert.Equal(t, KeyTypeEd25519, s.KeyType())
+
+	payload := []byte("apiKey=foo&timestamp=1649729878532")
+	sig, err := s.Sign(payload)
+	require.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, payload, raw))
+
+	_, err = NewRSASigner(pemData)
+	assert.Error(t, err)
+}
+
+func TestRSASigner(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	for _, block := range []*pem.Block{
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+		{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)},
+	} {
+		s, err := NewRSASigner(pem.EncodeToMemory(block))
+		require.NoError(t, err)
+		assert.Equal(t, KeyTypeRSA, s.KeyType())
+
+		payload := []byte("symbol=BTCUSDT&timestamp=1649729878532")
+		sig, err := s.Sign(payload)
+		require.NoError(t, err)
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		require.NoError(t, err)
+		hashed := sha256.Sum256(payload)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&priv.PublicKey, crypto.SHA256, hashed[:], raw))
+	}
+}
+
+func TestSignerInvalidPEM(t *testing.T) {
+	_, err := NewEd25519Signer([]byte("not a pem"))
+	assert.Error(t, err)
+	_, err = NewRSASigner(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,476 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults of StreamManager, the limits are the ones of the exchange
+const (
+	DefaultMaxStreamsPerConn       = 1024
+	DefaultStreamMessagesPerSecond = 5
+	DefaultStreamResponseTimeout   = 10 * time.Second
+)
+
+// the methods of the combined streams
+const (
+	streamMethodSubscribe         = "SUBSCRIBE"
+	streamMethodUnsubscribe       = "UNSUBSCRIBE"
+	streamMethodListSubscriptions = "LIST_SUBSCRIPTIONS"
+)
+
+// websocket.TextMessage
+const streamTextMessage = 1
+
+// ErrStreamManagerClosed is returned by the calls after Close
+var ErrStreamManagerClosed = errors.New("stream manager is closed")
+
+// StreamConn is a connection to the combined streams endpoint,
+// *websocket.Conn implements it
+type StreamConn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// StreamHandler receives the data of a stream
+type StreamHandler func(stream string, data []byte)
+
+// StreamsDroppedError is given to the ErrHandler when a connection drops,
+// its streams are no longer subscribed
+type StreamsDroppedError struct {
+	Streams []string
+	Err     error
+}
+
+func (e *StreamsDroppedError) Error() string {
+	return fmt.Sprintf("%d streams dropped: %v", len(e.Streams), e.Err)
+}
+
+func (e *StreamsDroppedError) Unwrap() error {
+	return e.Err
+}
+
+// StreamManager multiplex the streams over a few connections to the
+// combined streams endpoint, with the SUBSCRIBE and UNSUBSCRIBE methods.
+// A connection is dialed when the others have MaxStreamsPerConn streams,
+// and sends at most MessagesPerSecond messages. The payloads are routed to
+// the handlers by stream name.
+//
+// It is product agnostic, the stream managers of each client dial their
+// own endpoint and decode their own events.
+type StreamManager struct {
+	// Dial connect the combined streams endpoint, without streams
+	Dial func(ctx context.Context) (StreamConn, error)
+
+	// MaxStreamsPerConn defaults to DefaultMaxStreamsPerConn
+	MaxStreamsPerConn int
+	// MessagesPerSecond defaults to DefaultStreamMessagesPerSecond
+	MessagesPerSecond int
+	// ResponseTimeout defaults to DefaultStreamResponseTimeout
+	ResponseTimeout time.Duration
+	// ErrHandler receives the errors of the connections, a
+	// *StreamsDroppedError when one drops. May be nil.
+	ErrHandler func(err error)
+
+	// subMu serializes the subscriptions, lock guards the state read by
+	// the connections
+	subMu    sync.Mutex
+	lock     sync.Mutex
+	conns    []*streamConn
+	handlers map[string]StreamHandler
+	closed   bool
+	lastID   int64
+	now      func() time.Time
+}
+
+type streamConn struct {
+	conn    StreamConn
+	streams map[string]bool
+	pending map[int64]chan *streamResponse
+	doneC   chan struct{}
+	err     error
+
+	// writeMu serializes the writes, sent are the times of the messages of
+	// the last second
+	writeMu sync.Mutex
+	sent    []time.Time
+}
+
+type streamRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params,omitempty"`
+	ID     int64    `json:"id"`
+}
+
+type streamResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *APIError       `json:"error"`
+	ID     *int64          `json:"id"`
+}
+
+type streamPayload struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Subscribe route the data of the streams to the handler, the streams which
+// are not subscribed yet are subscribed. The handler is called by the
+// goroutine reading the connection, which also reads the responses of the
+// methods: a handler calling Subscribe, Unsubscribe or ListSubscriptions
+// blocks the connection until ResponseTimeout, and one calling Close never
+// returns. Call them from another goroutine.
+func (m *StreamManager) Subscribe(ctx context.Context, handler StreamHandler, streams ...string) error {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return ErrStreamManagerClosed
+	}
+	if m.handlers == nil {
+		m.handlers = make(map[string]StreamHandler)
+	}
+	var added []string
+	for _, stream := range streams {
+		if _, ok := m.handlers[stream]; !ok && !contains(added, stream) {
+			added = append(added, stream)
+		}
+		m.handlers[stream] = handler
+	}
+	m.lock.Unlock()
+
+	for len(added) > 0 {
+		c, n, err := m.connWithRoom(ctx)
+		if err == nil {
+			if n > len(added) {
+				n = len(added)
+			}
+			err = m.send(ctx, c, streamMethodSubscribe, added[:n], nil)
+		}
+		if err != nil {
+			m.removeHandlers(added)
+			return err
+		}
+		m.lock.Lock()
+		for _, stream := range added[:n] {
+			c.streams[stream] = true
+		}
+		m.lock.Unlock()
+		added = added[n:]
+	}
+	return nil
+}
+
+// Unsubscribe the streams and remove their handlers
+func (m *StreamManager) Unsubscribe(ctx context.Context, streams ...string) error {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return ErrStreamManagerClosed
+	}
+	byConn := make(map[*streamConn][]string)
+	for _, stream := range streams {
+		for _, c := range m.conns {
+			if c.streams[stream] && !contains(byConn[c], stream) {
+				byConn[c] = append(byConn[c], stream)
+			}
+		}
+	}
+	m.lock.Unlock()
+
+	for c, removed := range byConn {
+		if err := m.send(ctx, c, streamMethodUnsubscribe, removed, nil); err != nil {
+			return err
+		}
+		m.lock.Lock()
+		for _, stream := range removed {
+			delete(c.streams, stream)
+		}
+		m.lock.Unlock()
+	}
+	m.removeHandlers(streams)
+	return nil
+}
+
+// ListSubscriptions ask the connections for their subscribed streams
+func (m *StreamManager) ListSubscriptions(ctx context.Context) ([]string, error) {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return nil, ErrStreamManagerClosed
+	}
+	conns := append([]*streamConn(nil), m.conns...)
+	m.lock.Unlock()
+
+	res := []string{}
+	for _, c := range conns {
+		var streams []string
+		if err := m.send(ctx, c, streamMethodListSubscriptions, nil, &streams); err != nil {
+			return nil, err
+		}
+		res = append(res, streams...)
+	}
+	return res, nil
+}
+
+// Streams return the subscribed streams
+func (m *StreamManager) Streams() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	res := []string{}
+	for _, c := range m.conns {
+		for stream := range c.streams {
+			res = append(res, stream)
+		}
+	}
+	return res
+}
+
+// Connections return the number of open connections
+func (m *StreamManager) Connections() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.conns)
+}
+
+// Close close the connections, the manager can't be used after
+func (m *StreamManager) Close() {
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		return
+	}
+	m.closed = true
+	conns := m.conns
+	m.conns = nil
+	m.lock.Unlock()
+	for _, c := range conns {
+		c.conn.Close()
+		<-c.doneC
+	}
+}
+
+// connWithRoom return a connection which can subscribe more streams, with
+// the number of streams it can take, a new one if they are all full
+func (m *StreamManager) connWithRoom(ctx context.Context) (*streamConn, int, error) {
+	max := m.MaxStreamsPerConn
+	if max <= 0 {
+		max = DefaultMaxStreamsPerConn
+	}
+	m.lock.Lock()
+	for _, c := range m.conns {
+		if n := max - len(c.streams); n > 0 {
+			m.lock.Unlock()
+			return c, n, nil
+		}
+	}
+	m.lock.Unlock()
+
+	conn, err := m.Dial(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	c := &streamConn{
+		conn:    conn,
+		streams: make(map[string]bool),
+		pending: make(map[int64]chan *streamResponse),
+		doneC:   make(chan struct{}),
+	}
+	m.lock.Lock()
+	if m.closed {
+		m.lock.Unlock()
+		conn.Close()
+		return nil, 0, ErrStreamManagerClosed
+	}
+	m.conns = append(m.conns, c)
+	m.lock.Unlock()
+	go m.read(c)
+	return c, max, nil
+}
+
+// send a method on the connection and wait for its response, whose result
+// is decoded into result if it is not nil
+func (m *StreamManager) send(ctx context.Context, c *streamConn, method string, params []string, result interface{}) error {
+	id := atomic.AddInt64(&m.lastID, 1)
+	data, err := json.Marshal(&streamRequest{Method: method, Params: params, ID: id})
+	if err != nil {
+		return err
+	}
+	resC := make(chan *streamResponse, 1)
+	m.lock.Lock()
+	if c.err != nil {
+		m.lock.Unlock()
+		return c.err
+	}
+	c.pending[id] = resC
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		delete(c.pending, id)
+		m.lock.Unlock()
+	}()
+
+	if err := m.write(ctx, c, data); err != nil {
+		return err
+	}
+
+	timeout := m.ResponseTimeout
+	if timeout <= 0 {
+		timeout = DefaultStreamResponseTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case res := <-resC:
+		if res.Error != nil {
+			res.Error.Method = method
+			return res.Error
+		}
+		if result != nil {
+			return json.Unmarshal(res.Result, result)
+		}
+		return nil
+	case <-c.doneC:
+		return c.err
+	case <-timer.C:
+		return fmt.Errorf("no response to %s after %s", method, timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// write a message once the connection sent less than MessagesPerSecond
+// messages in the last second
+func (m *StreamManager) write(ctx context.Context, c *streamConn, data []byte) error {
+	limit := m.MessagesPerSecond
+	if limit <= 0 {
+		limit = DefaultStreamMessagesPerSecond
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	for {
+		now := m.timeNow()
+		for len(c.sent) > 0 && now.Sub(c.sent[0]) >= time.Second {
+			c.sent = c.sent[1:]
+		}
+		if len(c.sent) < limit {
+			break
+		}
+		timer := time.NewTimer(c.sent[0].Add(time.Second).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-c.doneC:
+			timer.Stop()
+			return c.err
+		case <-timer.C:
+		}
+	}
+	c.sent = append(c.sent, m.timeNow())
+	return c.conn.WriteMessage(streamTextMessage, data)
+}
+
+// read the connection until it drops, the responses are given to the
+// pending methods and the payloads to the handlers
+func (m *StreamManager) read(c *streamConn) {
+	var err error
+	for {
+		var message []byte
+		_, message, err = c.conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		var res streamResponse
+		if e := json.Unmarshal(message, &res); e == nil && res.ID != nil {
+			m.lock.Lock()
+			resC := c.pending[*res.ID]
+			m.lock.Unlock()
+			if resC != nil {
+				resC <- &res
+			}
+			continue
+		}
+		var payload streamPayload
+		if e := json.Unmarshal(message, &payload); e != nil || payload.Stream == "" {
+			m.handleErr(fmt.Errorf("unexpected stream message: %s", message))
+			continue
+		}
+		m.lock.Lock()
+		handler := m.handlers[payload.Stream]
+		m.lock.Unlock()
+		if handler != nil {
+			handler(payload.Stream, payload.Data)
+		}
+	}
+
+	m.lock.Lock()
+	closed := m.closed
+	c.err = err
+	if closed {
+		c.err = ErrStreamManagerClosed
+	}
+	var dropped []string
+	for i, other := range m.conns {
+		if other == c {
+			m.conns = append(m.conns[:i:i], m.conns[i+1:]...)
+			break
+		}
+	}
+	for stream := range c.streams {
+		dropped = append(dropped, stream)
+		delete(m.handlers, stream)
+	}
+	m.lock.Unlock()
+	c.conn.Close()
+	close(c.doneC)
+	if !closed {
+		m.handleErr(&StreamsDroppedError{Streams: dropped, Err: err})
+	}
+}
+
+func (m *StreamManager) removeHandlers(streams []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, stream := range streams {
+		subscribed := false
+		for _, c := range m.conns {
+			subscribed = subscribed || c.streams[stream]
+		}
+		if !subscribed {
+			delete(m.handlers, stream)
+		}
+	}
+}
+
+func (m *StreamManager) handleErr(err error) {
+	if m.ErrHandler != nil {
+		m.ErrHandler(err)
+	}
+}
+
+func (m *StreamManager) timeNow() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
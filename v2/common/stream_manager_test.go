@@ -0,0 +1,285 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamConn answer the methods like the server, the streams are
+// pushed with push
+type fakeStreamConn struct {
+	mu       sync.Mutex
+	requests []streamRequest
+	streams  map[string]bool
+	fail     bool
+	readC    chan []byte
+	closeC   chan struct{}
+	once     sync.Once
+}
+
+func newFakeStreamConn() *fakeStreamConn {
+	return &fakeStreamConn{
+		streams: make(map[string]bool),
+		readC:   make(chan []byte, 100),
+		closeC:  make(chan struct{}),
+	}
+}
+
+func (c *fakeStreamConn) ReadMessage() (int, []byte, error) {
+	select {
+	case message := <-c.readC:
+		return streamTextMessage, message, nil
+	case <-c.closeC:
+		return 0, nil, errors.New("connection closed")
+	}
+}
+
+func (c *fakeStreamConn) WriteMessage(messageType int, data []byte) error {
+	var req streamRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	if c.fail {
+		c.readC <- []byte(fmt.Sprintf(`{"error":{"code":2,"msg":"Invalid request"},"id":%d}`, req.ID))
+		return nil
+	}
+	var result interface{}
+	switch req.Method {
+	case streamMethodSubscribe:
+		for _, stream := range req.Params {
+			c.streams[stream] = true
+		}
+	case streamMethodUnsubscribe:
+		for _, stream := range req.Params {
+			delete(c.streams, stream)
+		}
+	case streamMethodListSubscriptions:
+		streams := []string{}
+		for stream := range c.streams {
+			streams = append(streams, stream)
+		}
+		sort.Strings(streams)
+		result = streams
+	}
+	res, _ := json.Marshal(map[string]interface{}{"result": result, "id": req.ID})
+	c.readC <- res
+	return nil
+}
+
+func (c *fakeStreamConn) Close() error {
+	c.once.Do(func() { close(c.closeC) })
+	return nil
+}
+
+func (c *fakeStreamConn) push(stream, data string) {
+	c.readC <- []byte(fmt.Sprintf(`{"stream":%q,"data":%s}`, stream, data))
+}
+
+func (c *fakeStreamConn) methods() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var methods []string
+	for _, req := range c.requests {
+		methods = append(methods, fmt.Sprintf("%s %v", req.Method, req.Params))
+	}
+	return methods
+}
+
+type fakeStreamServer struct {
+	mu    sync.Mutex
+	conns []*fakeStreamConn
+}
+
+func (s *fakeStreamServer) dial(ctx context.Context) (StreamConn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := newFakeStreamConn()
+	s.conns = append(s.conns, c)
+	return c, nil
+}
+
+func (s *fakeStreamServer) conn(i int) *fakeStreamConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns[i]
+}
+
+type receivedStreams struct {
+	mu   sync.Mutex
+	data []string
+}
+
+func (r *receivedStreams) handle(stream string, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = append(r.data, stream+" "+string(data))
+}
+
+func (r *receivedStreams) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.data...)
+}
+
+func TestStreamManagerSubscribe(t *testing.T) {
+	server := &fakeStreamServer{}
+	m := &StreamManager{Dial: server.dial, MessagesPerSecond: 100}
+	defer m.Close()
+	received := &receivedStreams{}
+
+	err := m.Subscribe(context.Background(), received.handle, "btcusdt@aggTrade", "ethusdt@aggTrade")
+	require.NoError(t, err)
+	err = m.Subscribe(context.Background(), received.handle, "btcusdt@aggTrade", "btcusdt@depth")
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Connections())
+
+	conn := server.conn(0)
+	assert.Equal(t, []string{
+		"SUBSCRIBE [btcusdt@aggTrade ethusdt@aggTrade]",
+		"SUBSCRIBE [btcusdt@depth]",
+	}, conn.methods())
+
+	conn.push("ethusdt@aggTrade", `{"p":"1"}`)
+	conn.push("xrpusdt@aggTrade", `{"p":"2"}`)
+	conn.push("btcusdt@depth", `{"u":3}`)
+	assert.Eventually(t, func() bool { return len(received.get()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{`ethusdt@aggTrade {"p":"1"}`, `btcusdt@depth {"u":3}`}, received.get())
+
+	streams, err := m.ListSubscriptions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"btcusdt@aggTrade", "btcusdt@depth", "ethusdt@aggTrade"}, streams)
+
+	err = m.Unsubscribe(context.Background(), "ethusdt@aggTrade")
+	require.NoError(t, err)
+	streams = m.Streams()
+	sort.Strings(streams)
+	assert.Equal(t, []string{"btcusdt@aggTrade", "btcusdt@depth"}, streams)
+	assert.Equal(t, "UNSUBSCRIBE [ethusdt@aggTrade]", conn.methods()[3])
+
+	conn.push("ethusdt@aggTrade", `{"p":"4"}`)
+	conn.push("btcusdt@aggTrade", `{"p":"5"}`)
+	assert.Eventually(t, func() bool { return len(received.get()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, `btcusdt@aggTrade {"p":"5"}`, received.get()[2])
+}
+
+func TestStreamManagerMaxStreamsPerConn(t *testing.T) {
+	server := &fakeStreamServer{}
+	m := &StreamManager{Dial: server.dial, MaxStreamsPerConn: 2, MessagesPerSecond: 100}
+	defer m.Close()
+	received := &receivedStreams{}
+
+	err := m.Subscribe(context.Background(), received.handle, "a@trade", "b@trade", "c@trade")
+	require.NoError(t, err)
+	err = m.Subscribe(context.Background(), received.handle, "d@trade", "e@trade")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, m.Connections())
+	assert.Equal(t, []string{"SUBSCRIBE [a@trade b@trade]"}, server.conn(0).methods())
+	assert.Equal(t, []string{"SUBSCRIBE [c@trade]", "SUBSCRIBE [d@trade]"}, server.conn(1).methods())
+	assert.Equal(t, []string{"SUBSCRIBE [e@trade]"}, server.conn(2).methods())
+
+	server.conn(2).push("e@trade", `{}`)
+	assert.Eventually(t, func() bool { return len(received.get()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestStreamManagerMessagesPerSecond(t *testing.T) {
+	server := &fakeStreamServer{}
+	m := &StreamManager{Dial: server.dial, MessagesPerSecond: 2}
+	defer m.Close()
+	now := time.Now()
+	var mu sync.Mutex
+	m.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	for _, stream := range []string{"a@trade", "b@trade"} {
+		require.NoError(t, m.Subscribe(context.Background(), func(string, []byte) {}, stream))
+	}
+	doneC := make(chan error)
+	go func() {
+		doneC <- m.Subscribe(context.Background(), func(string, []byte) {}, "c@trade")
+	}()
+	select {
+	case <-doneC:
+		t.Fatal("the third message was sent in the same second")
+	case <-time.After(50 * time.Millisecond):
+	}
+	mu.Lock()
+	now = now.Add(time.Second)
+	mu.Unlock()
+	select {
+	case err := <-doneC:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the third message was not sent")
+	}
+	assert.Len(t, server.conn(0).methods(), 3)
+}
+
+func TestStreamManagerSubscribeError(t *testing.T) {
+	server := &fakeStreamServer{}
+	m := &StreamManager{Dial: server.dial}
+	defer m.Close()
+	require.NoError(t, m.Subscribe(context.Background(), func(string, []byte) {}, "a@trade"))
+	conn := server.conn(0)
+	conn.mu.Lock()
+	conn.fail = true
+	conn.mu.Unlock()
+
+	err := m.Subscribe(context.Background(), func(string, []byte) {}, "b@trade")
+	apiErr, ok := err.(*APIError)
+	require.True(t, ok, err)
+	assert.Equal(t, int64(2), apiErr.Code)
+	assert.Equal(t, "SUBSCRIBE", apiErr.Method)
+	assert.Equal(t, []string{"a@trade"}, m.Streams())
+}
+
+func TestStreamManagerDropped(t *testing.T) {
+	server := &fakeStreamServer{}
+	errC := make(chan error, 1)
+	m := &StreamManager{Dial: server.dial, ErrHandler: func(err error) { errC <- err }}
+	defer m.Close()
+	require.NoError(t, m.Subscribe(context.Background(), func(string, []byte) {}, "a@trade", "b@trade"))
+
+	server.conn(0).Close()
+	var err error
+	select {
+	case err = <-errC:
+	case <-time.After(time.Second):
+		t.Fatal("the drop was not reported")
+	}
+	var dropped *StreamsDroppedError
+	require.True(t, errors.As(err, &dropped))
+	sort.Strings(dropped.Streams)
+	assert.Equal(t, []string{"a@trade", "b@trade"}, dropped.Streams)
+	assert.Equal(t, 0, m.Connections())
+	assert.Empty(t, m.Streams())
+
+	// subscribing again dials a new connection
+	require.NoError(t, m.Subscribe(context.Background(), func(string, []byte) {}, "a@trade"))
+	assert.Equal(t, []string{"SUBSCRIBE [a@trade]"}, server.conn(1).methods())
+}
+
+func TestStreamManagerClose(t *testing.T) {
+	server := &fakeStreamServer{}
+	m := &StreamManager{Dial: server.dial, ErrHandler: func(err error) { t.Error(err) }}
+	require.NoError(t, m.Subscribe(context.Background(), func(string, []byte) {}, "a@trade"))
+	m.Close()
+	assert.Equal(t, ErrStreamManagerClosed, m.Subscribe(context.Background(), func(string, []byte) {}, "b@trade"))
+	_, err := m.ListSubscriptions(context.Background())
+	assert.Equal(t, ErrStreamManagerClosed, err)
+}
@@ -0,0 +1,276 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSymbolRefreshInterval is the interval between the refreshes of a
+// started SymbolRegistry
+const DefaultSymbolRefreshInterval = time.Hour
+
+// ErrSymbolRegistryStarted is returned when a SymbolRegistry is started twice
+var ErrSymbolRegistryStarted = errors.New("symbol registry is already started")
+
+// SymbolEventType define the type of a SymbolEvent
+type SymbolEventType string
+
+// SymbolEventType values
+const (
+	SymbolEventTypeAdded         SymbolEventType = "ADDED"
+	SymbolEventTypeRemoved       SymbolEventType = "REMOVED"
+	SymbolEventTypeStatusChanged SymbolEventType = "STATUS_CHANGED"
+)
+
+// RegistrySymbol is a symbol of the exchange info kept by a SymbolRegistry
+type RegistrySymbol struct {
+	Symbol     string
+	BaseAsset  string
+	QuoteAsset string
+	Status     string
+	// Filters are the parsed filters of the symbol
+	Filters *SymbolFilters
+	// Info is the symbol as returned by the exchange info service of the
+	// client, e.g. *binance.Symbol or *futures.Symbol
+	Info interface{}
+}
+
+// SymbolEvent is emitted when a refresh adds or removes a symbol or changes
+// its status. Symbol is the removed symbol for SymbolEventTypeRemoved.
+type SymbolEvent struct {
+	Type      SymbolEventType
+	Symbol    *RegistrySymbol
+	OldStatus string
+}
+
+// SymbolRegistry caches the symbols of the exchange info, so that they are
+// not downloaded again for every lookup. The symbols are refreshed by
+// Refresh, and periodically after Start.
+//
+// It is product agnostic, the registries of each client fill Fetch with
+// their own exchange info service.
+type SymbolRegistry struct {
+	// Fetch download the symbols of the exchange info
+	Fetch func(ctx context.Context) ([]*RegistrySymbol, error)
+
+	// RefreshInterval defaults to DefaultSymbolRefreshInterval
+	RefreshInterval time.Duration
+	// OrderValidator, if set, return the validator which receives the filters
+	// of the symbols on every refresh, nil if there is none
+	OrderValidator func() *OrderValidator
+	// OnEvent is called for each change found by a refresh, the first load
+	// emits no events. May be nil.
+	OnEvent func(event *SymbolEvent)
+	// ErrHandler receives the errors of the periodic refreshes, the symbols
+	// of the last successful refresh are kept. May be nil.
+	ErrHandler func(err error)
+
+	lock      sync.RWMutex
+	refreshMu sync.Mutex
+	symbols   map[string]*RegistrySymbol
+	updatedAt time.Time
+
+	started  bool
+	stopC    chan struct{}
+	doneC    chan struct{}
+	stopOnce sync.Once
+}
+
+// Start load the symbols, then refresh them every RefreshInterval in
+// background until Stop is called. It returns the error of the first load.
+func (r *SymbolRegistry) Start(ctx context.Context) error {
+	r.lock.Lock()
+	if r.started {
+		r.lock.Unlock()
+		return ErrSymbolRegistryStarted
+	}
+	r.started = true
+	r.stopC = make(chan struct{})
+	r.doneC = make(chan struct{})
+	r.lock.Unlock()
+
+	if err := r.Refresh(ctx); err != nil {
+		// the registry can be started again
+		r.lock.Lock()
+		close(r.doneC)
+		r.started = false
+		r.stopC = nil
+		r.lock.Unlock()
+		return err
+	}
+	go r.run()
+	return nil
+}
+
+// Stop the periodic refreshes, the cached symbols are still available
+func (r *SymbolRegistry) Stop() {
+	r.lock.Lock()
+	stopC, doneC := r.stopC, r.doneC
+	r.lock.Unlock()
+	if stopC == nil {
+		return
+	}
+	r.stopOnce.Do(func() { close(stopC) })
+	<-doneC
+}
+
+// Done is closed when the registry is stopped
+func (r *SymbolRegistry) Done() <-chan struct{} {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.doneC
+}
+
+// Refresh download the symbols now, and emit the events of the changes
+func (r *SymbolRegistry) Refresh(ctx context.Context) error {
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+
+	symbols, err := r.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	next := make(map[string]*RegistrySymbol, len(symbols))
+	for _, s := range symbols {
+		next[s.Symbol] = s
+	}
+
+	r.lock.Lock()
+	prev := r.symbols
+	r.symbols = next
+	r.updatedAt = time.Now()
+	r.lock.Unlock()
+
+	var removed []*RegistrySymbol
+	for _, s := range sortSymbols(prev) {
+		if _, ok := next[s.Symbol]; !ok {
+			removed = append(removed, s)
+		}
+	}
+	if validator := r.orderValidator(); validator != nil {
+		filters := make([]*SymbolFilters, 0, len(symbols))
+		for _, s := range symbols {
+			if s.Filters != nil {
+				filters = append(filters, s.Filters)
+			}
+		}
+		validator.SetFilters(filters...)
+		for _, s := range removed {
+			validator.RemoveFilters(s.Symbol)
+		}
+	}
+	if prev == nil || r.OnEvent == nil {
+		return nil
+	}
+	for _, s := range sortSymbols(next) {
+		old, ok := prev[s.Symbol]
+		switch {
+		case !ok:
+			r.OnEvent(&SymbolEvent{Type: SymbolEventTypeAdded, Symbol: s})
+		case old.Status != s.Status:
+			r.OnEvent(&SymbolEvent{Type: SymbolEventTypeStatusChanged, Symbol: s, OldStatus: old.Status})
+		}
+	}
+	for _, s := range removed {
+		r.OnEvent(&SymbolEvent{Type: SymbolEventTypeRemoved, Symbol: s, OldStatus: s.Status})
+	}
+	return nil
+}
+
+func (r *SymbolRegistry) orderValidator() *OrderValidator {
+	if r.OrderValidator == nil {
+		return nil
+	}
+	return r.OrderValidator()
+}
+
+// UpdatedAt return the time of the last successful refresh, zero if the
+// symbols are not loaded
+func (r *SymbolRegistry) UpdatedAt() time.Time {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.updatedAt
+}
+
+// Symbol return the symbol, nil if unknown
+func (r *SymbolRegistry) Symbol(symbol string) *RegistrySymbol {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.symbols[symbol]
+}
+
+// Symbols return all the symbols sorted by name
+func (r *SymbolRegistry) Symbols() []*RegistrySymbol {
+	return r.filter(func(s *RegistrySymbol) bool { return true })
+}
+
+// SymbolsByBaseAsset return the symbols of the base asset sorted by name
+func (r *SymbolRegistry) SymbolsByBaseAsset(asset string) []*RegistrySymbol {
+	return r.filter(func(s *RegistrySymbol) bool { return s.BaseAsset == asset })
+}
+
+// SymbolsByQuoteAsset return the symbols of the quote asset sorted by name
+func (r *SymbolRegistry) SymbolsByQuoteAsset(asset string) []*RegistrySymbol {
+	return r.filter(func(s *RegistrySymbol) bool { return s.QuoteAsset == asset })
+}
+
+// SymbolsByStatus return the symbols with the status, e.g. TRADING, sorted
+// by name
+func (r *SymbolRegistry) SymbolsByStatus(status string) []*RegistrySymbol {
+	return r.filter(func(s *RegistrySymbol) bool { return s.Status == status })
+}
+
+func (r *SymbolRegistry) filter(match func(s *RegistrySymbol) bool) []*RegistrySymbol {
+	r.lock.RLock()
+	all := sortSymbols(r.symbols)
+	r.lock.RUnlock()
+	res := make([]*RegistrySymbol, 0, len(all))
+	for _, s := range all {
+		if match(s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func sortSymbols(symbols map[string]*RegistrySymbol) []*RegistrySymbol {
+	res := make([]*RegistrySymbol, 0, len(symbols))
+	for _, s := range symbols {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Symbol < res[j].Symbol })
+	return res
+}
+
+func (r *SymbolRegistry) run() {
+	defer close(r.doneC)
+	interval := r.RefreshInterval
+	if interval <= 0 {
+		interval = DefaultSymbolRefreshInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stopC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		select {
+		case <-r.stopC:
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil && ctx.Err() == nil && r.ErrHandler != nil {
+				r.ErrHandler(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSymbolAPI struct {
+	mu      sync.Mutex
+	symbols []*RegistrySymbol
+	err     error
+	fetched int
+}
+
+func (a *fakeSymbolAPI) set(symbols ...*RegistrySymbol) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.symbols = symbols
+}
+
+func (a *fakeSymbolAPI) fetch(ctx context.Context) ([]*RegistrySymbol, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.fetched++
+	return a.symbols, a.err
+}
+
+func registrySymbol(symbol, base, quote, status string) *RegistrySymbol {
+	return &RegistrySymbol{
+		Symbol:     symbol,
+		BaseAsset:  base,
+		QuoteAsset: quote,
+		Status:     status,
+		Filters:    &SymbolFilters{Symbol: symbol, TickSize: MustParseDecimal("0.01")},
+	}
+}
+
+func registryNames(symbols []*RegistrySymbol) []string {
+	names := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		names = append(names, s.Symbol)
+	}
+	return names
+}
+
+func TestSymbolRegistryLookups(t *testing.T) {
+	api := &fakeSymbolAPI{}
+	api.set(
+		registrySymbol("ETHBTC", "ETH", "BTC", "TRADING"),
+		registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"),
+		registrySymbol("ETHUSDT", "ETH", "USDT", "BREAK"),
+	)
+	r := &SymbolRegistry{Fetch: api.fetch}
+	assert.Nil(t, r.Symbol("BTCUSDT"))
+	assert.True(t, r.UpdatedAt().IsZero())
+
+	require.NoError(t, r.Refresh(context.Background()))
+	assert.Equal(t, "USDT", r.Symbol("BTCUSDT").QuoteAsset)
+	assert.Nil(t, r.Symbol("XRPUSDT"))
+	assert.Equal(t, []string{"BTCUSDT", "ETHBTC", "ETHUSDT"}, registryNames(r.Symbols()))
+	assert.Equal(t, []string{"ETHBTC", "ETHUSDT"}, registryNames(r.SymbolsByBaseAsset("ETH")))
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, registryNames(r.SymbolsByQuoteAsset("USDT")))
+	assert.Equal(t, []string{"BTCUSDT", "ETHBTC"}, registryNames(r.SymbolsByStatus("TRADING")))
+	assert.Empty(t, r.SymbolsByStatus("HALT"))
+	assert.False(t, r.UpdatedAt().IsZero())
+}
+
+func TestSymbolRegistryEvents(t *testing.T) {
+	api := &fakeSymbolAPI{}
+	api.set(
+		registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"),
+		registrySymbol("ETHUSDT", "ETH", "USDT", "TRADING"),
+		registrySymbol("LUNAUSDT", "LUNA", "USDT", "TRADING"),
+	)
+	var events []*SymbolEvent
+	validator := NewOrderValidator()
+	r := &SymbolRegistry{
+		Fetch:          api.fetch,
+		OrderValidator: func() *OrderValidator { return validator },
+		OnEvent:        func(event *SymbolEvent) { events = append(events, event) },
+	}
+	require.NoError(t, r.Refresh(context.Background()))
+	assert.Empty(t, events, "the first load emits no events")
+	assert.NotNil(t, validator.Filters("LUNAUSDT"))
+
+	api.set(
+		registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"),
+		registrySymbol("ETHUSDT", "ETH", "USDT", "HALT"),
+		registrySymbol("SOLUSDT", "SOL", "USDT", "TRADING"),
+	)
+	require.NoError(t, r.Refresh(context.Background()))
+	require.Len(t, events, 3)
+	assert.Equal(t, SymbolEventTypeStatusChanged, events[0].Type)
+	assert.Equal(t, "ETHUSDT", events[0].Symbol.Symbol)
+	assert.Equal(t, "TRADING", events[0].OldStatus)
+	assert.Equal(t, "HALT", events[0].Symbol.Status)
+	assert.Equal(t, SymbolEventTypeAdded, events[1].Type)
+	assert.Equal(t, "SOLUSDT", events[1].Symbol.Symbol)
+	assert.Equal(t, SymbolEventTypeRemoved, events[2].Type)
+	assert.Equal(t, "LUNAUSDT", events[2].Symbol.Symbol)
+
+	assert.Nil(t, r.Symbol("LUNAUSDT"))
+	assert.Nil(t, validator.Filters("LUNAUSDT"))
+	assert.NotNil(t, validator.Filters("SOLUSDT"))
+}
+
+func TestSymbolRegistryRefreshError(t *testing.T) {
+	api := &fakeSymbolAPI{}
+	api.set(registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"))
+	r := &SymbolRegistry{Fetch: api.fetch}
+	require.NoError(t, r.Refresh(context.Background()))
+
+	api.err = errors.New("timeout")
+	assert.EqualError(t, r.Refresh(context.Background()), "timeout")
+	assert.NotNil(t, r.Symbol("BTCUSDT"), "the symbols are kept")
+}
+
+func TestSymbolRegistryStart(t *testing.T) {
+	api := &fakeSymbolAPI{}
+	api.set(registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"))
+	errC := make(chan error, 10)
+	r := &SymbolRegistry{
+		Fetch:           api.fetch,
+		RefreshInterval: 5 * time.Millisecond,
+		ErrHandler:      func(err error) { errC <- err },
+	}
+	require.NoError(t, r.Start(context.Background()))
+	assert.Equal(t, ErrSymbolRegistryStarted, r.Start(context.Background()))
+	assert.NotNil(t, r.Symbol("BTCUSDT"))
+
+	api.mu.Lock()
+	api.err = errors.New("timeout")
+	api.mu.Unlock()
+	select {
+	case err := <-errC:
+		assert.EqualError(t, err, "timeout")
+	case <-time.After(time.Second):
+		t.Fatal("the registry is not refreshed")
+	}
+	r.Stop()
+	<-r.Done()
+	assert.NotNil(t, r.Symbol("BTCUSDT"))
+}
+
+func TestSymbolRegistryStartError(t *testing.T) {
+	api := &fakeSymbolAPI{err: errors.New("timeout")}
+	r := &SymbolRegistry{Fetch: api.fetch}
+	assert.EqualError(t, r.Start(context.Background()), "timeout")
+	<-r.Done()
+	r.Stop()
+
+	// the registry is started again once the symbols can be loaded
+	api.mu.Lock()
+	api.err = nil
+	api.mu.Unlock()
+	api.set(registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"))
+	require.NoError(t, r.Start(context.Background()))
+	assert.NotNil(t, r.Symbol("BTCUSDT"))
+	r.Stop()
+	<-r.Done()
+}
+
+func TestSymbolRegistryOrderValidator(t *testing.T) {
+	api := &fakeSymbolAPI{}
+	api.set(registrySymbol("BTCUSDT", "BTC", "USDT", "TRADING"))
+	var validator *OrderValidator
+	r := &SymbolRegistry{
+		Fetch:          api.fetch,
+		OrderValidator: func() *OrderValidator { return validator },
+	}
+	require.NoError(t, r.Refresh(context.Background()))
+
+	// the validator set after the registry is built receives the filters
+	validator = NewOrderValidator()
+	require.NoError(t, r.Refresh(context.Background()))
+	assert.NotNil(t, validator.Filters("BTCUSDT"))
+}
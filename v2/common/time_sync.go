@@ -0,0 +1,222 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TimeSync defaults
+const (
+	DefaultTimeSyncInterval  = time.Minute
+	DefaultTimeSyncSamples   = 3
+	DefaultTimeSyncSmoothing = 0.3
+)
+
+// TimeSyncMaxSmoothedStep is the largest change of the offset which is
+// smoothed, a larger one is applied at once
+const TimeSyncMaxSmoothedStep = time.Second
+
+// ErrTimeSyncStarted is returned when a TimeSync is started twice
+var ErrTimeSyncStarted = errors.New("time sync is already started")
+
+// TimeSync keeps the offset of the local clock to the server time, so that
+// the timestamps of the signed requests stay in their recvWindow. The
+// server time is sampled by Sync, and periodically after Start.
+//
+// Each sync samples the server time several times, and keeps the sample
+// with the shortest round trip, whose middle is taken as the local time of
+// the server time. The offset moves smoothly toward the samples, unless it
+// is wrong by more than TimeSyncMaxSmoothedStep.
+type TimeSync struct {
+	// the int64 are first for the alignment of the atomic operations
+	offset int64
+	drift  int64
+	syncs  int64
+
+	// ServerTime return the server time in milliseconds
+	ServerTime func(ctx context.Context) (int64, error)
+
+	// Interval defaults to DefaultTimeSyncInterval
+	Interval time.Duration
+	// Samples per sync, DefaultTimeSyncSamples by default
+	Samples int
+	// Smoothing is the weight of a new sample in the offset, between 0 and
+	// 1, DefaultTimeSyncSmoothing by default
+	Smoothing float64
+	// ErrHandler receives the errors of the periodic syncs, the last offset
+	// is kept. May be nil.
+	ErrHandler func(err error)
+
+	syncMu sync.Mutex
+	lock   sync.Mutex
+	now    func() time.Time
+
+	started  bool
+	stopC    chan struct{}
+	doneC    chan struct{}
+	stopOnce sync.Once
+}
+
+// Start sync the time, then sync it every Interval in background until Stop
+// is called. It returns the error of the first sync.
+func (s *TimeSync) Start(ctx context.Context) error {
+	s.lock.Lock()
+	if s.started {
+		s.lock.Unlock()
+		return ErrTimeSyncStarted
+	}
+	s.started = true
+	s.stopC = make(chan struct{})
+	s.doneC = make(chan struct{})
+	s.lock.Unlock()
+
+	if err := s.Sync(ctx); err != nil {
+		close(s.doneC)
+		return err
+	}
+	go s.run()
+	return nil
+}
+
+// Stop the periodic syncs, the last offset is still used
+func (s *TimeSync) Stop() {
+	s.lock.Lock()
+	stopC, doneC := s.stopC, s.doneC
+	s.lock.Unlock()
+	if stopC == nil {
+		return
+	}
+	s.stopOnce.Do(func() { close(stopC) })
+	<-doneC
+}
+
+// Done is closed when the time sync is stopped
+func (s *TimeSync) Done() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.doneC
+}
+
+// Sync sample the server time now, and move the offset toward it
+func (s *TimeSync) Sync(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	return s.sync(ctx, true)
+}
+
+// Resync sample the server time now and apply it at once, e.g. after a
+// request was rejected for its timestamp. The concurrent calls sync once.
+func (s *TimeSync) Resync(ctx context.Context) error {
+	syncs := atomic.LoadInt64(&s.syncs)
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	if atomic.LoadInt64(&s.syncs) != syncs {
+		// synced while waiting for the lock
+		return nil
+	}
+	return s.sync(ctx, false)
+}
+
+// Synced return true if the time has been synced once
+func (s *TimeSync) Synced() bool {
+	return atomic.LoadInt64(&s.syncs) > 0
+}
+
+// Offset return the offset of the local clock to the server time, the
+// server time is the local time minus the offset
+func (s *TimeSync) Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.offset))
+}
+
+// Drift return the difference between the last sample and the offset before
+// it, i.e. how far the clocks drifted apart since the previous sync
+func (s *TimeSync) Drift() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.drift))
+}
+
+func (s *TimeSync) sync(ctx context.Context, smooth bool) error {
+	samples := s.Samples
+	if samples <= 0 {
+		samples = DefaultTimeSyncSamples
+	}
+	var sample, rtt time.Duration
+	var err error
+	found := false
+	for i := 0; i < samples && ctx.Err() == nil; i++ {
+		start := s.timeNow()
+		serverTime, e := s.ServerTime(ctx)
+		end := s.timeNow()
+		if e != nil {
+			err = e
+			continue
+		}
+		if d := end.Sub(start); !found || d < rtt {
+			sample = start.Add(d / 2).Sub(time.UnixMilli(serverTime))
+			rtt = d
+			found = true
+		}
+	}
+	if !found {
+		if err == nil {
+			err = ctx.Err()
+		}
+		return err
+	}
+
+	offset := sample
+	if s.Synced() {
+		current := s.Offset()
+		step := sample - current
+		atomic.StoreInt64(&s.drift, int64(step))
+		if smooth && step < TimeSyncMaxSmoothedStep && step > -TimeSyncMaxSmoothedStep {
+			smoothing := s.Smoothing
+			if smoothing <= 0 || smoothing > 1 {
+				smoothing = DefaultTimeSyncSmoothing
+			}
+			offset = current + time.Duration(float64(step)*smoothing)
+		}
+	}
+	atomic.StoreInt64(&s.offset, int64(offset))
+	atomic.AddInt64(&s.syncs, 1)
+	return nil
+}
+
+func (s *TimeSync) timeNow() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func (s *TimeSync) run() {
+	defer close(s.doneC)
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultTimeSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		select {
+		case <-s.stopC:
+			return
+		case <-ticker.C:
+			if err := s.Sync(ctx); err != nil && ctx.Err() == nil && s.ErrHandler != nil {
+				s.ErrHandler(err)
+			}
+		}
+	}
+}
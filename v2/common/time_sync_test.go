@@ -0,0 +1,122 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServerTime is a server whose clock is behind the local clock by lag,
+// and whose responses take the given round trips
+type fakeServerTime struct {
+	lock  sync.Mutex
+	now   time.Time
+	lag   time.Duration
+	rtts  []time.Duration
+	calls int
+	err   error
+}
+
+func (f *fakeServerTime) clock() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *fakeServerTime) serverTime(ctx context.Context) (int64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	rtt := f.rtts[f.calls%len(f.rtts)]
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	// the server answers in the middle of the round trip
+	f.now = f.now.Add(rtt / 2)
+	serverTime := f.now.Add(-f.lag).UnixMilli()
+	f.now = f.now.Add(rtt / 2)
+	return serverTime, nil
+}
+
+func newFakeTimeSync(f *fakeServerTime) *TimeSync {
+	s := &TimeSync{ServerTime: f.serverTime}
+	s.now = f.clock
+	return s
+}
+
+func TestTimeSyncRTTCompensation(t *testing.T) {
+	f := &fakeServerTime{now: time.Unix(1700000000, 0), lag: 250 * time.Millisecond, rtts: []time.Duration{300 * time.Millisecond, 20 * time.Millisecond, 100 * time.Millisecond}}
+	s := newFakeTimeSync(f)
+	assert.False(t, s.Synced())
+
+	require.NoError(t, s.Sync(context.Background()))
+	assert.True(t, s.Synced())
+	assert.Equal(t, DefaultTimeSyncSamples, f.calls)
+	assert.Equal(t, 250*time.Millisecond, s.Offset())
+	assert.Zero(t, s.Drift())
+}
+
+func TestTimeSyncSmoothing(t *testing.T) {
+	f := &fakeServerTime{now: time.Unix(1700000000, 0), lag: 100 * time.Millisecond, rtts: []time.Duration{10 * time.Millisecond}}
+	s := newFakeTimeSync(f)
+	s.Samples = 1
+	s.Smoothing = 0.5
+	require.NoError(t, s.Sync(context.Background()))
+	assert.Equal(t, 100*time.Millisecond, s.Offset())
+
+	// a small drift is smoothed
+	f.lag = 200 * time.Millisecond
+	require.NoError(t, s.Sync(context.Background()))
+	assert.Equal(t, 150*time.Millisecond, s.Offset())
+	assert.Equal(t, 100*time.Millisecond, s.Drift())
+
+	// a large step is applied at once
+	f.lag = 5 * time.Second
+	require.NoError(t, s.Sync(context.Background()))
+	assert.Equal(t, 5*time.Second, s.Offset())
+	assert.Equal(t, 5*time.Second-150*time.Millisecond, s.Drift())
+}
+
+func TestTimeSyncResync(t *testing.T) {
+	f := &fakeServerTime{now: time.Unix(1700000000, 0), lag: 100 * time.Millisecond, rtts: []time.Duration{10 * time.Millisecond}}
+	s := newFakeTimeSync(f)
+	s.Samples = 1
+	require.NoError(t, s.Sync(context.Background()))
+
+	f.lag = 300 * time.Millisecond
+	require.NoError(t, s.Resync(context.Background()))
+	assert.Equal(t, 300*time.Millisecond, s.Offset())
+	assert.Equal(t, 2, f.calls)
+}
+
+func TestTimeSyncError(t *testing.T) {
+	errFailed := errors.New("failed")
+	f := &fakeServerTime{now: time.Unix(1700000000, 0), rtts: []time.Duration{10 * time.Millisecond}, err: errFailed}
+	s := newFakeTimeSync(f)
+	assert.ErrorIs(t, s.Sync(context.Background()), errFailed)
+	assert.False(t, s.Synced())
+	assert.ErrorIs(t, s.Start(context.Background()), errFailed)
+	<-s.Done()
+}
+
+func TestTimeSyncStartStop(t *testing.T) {
+	f := &fakeServerTime{now: time.Unix(1700000000, 0), lag: time.Second, rtts: []time.Duration{10 * time.Millisecond}}
+	s := newFakeTimeSync(f)
+	s.Samples = 1
+	s.Interval = 10 * time.Millisecond
+	require.NoError(t, s.Start(context.Background()))
+	assert.ErrorIs(t, s.Start(context.Background()), ErrTimeSyncStarted)
+	assert.Equal(t, time.Second, s.Offset())
+	assert.Eventually(t, func() bool {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		return f.calls > 2
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+	<-s.Done()
+}
@@ -47,7 +47,7 @@ func (s *ConvertTradeHistoryService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res := ConvertTradeHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -45,7 +45,7 @@ func (s *ListLoanableCoinService) Do(ctx context.Context) (res *LoanableCoinList
 		return
 	}
 	res = new(LoanableCoinList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -112,7 +112,7 @@ func (s *ListCollateralCoinService) Do(ctx context.Context) (res *CollateralCoin
 		return
 	}
 	res = new(CollateralCoinList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -182,7 +182,7 @@ func (s *LoanBorrowLockedService) Do(ctx context.Context) (res *LoanBorrowLocked
 		return
 	}
 	res = new(LoanBorrowLockedResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -241,7 +241,7 @@ func (s *LoanRepayLockedService) Do(ctx context.Context) (res *LoanRepayLockedRe
 		return
 	}
 	res = new(LoanRepayLockedResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -298,7 +298,7 @@ func (s *ListLoanLockedService) Do(ctx context.Context) (res *LoanOrderLockedLis
 		return
 	}
 	res = new(LoanOrderLockedList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -35,7 +35,7 @@ func (s *ListLoanableCoinFlexibleService) Do(ctx context.Context) (res *Loanable
 		return
 	}
 	res = new(LoanableCoinFlexibleList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -82,7 +82,7 @@ func (s *ListCollateralCoinFlexibleService) Do(ctx context.Context) (res *Collat
 		return
 	}
 	res = new(CollateralCoinFlexibleList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -143,7 +143,7 @@ func (s *LoanBorrowFlexibleService) Do(ctx context.Context) (res *LoanBorrowFlex
 		return
 	}
 	res = new(LoanBorrowFlexibleResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -209,7 +209,7 @@ func (s *LoanRepayFlexibleService) Do(ctx context.Context) (res *LoanRepayFlexib
 		return
 	}
 	res = new(LoanRepayFlexibleResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -266,7 +266,7 @@ func (s *ListLoanFlexibleService) Do(ctx context.Context) (res *LoanOrderFlexibl
 		return
 	}
 	res = new(LoanOrderFlexibleList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -334,7 +334,7 @@ func (s *AdjustLtvLoanFlexibleService) Do(ctx context.Context) (res *AdjustLtvLo
 		return
 	}
 	res = new(AdjustLtvLoanFlexibleResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,125 @@
+package binance
+
+import "github.com/adshao/go-binance/v2/common"
+
+// Decimal accessors of the response types, an empty or invalid field is 0
+
+// PriceDecimal return Price as an exact decimal
+func (o *Order) PriceDecimal() common.Decimal {
+	return common.DecimalFromString(o.Price)
+}
+
+// OrigQuantityDecimal return OrigQuantity as an exact decimal
+func (o *Order) OrigQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.OrigQuantity)
+}
+
+// ExecutedQuantityDecimal return ExecutedQuantity as an exact decimal
+func (o *Order) ExecutedQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.ExecutedQuantity)
+}
+
+// CummulativeQuoteQuantityDecimal return CummulativeQuoteQuantity as an exact decimal
+func (o *Order) CummulativeQuoteQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.CummulativeQuoteQuantity)
+}
+
+// StopPriceDecimal return StopPrice as an exact decimal
+func (o *Order) StopPriceDecimal() common.Decimal {
+	return common.DecimalFromString(o.StopPrice)
+}
+
+// IcebergQuantityDecimal return IcebergQuantity as an exact decimal
+func (o *Order) IcebergQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.IcebergQuantity)
+}
+
+// FreeDecimal return Free as an exact decimal
+func (b *Balance) FreeDecimal() common.Decimal {
+	return common.DecimalFromString(b.Free)
+}
+
+// LockedDecimal return Locked as an exact decimal
+func (b *Balance) LockedDecimal() common.Decimal {
+	return common.DecimalFromString(b.Locked)
+}
+
+// PriceDecimal return Price as an exact decimal
+func (t *TradeV3) PriceDecimal() common.Decimal {
+	return common.DecimalFromString(t.Price)
+}
+
+// QuantityDecimal return Quantity as an exact decimal
+func (t *TradeV3) QuantityDecimal() common.Decimal {
+	return common.DecimalFromString(t.Quantity)
+}
+
+// QuoteQuantityDecimal return QuoteQuantity as an exact decimal
+func (t *TradeV3) QuoteQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(t.QuoteQuantity)
+}
+
+// CommissionDecimal return Commission as an exact decimal
+func (t *TradeV3) CommissionDecimal() common.Decimal {
+	return common.DecimalFromString(t.Commission)
+}
+
+// OpenDecimal return Open as an exact decimal
+func (k *Kline) OpenDecimal() common.Decimal {
+	return common.DecimalFromString(k.Open)
+}
+
+// HighDecimal return High as an exact decimal
+func (k *Kline) HighDecimal() common.Decimal {
+	return common.DecimalFromString(k.High)
+}
+
+// LowDecimal return Low as an exact decimal
+func (k *Kline) LowDecimal() common.Decimal {
+	return common.DecimalFromString(k.Low)
+}
+
+// CloseDecimal return Close as an exact decimal
+func (k *Kline) CloseDecimal() common.Decimal {
+	return common.DecimalFromString(k.Close)
+}
+
+// VolumeDecimal return Volume as an exact decimal
+func (k *Kline) VolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.Volume)
+}
+
+// QuoteAssetVolumeDecimal return QuoteAssetVolume as an exact decimal
+func (k *Kline) QuoteAssetVolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.QuoteAssetVolume)
+}
+
+// OpenDecimal return Open as an exact decimal
+func (k *WsKline) OpenDecimal() common.Decimal {
+	return common.DecimalFromString(k.Open)
+}
+
+// HighDecimal return High as an exact decimal
+func (k *WsKline) HighDecimal() common.Decimal {
+	return common.DecimalFromString(k.High)
+}
+
+// LowDecimal return Low as an exact decimal
+func (k *WsKline) LowDecimal() common.Decimal {
+	return common.DecimalFromString(k.Low)
+}
+
+// CloseDecimal return Close as an exact decimal
+func (k *WsKline) CloseDecimal() common.Decimal {
+	return common.DecimalFromString(k.Close)
+}
+
+// VolumeDecimal return Volume as an exact decimal
+func (k *WsKline) VolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.Volume)
+}
+
+// QuoteVolumeDecimal return QuoteVolume as an exact decimal
+func (k *WsKline) QuoteVolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.QuoteVolume)
+}
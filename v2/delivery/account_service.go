@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -17,13 +16,14 @@ func (s *GetBalanceService) Do(ctx context.Context, opts ...RequestOption) (res
 		method:   http.MethodGet,
 		endpoint: "/dapi/v1/balance",
 		secType:  secTypeSigned,
+		wsMethod: "account.balance",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*Balance{}, err
 	}
 	res = make([]*Balance, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Balance{}, err
 	}
@@ -53,13 +53,14 @@ func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res
 		method:   http.MethodGet,
 		endpoint: "/dapi/v1/account",
 		secType:  secTypeSigned,
+		wsMethod: "account.status",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(Account)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -3,15 +3,15 @@ package delivery
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -27,6 +27,16 @@ type PositionSideType string
 // OrderType define order type
 type OrderType string
 
+// isAlgo return true for the conditional orders, counted by the
+// MAX_NUM_ALGO_ORDERS filter
+func (t OrderType) isAlgo() bool {
+	switch t {
+	case OrderTypeStop, OrderTypeStopMarket, OrderTypeTakeProfit, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+		return true
+	}
+	return false
+}
+
 // TimeInForceType define time in force type of order
 type TimeInForceType string
 
@@ -64,9 +74,9 @@ type UserDataEventType string
 type UserDataEventReasonType string
 
 // Endpoints
-const (
-	baseApiMainUrl    = "https://dapi.binance.com"
-	baseApiTestnetUrl = "https://testnet.binancefuture.com"
+var (
+	BaseAPIMainURL    = "https://dapi.binance.com"
+	BaseAPITestnetURL = "https://testnet.binancefuture.com"
 )
 
 // Global enums
@@ -158,6 +168,7 @@ const (
 	UserDataEventReasonTypeOptionsPremiumFee   UserDataEventReasonType = "OPTIONS_PREMIUM_FEE"
 	UserDataEventReasonTypeOptionsSettleProfit UserDataEventReasonType = "OPTIONS_SETTLE_PROFIT"
 
+	apiKey        = "apiKey"
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
 	recvWindowKey = "recvWindow"
@@ -175,47 +186,139 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getApiEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getApiEndpoint() string {
-	if UseTestnet {
-		return baseApiTestnetUrl
-	}
-	return baseApiMainUrl
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewClient(apiKey, secretKey string) *Client {
-	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getApiEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+	return NewClientWithEnvironment(apiKey, secretKey, DefaultEnvironment())
+}
+
+// NewClientWithEnvironment initialize an API client instance with the
+// endpoints of the environment instead of the ones of the UseTestnet flag
+func NewClientWithEnvironment(apiKey, secretKey string, env Environment) *Client {
+	client := &Client{
+		APIKey:            apiKey,
+		SecretKey:         secretKey,
+		BaseURL:           env.BaseURL,
+		UserAgent:         "Binance/golang",
+		HTTPClient:        http.DefaultClient,
+		Logger:            log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		WsURL:             env.WsAPIURL,
+		WsStreamURL:       env.WsStreamURL,
+		CombinedStreamURL: env.CombinedStreamURL,
+		wsState:           WsInit,
+		wsStopC:           make(chan struct{}),
 	}
+	// the errors of the connection are logged by the logger of the client
+	client.Connect()
+
+	return client
 }
 
 type doFunc func(req *http.Request) (*http.Response, error)
 
 // Client define API client
 type Client struct {
+	sync.Mutex
 	APIKey     string
 	SecretKey  string
+	Signer     common.Signer
 	BaseURL    string
 	UserAgent  string
 	HTTPClient *http.Client
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	timeLock   sync.RWMutex
 	do         doFunc
+	// WsURL is the endpoint of the WebSocket API, and WsConn its connection
+	WsURL  string
+	WsConn *WsConnection
+	// WsStreamURL and CombinedStreamURL are the base endpoints of the
+	// websocket streams of the client, the ones of the UseTestnet flag if
+	// they are empty
+	WsStreamURL       string
+	CombinedStreamURL string
+	wsLock            sync.RWMutex
+	wsState           WsClientState // init/connecting/connected
+	wsSession         bool          // logon the session again after reconnecting
+	wsStopC           chan struct{} // closed by Close to stop reconnecting
+
+	reconnectPolicy *common.ReconnectPolicy
+
+	// OrderValidator checks the orders against the symbol filters before they
+	// are sent, nil to let the server check them
+	OrderValidator *common.OrderValidator
+	// RateLimiter keeps the REST and WebSocket API calls under the rate
+	// limits, nil to let the server enforce them
+	RateLimiter *common.RateLimitGovernor
+	// RetryPolicy sends the failed requests again when it is safe, nil to
+	// send them once
+	RetryPolicy *common.RetryPolicy
+	// Interceptors wrap every REST and WebSocket API call, the first one is
+	// the outermost
+	Interceptors []common.Interceptor
+	// Instrumentation receives the spans of the REST and WebSocket API
+	// calls, nil to ignore them
+	Instrumentation common.Instrumentation
+	// StructuredLogger receives the leveled logs of the client, with the
+	// secrets redacted. Logger is used if it is nil, from the debug level if
+	// Debug is set.
+	StructuredLogger common.Logger
+	// TimeSync, if set and synced, gives the time offset of the signed
+	// requests instead of TimeOffset. It is synced again when a request is
+	// rejected for its timestamp, and the request is sent once again.
+	TimeSync *common.TimeSync
+	// ResilientStreams, if set, reconnects the market data streams of the
+	// client when they drop and signals the gaps, nil to end them on the
+	// first error
+	ResilientStreams *common.ResilientStreams
+}
+
+// WsConnected return true if the client is connected to the WebSocket API,
+// the requests which have a WebSocket API method are sent over it then
+func (c *Client) WsConnected() bool {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	return c.wsState == WsConnected
+}
+
+// timeOffset return the offset of the local time to the server time in
+// milliseconds
+func (c *Client) timeOffset() int64 {
+	if c.TimeSync != nil && c.TimeSync.Synced() {
+		return c.TimeSync.Offset().Milliseconds()
+	}
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.TimeOffset
+}
+
+// setTimeOffset set TimeOffset, which the requests may be reading
+func (c *Client) setTimeOffset(offset int64) {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+	c.TimeOffset = offset
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
+// logger return the logger of the client, which redacts the secrets
+func (c *Client) logger() common.Logger {
+	if c.StructuredLogger != nil {
+		return common.NewRedactingLogger(c.StructuredLogger)
+	}
+	level := common.LogLevelInfo
 	if c.Debug {
-		c.Logger.Printf(format, v...)
+		level = common.LogLevelDebug
 	}
+	return common.NewRedactingLogger(common.NewStdLogger(c.Logger, level))
+}
+
+// signer return the signer of SIGNED requests, HMAC with SecretKey by default
+func (c *Client) signer() common.Signer {
+	if c.Signer != nil {
+		return c.Signer
+	}
+	return common.NewHMACSigner(c.SecretKey)
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
@@ -233,7 +336,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		r.setParam(recvWindowKey, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+		r.setParam(timestampKey, currentTimestamp()-c.timeOffset())
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -252,13 +355,12 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, sig)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -268,7 +370,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.logger().Debug("request url", "url", fullURL, "body", bodyString)
 
 	r.fullURL = fullURL
 	r.header = header
@@ -276,7 +378,131 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	return nil
 }
 
+// callAPI send the request, and send it once again after syncing TimeSync
+// if its timestamp is outside of the recvWindow
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	if c.TimeSync == nil || r.secType != secTypeSigned {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	rc := r.clone()
+	data, err = c.retryAPI(ctx, rc, opts...)
+	r.ws, r.statusCode = rc.ws, rc.statusCode
+	if errors.Is(err, common.ErrTimestampOutsideRecvWindow) && c.TimeSync.Resync(ctx) == nil {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	return data, err
+}
+
+// retryAPI send the request, and send it again according to RetryPolicy
+func (c *Client) retryAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	if c.RetryPolicy == nil {
+		return c.callAPIOnce(ctx, r, opts...)
+	}
+	err = c.RetryPolicy.Do(ctx, &common.RetryRequest{
+		Idempotent:    r.idempotent(),
+		PlacesOrder:   r.orderCount() > 0,
+		ClientOrderID: r.param("newClientOrderId"),
+		Send: func() (err error) {
+			rc := r.clone()
+			data, err = c.callAPIOnce(ctx, rc, opts...)
+			r.ws, r.statusCode = rc.ws, rc.statusCode
+			return err
+		},
+		LookupOrder: c.lookupOrder(ctx, r, &data),
+	})
+	return data, err
+}
+
+// lookupOrder return a function which finds the order placed by the request
+// by its client order id, nil if the order cannot be queried
+func (c *Client) lookupOrder(ctx context.Context, r *request, data *[]byte) func() (bool, error) {
+	if r.endpoint != "/dapi/v1/order" {
+		return nil
+	}
+	return func() (bool, error) {
+		order, err := c.NewGetOrderService().Symbol(r.param("symbol")).OrigClientOrderID(r.param("newClientOrderId")).Do(ctx)
+		if errors.Is(err, common.ErrUnknownOrder) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		*data, err = json.Marshal(order)
+		return err == nil, err
+	}
+}
+
+// callAPIOnce send the request without retrying it
+func (c *Client) callAPIOnce(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Acquire(ctx, r.method, r.endpoint, r.weight(), r.orderCount()); err != nil {
+			return []byte{}, err
+		}
+	}
+	// prefer to WS API
+	ws := c.WsConnected() && r.wsMethod != ""
+	return c.invoke(ctx, r, ws, opts...)
+}
+
+// invoke send the request through the instrumentation and the Interceptors,
+// over the WebSocket API if ws is true
+func (c *Client) invoke(ctx context.Context, r *request, ws bool, opts ...RequestOption) (data []byte, err error) {
+	ctx, span := c.startSpan(ctx, r, ws)
+	if len(c.Interceptors) > 0 {
+		data, err = c.intercept(ctx, r, ws, opts...)
+	} else {
+		data, err = c.sendAPI(ctx, r, ws, opts...)
+	}
+	span.Finish(common.NewSpanEnd(r.statusCode, r.rateLimits, err))
+	return data, err
+}
+
+func (c *Client) instrumentation() common.Instrumentation {
+	return common.InstrumentationOrNoop(c.Instrumentation)
+}
+
+// startSpan start the span of the request, sent over the WebSocket API if
+// ws is true
+func (c *Client) startSpan(ctx context.Context, r *request, ws bool) (context.Context, common.Span) {
+	start := &common.SpanStart{BaseURL: c.BaseURL, Method: r.method, Endpoint: r.endpoint, WebSocket: ws, Time: time.Now()}
+	if ws {
+		start.BaseURL = c.WsURL
+		start.WsMethod = r.wsMethod
+	}
+	return c.instrumentation().StartSpan(ctx, start)
+}
+
+// intercept send the request through the Interceptors
+func (c *Client) intercept(ctx context.Context, r *request, ws bool, opts ...RequestOption) ([]byte, error) {
+	// the interceptors see the request options
+	for _, opt := range opts {
+		opt(r)
+	}
+	invoker := common.ChainInterceptors(c.Interceptors, func(ctx context.Context, req *common.APIRequest) (*common.APIResponse, error) {
+		r.setAPIRequest(req)
+		data, err := c.sendAPI(ctx, r, req.WebSocket)
+		if err != nil {
+			return nil, err
+		}
+		return &common.APIResponse{Data: data, StatusCode: r.statusCode, RateLimits: r.rateLimits}, nil
+	})
+	res, err := invoker(ctx, r.apiRequest(ws))
+	if err != nil {
+		return []byte{}, err
+	}
+	if res == nil {
+		return []byte{}, nil
+	}
+	r.statusCode, r.rateLimits = res.StatusCode, res.RateLimits
+	return res.Data, nil
+}
+
+// sendAPI send the request over the WebSocket API, or the REST API
+func (c *Client) sendAPI(ctx context.Context, r *request, ws bool, opts ...RequestOption) (data []byte, err error) {
+	r.ws = ws
+	if ws {
+		return c.callWsAPI(ctx, r, opts...)
+	}
 	err = c.parseRequest(r, opts...)
 	if err != nil {
 		return []byte{}, err
@@ -287,18 +513,18 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.logger().Debug("request", "method", req.Method, "url", req.URL.String(), "header", req.Header)
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
 	res, err := f(req)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	data, err = ioutil.ReadAll(res.Body)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	defer func() {
 		cerr := res.Body.Close()
@@ -308,17 +534,30 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 			err = cerr
 		}
 	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
+	c.logger().Debug("response", "status", res.StatusCode, "header", res.Header, "body", string(data))
+
+	r.statusCode = res.StatusCode
+	r.rateLimits = common.RateLimitsFromHeader(res.Header)
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(r.rateLimits...)
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			c.RateLimiter.Backoff(common.RetryAfterFromHeader(res.Header))
+		}
+	}
 	if res.StatusCode >= http.StatusBadRequest {
 		apiErr := new(common.APIError)
 		e := json.Unmarshal(data, apiErr)
 		if e != nil {
 			apiErr.Code = int64(res.StatusCode)
 			apiErr.Message = string(data)
-			c.debug("failed to unmarshal json: %s", e)
+			c.logger().Debug("failed to unmarshal json", "error", e)
+		}
+		apiErr.StatusCode = res.StatusCode
+		apiErr.Method = r.method
+		apiErr.Endpoint = r.endpoint
+		apiErr.RateLimits = r.rateLimits
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			apiErr.RetryAfter = common.RetryAfterFromHeader(res.Header)
 		}
 		return nil, apiErr
 	}
@@ -331,6 +570,21 @@ func (c *Client) SetApiEndpoint(url string) *Client {
 	return c
 }
 
+// NewSessionLogonService init session logon service of WebSocket API
+func (c *Client) NewSessionLogonService() *SessionLogonService {
+	return &SessionLogonService{c: c}
+}
+
+// NewSessionStatusService init session status service of WebSocket API
+func (c *Client) NewSessionStatusService() *SessionStatusService {
+	return &SessionStatusService{c: c}
+}
+
+// NewSessionLogoutService init session logout service of WebSocket API
+func (c *Client) NewSessionLogoutService() *SessionLogoutService {
+	return &SessionLogoutService{c: c}
+}
+
 // NewPingService init ping service
 func (c *Client) NewPingService() *PingService {
 	return &PingService{c: c}
@@ -3,10 +3,15 @@ package delivery
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
+	"testing"
+	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -142,3 +147,137 @@ func (m *mockedClient) do(req *http.Request) (*http.Response, error) {
 	args := m.Called(req)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+type clientTestSuite struct {
+	baseTestSuite
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
+
+func (s *clientTestSuite) TestSigner() {
+	s.client.Signer = common.NewHMACSigner("otherSecretKey")
+	s.mockDo([]byte(`[]`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		signature, err := s.client.Signer.Sign([]byte(fmt.Sprintf("%s=%s", timestampKey, r.query.Get(timestampKey))))
+		s.r().NoError(err)
+		s.r().Equal(signature, r.query.Get(signatureKey))
+	})
+
+	_, err := s.client.NewGetBalanceService().Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *clientTestSuite) TestRateLimited() {
+	res := newHTTPResponse([]byte(`{"code": -1003, "msg": "Too many requests."}`), http.StatusTooManyRequests)
+	res.Header = http.Header{}
+	res.Header.Set("Retry-After", "30")
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(res, nil).Once()
+	s.client.RateLimiter = common.NewRateLimitGovernor()
+	s.client.RateLimiter.FailFast = true
+
+	err := s.client.NewPingService().Do(newContext())
+	s.r().ErrorIs(err, common.ErrRateLimited)
+	// the next calls are not sent during the backoff
+	err = s.client.NewPingService().Do(newContext())
+	s.r().True(common.IsRateLimitError(err))
+	s.client.AssertNumberOfCalls(s.T(), "do", 1)
+}
+
+func (s *clientTestSuite) TestErrorContext() {
+	s.mockDo([]byte(`{"code": -1121, "msg": "Invalid symbol."}`), nil, http.StatusBadRequest)
+
+	err := s.client.NewPingService().Do(newContext())
+	s.r().True(common.IsAPIError(err))
+	apiErr := err.(*common.APIError)
+	s.r().Equal(http.StatusBadRequest, apiErr.StatusCode)
+	s.r().Equal(http.MethodGet, apiErr.Method)
+	s.r().Equal("/dapi/v1/ping", apiErr.Endpoint)
+}
+
+func (s *clientTestSuite) TestDecodeErrorContext() {
+	s.mockDo([]byte(`{}`), nil)
+
+	_, err := s.client.NewGetBalanceService().Do(newContext())
+	var decodeErr *common.DecodeError
+	s.r().ErrorAs(err, &decodeErr)
+	s.r().Equal(http.MethodGet, decodeErr.Method)
+	s.r().Equal("/dapi/v1/balance", decodeErr.Endpoint)
+	s.r().Equal(http.StatusOK, decodeErr.StatusCode)
+}
+
+func (s *clientTestSuite) TestInterceptors() {
+	res := newHTTPResponse([]byte(`{}`), http.StatusOK)
+	res.Header = http.Header{}
+	res.Header.Set("X-MBX-USED-WEIGHT-1M", "12")
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(res, nil).Once()
+	s.assertReq(func(r *request) {
+		s.r().Equal("1", r.query.Get("traceId"))
+	})
+
+	var seen *common.APIResponse
+	s.client.Interceptors = []common.Interceptor{
+		func(ctx context.Context, req *common.APIRequest, next common.Invoker) (*common.APIResponse, error) {
+			s.r().Equal("/dapi/v1/ping", req.Endpoint)
+			req.Query.Set("traceId", "1")
+			res, err := next(ctx, req)
+			seen = res
+			return res, err
+		},
+	}
+	err := s.client.NewPingService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(http.StatusOK, seen.StatusCode)
+	s.r().Len(seen.RateLimits, 1)
+}
+
+func (s *clientTestSuite) TestInstrumentation() {
+	res := newHTTPResponse([]byte(`{"code": -1121, "msg": "Invalid symbol."}`), http.StatusBadRequest)
+	res.Header = http.Header{}
+	res.Header.Set("X-MBX-USED-WEIGHT-1M", "10")
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(res, nil).Once()
+	instrumentation := common.NewMetricsInstrumentation()
+	s.client.Instrumentation = instrumentation
+
+	err := s.client.NewPingService().Do(newContext())
+	s.r().Error(err)
+	var buf bytes.Buffer
+	s.r().NoError(instrumentation.WritePrometheus(&buf))
+	s.r().Contains(buf.String(), `binance_api_requests_total{method="GET",endpoint="/dapi/v1/ping",status="400"} 1`)
+	s.r().Contains(buf.String(), `binance_api_errors_total{method="GET",endpoint="/dapi/v1/ping",code="-1121"} 1`)
+	s.r().Contains(buf.String(), fmt.Sprintf(`binance_rate_limit_usage{base_url=%q,type="REQUEST_WEIGHT",interval="1m"} 10`, s.client.BaseURL))
+}
+
+func (s *clientTestSuite) TestLogsRedacted() {
+	s.mockDo([]byte(`[]`), nil)
+	defer s.assertDo()
+	var buf bytes.Buffer
+	s.client.StructuredLogger = common.NewStdLogger(log.New(&buf, "", 0), common.LogLevelDebug)
+
+	_, err := s.client.NewGetBalanceService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Contains(buf.String(), "[REDACTED]")
+	s.r().NotContains(buf.String(), s.apiKey)
+}
+
+func (s *clientTestSuite) TestResyncTime() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1021, "msg": "Timestamp for this request is outside of the recvWindow."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`[]`), http.StatusOK), nil).Once()
+	s.client.TimeSync = &common.TimeSync{
+		ServerTime: func(ctx context.Context) (int64, error) {
+			return time.Now().Add(-time.Hour).UnixMilli(), nil
+		},
+		Samples: 1,
+	}
+
+	_, err := s.client.NewGetBalanceService().Do(newContext())
+	s.r().NoError(err)
+	s.r().InDelta(time.Hour.Milliseconds(), s.client.TimeSync.Offset().Milliseconds(), 1000)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
@@ -0,0 +1,150 @@
+package delivery
+
+import "github.com/adshao/go-binance/v2/common"
+
+// Decimal accessors of the response types, an empty or invalid field is 0
+
+// PriceDecimal return Price as an exact decimal
+func (o *Order) PriceDecimal() common.Decimal {
+	return common.DecimalFromString(o.Price)
+}
+
+// OrigQuantityDecimal return OrigQuantity as an exact decimal
+func (o *Order) OrigQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.OrigQuantity)
+}
+
+// ExecutedQuantityDecimal return ExecutedQuantity as an exact decimal
+func (o *Order) ExecutedQuantityDecimal() common.Decimal {
+	return common.DecimalFromString(o.ExecutedQuantity)
+}
+
+// CumBaseDecimal return CumBase as an exact decimal
+func (o *Order) CumBaseDecimal() common.Decimal {
+	return common.DecimalFromString(o.CumBase)
+}
+
+// AvgPriceDecimal return AvgPrice as an exact decimal
+func (o *Order) AvgPriceDecimal() common.Decimal {
+	return common.DecimalFromString(o.AvgPrice)
+}
+
+// StopPriceDecimal return StopPrice as an exact decimal
+func (o *Order) StopPriceDecimal() common.Decimal {
+	return common.DecimalFromString(o.StopPrice)
+}
+
+// BalanceDecimal return Balance as an exact decimal
+func (b *Balance) BalanceDecimal() common.Decimal {
+	return common.DecimalFromString(b.Balance)
+}
+
+// CrossWalletBalanceDecimal return CrossWalletBalance as an exact decimal
+func (b *Balance) CrossWalletBalanceDecimal() common.Decimal {
+	return common.DecimalFromString(b.CrossWalletBalance)
+}
+
+// CrossUnPnlDecimal return CrossUnPnl as an exact decimal
+func (b *Balance) CrossUnPnlDecimal() common.Decimal {
+	return common.DecimalFromString(b.CrossUnPnl)
+}
+
+// AvailableBalanceDecimal return AvailableBalance as an exact decimal
+func (b *Balance) AvailableBalanceDecimal() common.Decimal {
+	return common.DecimalFromString(b.AvailableBalance)
+}
+
+// WithdrawAvailableDecimal return WithdrawAvailable as an exact decimal
+func (b *Balance) WithdrawAvailableDecimal() common.Decimal {
+	return common.DecimalFromString(b.WithdrawAvailable)
+}
+
+// PositionAmtDecimal return PositionAmt as an exact decimal
+func (p *PositionRisk) PositionAmtDecimal() common.Decimal {
+	return common.DecimalFromString(p.PositionAmt)
+}
+
+// EntryPriceDecimal return EntryPrice as an exact decimal
+func (p *PositionRisk) EntryPriceDecimal() common.Decimal {
+	return common.DecimalFromString(p.EntryPrice)
+}
+
+// MarkPriceDecimal return MarkPrice as an exact decimal
+func (p *PositionRisk) MarkPriceDecimal() common.Decimal {
+	return common.DecimalFromString(p.MarkPrice)
+}
+
+// UnRealizedProfitDecimal return UnRealizedProfit as an exact decimal
+func (p *PositionRisk) UnRealizedProfitDecimal() common.Decimal {
+	return common.DecimalFromString(p.UnRealizedProfit)
+}
+
+// LiquidationPriceDecimal return LiquidationPrice as an exact decimal
+func (p *PositionRisk) LiquidationPriceDecimal() common.Decimal {
+	return common.DecimalFromString(p.LiquidationPrice)
+}
+
+// IsolatedMarginDecimal return IsolatedMargin as an exact decimal
+func (p *PositionRisk) IsolatedMarginDecimal() common.Decimal {
+	return common.DecimalFromString(p.IsolatedMargin)
+}
+
+// OpenDecimal return Open as an exact decimal
+func (k *Kline) OpenDecimal() common.Decimal {
+	return common.DecimalFromString(k.Open)
+}
+
+// HighDecimal return High as an exact decimal
+func (k *Kline) HighDecimal() common.Decimal {
+	return common.DecimalFromString(k.High)
+}
+
+// LowDecimal return Low as an exact decimal
+func (k *Kline) LowDecimal() common.Decimal {
+	return common.DecimalFromString(k.Low)
+}
+
+// CloseDecimal return Close as an exact decimal
+func (k *Kline) CloseDecimal() common.Decimal {
+	return common.DecimalFromString(k.Close)
+}
+
+// VolumeDecimal return Volume as an exact decimal
+func (k *Kline) VolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.Volume)
+}
+
+// QuoteAssetVolumeDecimal return QuoteAssetVolume as an exact decimal
+func (k *Kline) QuoteAssetVolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.QuoteAssetVolume)
+}
+
+// OpenDecimal return Open as an exact decimal
+func (k *WsKline) OpenDecimal() common.Decimal {
+	return common.DecimalFromString(k.Open)
+}
+
+// HighDecimal return High as an exact decimal
+func (k *WsKline) HighDecimal() common.Decimal {
+	return common.DecimalFromString(k.High)
+}
+
+// LowDecimal return Low as an exact decimal
+func (k *WsKline) LowDecimal() common.Decimal {
+	return common.DecimalFromString(k.Low)
+}
+
+// CloseDecimal return Close as an exact decimal
+func (k *WsKline) CloseDecimal() common.Decimal {
+	return common.DecimalFromString(k.Close)
+}
+
+// VolumeDecimal return Volume as an exact decimal
+func (k *WsKline) VolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.Volume)
+}
+
+// QuoteVolumeDecimal return QuoteVolume as an exact decimal
+func (k *WsKline) QuoteVolumeDecimal() common.Decimal {
+	return common.DecimalFromString(k.QuoteVolume)
+}
@@ -0,0 +1,14 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalAccessors(t *testing.T) {
+	p := &PositionRisk{PositionAmt: "-0.30000000", EntryPrice: "9500.1", UnRealizedProfit: ""}
+	assert.Equal(t, "-0.30000000", p.PositionAmtDecimal().String())
+	assert.Equal(t, "-2850.030000000", p.PositionAmtDecimal().Mul(p.EntryPriceDecimal()).String())
+	assert.True(t, p.UnRealizedProfitDecimal().IsZero())
+}
@@ -0,0 +1,71 @@
+package delivery
+
+// Environment define the endpoints of a client, e.g. of the production, the
+// testnet or a regional host
+type Environment struct {
+	// BaseURL of the REST API
+	BaseURL string
+	// WsStreamURL of the websocket streams
+	WsStreamURL string
+	// CombinedStreamURL of the combined streams, ending with ?streams=
+	CombinedStreamURL string
+	// WsAPIURL of the WebSocket API, the client does not connect to it if
+	// it is empty
+	WsAPIURL string
+}
+
+// MainEnvironment return the endpoints of the production
+func MainEnvironment() Environment {
+	return Environment{
+		BaseURL:           BaseAPIMainURL,
+		WsStreamURL:       baseWsMainUrl,
+		CombinedStreamURL: baseCombinedMainURL,
+		WsAPIURL:          WsAPIMainURL,
+	}
+}
+
+// TestnetEnvironment return the endpoints of the testnet
+func TestnetEnvironment() Environment {
+	return Environment{
+		BaseURL:           BaseAPITestnetURL,
+		WsStreamURL:       baseWsTestnetUrl,
+		CombinedStreamURL: baseCombinedTestnetURL,
+		WsAPIURL:          WsAPITestnetURL,
+	}
+}
+
+// DefaultEnvironment return the endpoints according the UseTestnet flag
+func DefaultEnvironment() Environment {
+	if UseTestnet {
+		return TestnetEnvironment()
+	}
+	return MainEnvironment()
+}
+
+// Environment return the endpoints of the client
+func (c *Client) Environment() Environment {
+	return Environment{
+		BaseURL:           c.BaseURL,
+		WsStreamURL:       c.wsEndpoint(),
+		CombinedStreamURL: c.combinedEndpoint(),
+		WsAPIURL:          c.WsURL,
+	}
+}
+
+// wsEndpoint return the base endpoint of the websocket streams of the
+// client, according the UseTestnet flag if it has none
+func (c *Client) wsEndpoint() string {
+	if c.WsStreamURL != "" {
+		return c.WsStreamURL
+	}
+	return getWsEndpoint()
+}
+
+// combinedEndpoint return the base endpoint of the combined streams of the
+// client, according the UseTestnet flag if it has none
+func (c *Client) combinedEndpoint() string {
+	if c.CombinedStreamURL != "" {
+		return c.CombinedStreamURL
+	}
+	return getCombinedEndpoint()
+}
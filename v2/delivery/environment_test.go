@@ -0,0 +1,49 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithEnvironment(t *testing.T) {
+	env := Environment{
+		BaseURL:           "https://dapi.example.com",
+		WsStreamURL:       "wss://dstream.example.com/ws",
+		CombinedStreamURL: "wss://dstream.example.com/stream?streams=",
+	}
+	c := NewClientWithEnvironment("apiKey", "secretKey", env)
+	assert.Equal(t, env, c.Environment())
+
+	var endpoints []string
+	origWsServe := wsServe
+	defer func() { wsServe = origWsServe }()
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+
+	_, _, err := c.WsAggTradeServe("BTCUSD_PERP", func(event *WsAggTradeEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = c.WsCombinedMarketTickerServe([]string{"BTCUSD_PERP"}, func(event *WsMarketTickerEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = c.WsDiffDepthServe("BTCUSD_PERP", func(event *WsDepthEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = WsAggTradeServe("BTCUSD_PERP", func(event *WsAggTradeEvent) {}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"wss://dstream.example.com/ws/btcusd_perp@aggTrade",
+		"wss://dstream.example.com/stream?streams=btcusd_perp@ticker",
+		"wss://dstream.example.com/ws/btcusd_perp@depth",
+		"wss://dstream.binance.com/ws/btcusd_perp@aggTrade",
+	}, endpoints)
+}
+
+func TestClientEnvironmentDefault(t *testing.T) {
+	c := &Client{BaseURL: BaseAPIMainURL, WsURL: WsAPIMainURL}
+	assert.Equal(t, MainEnvironment(), c.Environment())
+
+	UseTestnet = true
+	defer func() { UseTestnet = false }()
+	assert.Equal(t, TestnetEnvironment(), NewClient("apiKey", "secretKey").Environment())
+}
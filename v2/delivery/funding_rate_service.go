@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -62,7 +61,7 @@ func (s *ListFundingRateService) Do(ctx context.Context, opts ...RequestOption)
 	}
 
 	res = make([]*FundingRate, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +94,7 @@ func (s *ListFundingInfoService) Do(ctx context.Context, opts ...RequestOption)
 	}
 
 	res = make([]*FundingInfo, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -33,7 +32,7 @@ func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOptio
 		return []*LeverageBracket{}, err
 	}
 	res = make([]*LeverageBracket, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*LeverageBracket{}, err
 	}
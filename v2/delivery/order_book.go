@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// OrderBook is a local order book of a symbol, synchronized from the depth
+// snapshot and the diff depth stream. See common.OrderBook.
+type OrderBook struct {
+	*common.OrderBook
+}
+
+// NewOrderBook create a local COIN-M futures order book of the symbol, it is
+// synchronized after Start
+func (c *Client) NewOrderBook(symbol string) *OrderBook {
+	rate := 100 * time.Millisecond
+	return &OrderBook{&common.OrderBook{
+		Symbol: symbol,
+		Snapshot: func(ctx context.Context, limit int) (*common.DepthSnapshot, error) {
+			res, err := c.NewDepthService().Symbol(symbol).Limit(limit).Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &common.DepthSnapshot{
+				LastUpdateID: int64(res.LastUpdateID),
+				Bids:         toPriceLevels(res.Bids),
+				Asks:         toPriceLevels(res.Asks),
+			}, nil
+		},
+		Serve: func(handler func(update *common.DepthUpdate), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+			return c.WsDiffDepthServeWithRate(symbol, &rate, func(event *WsDepthEvent) {
+				handler(&common.DepthUpdate{
+					Time:             event.Time,
+					FirstUpdateID:    event.FirstUpdateID,
+					LastUpdateID:     event.LastUpdateID,
+					PrevLastUpdateID: event.PrevLastUpdateID,
+					Bids:             event.Bids,
+					Asks:             event.Asks,
+				})
+			}, errHandler)
+		},
+		UsePrevUpdateID: true,
+	}}
+}
+
+// toPriceLevels convert the [price, quantity] pairs of Depth
+func toPriceLevels(levels [][]string) []common.PriceLevel {
+	res := make([]common.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		res = append(res, common.PriceLevel{Price: level[0], Quantity: level[1]})
+	}
+	return res
+}
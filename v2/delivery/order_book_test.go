@@ -0,0 +1,79 @@
+package delivery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	baseTestSuite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.origWsServe = wsServe
+}
+
+func (s *orderBookTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *orderBookTestSuite) TestOrderBook() {
+	data := []byte(`{
+		"lastUpdateId": 17285690,
+		"symbol": "BTCUSD_200626",
+		"pair": "BTCUSD",
+		"E": 1591270260907,
+		"T": 1591270260891,
+		"bids": [["9517.5", "3"], ["9517.6", "10"]],
+		"asks": [["9518.5", "45"], ["9518.6", "1"]]
+	}`)
+	s.mockDo(data, nil)
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": "BTCUSD_200626",
+			"limit":  1000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	var endpoint string
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoint = cfg.Endpoint
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			<-stopC
+			close(doneC)
+		}()
+		handler([]byte(`{"e":"depthUpdate","E":1591270260907,"T":1591270260891,"s":"BTCUSD_200626","ps":"BTCUSD",
+			"U":17285681,"u":17285702,"pu":17285675,"b":[["9517.6","0"]],"a":[["9518.4","2"]]}`))
+		return doneC, stopC, nil
+	}
+
+	book := s.client.NewOrderBook("BTCUSD_200626")
+	book.ErrHandler = func(err error) {
+		s.r().NoError(err)
+	}
+	s.r().NoError(book.Start())
+	defer book.Stop()
+	s.r().Eventually(book.Synced, time.Second, time.Millisecond)
+
+	s.r().Equal(getWsEndpoint()+"/btcusd_200626@depth@100ms", endpoint)
+	s.r().Equal(int64(17285702), book.LastUpdateID())
+	bid, ok := book.BestBid()
+	s.r().True(ok)
+	s.r().Equal(common.PriceLevel{Price: "9517.5", Quantity: "3"}, bid)
+	s.r().Equal([]common.PriceLevel{
+		{Price: "9518.4", Quantity: "2"},
+		{Price: "9518.5", Quantity: "45"},
+	}, book.Asks(2))
+}
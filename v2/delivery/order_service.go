@@ -2,8 +2,9 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // CreateOrderService create order
@@ -123,11 +124,26 @@ func (s *CreateOrderService) ClosePosition(closePosition bool) *CreateOrderServi
 	return s
 }
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
-	r := &request{
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, r *request, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:    s.symbol,
+			Side:      string(s.side),
+			Market:    s.price == nil,
+			Algo:      s.orderType.isAlgo(),
+			Price:     s.price,
+			StopPrice: s.stopPrice,
+			Quantity:  &s.quantity,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
+		wsMethod: wsMethod,
 	}
 	m := params{
 		"symbol":           s.symbol,
@@ -172,19 +188,19 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
-	data, err := s.createOrder(ctx, "/dapi/v1/order", opts...)
+	data, r, err := s.createOrder(ctx, "/dapi/v1/order", "order.place", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +272,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -295,6 +311,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		method:   http.MethodGet,
 		endpoint: "/dapi/v1/order",
 		secType:  secTypeSigned,
+		wsMethod: "order.status",
 	}
 	r.setParam("symbol", s.symbol)
 	if s.orderID != nil {
@@ -308,7 +325,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +437,7 @@ func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -459,6 +476,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		method:   http.MethodDelete,
 		endpoint: "/dapi/v1/order",
 		secType:  secTypeSigned,
+		wsMethod: "order.cancel",
 	}
 	r.setFormParam("symbol", s.symbol)
 	if s.orderID != nil {
@@ -472,7 +490,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -601,7 +619,7 @@ func (s *ListLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return []*LiquidationOrder{}, err
 	}
 	res = make([]*LiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*LiquidationOrder{}, err
 	}
@@ -1,9 +1,13 @@
 package delivery
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 type baseOrderTestSuite struct {
@@ -122,6 +126,81 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	s.assertCreateOrderResponseEqual(e, res)
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderValidation() {
+	s.client.OrderValidator = common.NewOrderValidator(&common.SymbolFilters{
+		Symbol:      "BTCUSD_200925",
+		TickSize:    common.MustParseDecimal("0.1"),
+		MinQuantity: common.MustParseDecimal("1"),
+		StepSize:    common.MustParseDecimal("1"),
+	})
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSD_200925").
+		Side(SideTypeBuy).Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("0.5").Price("9000").Do(newContext())
+	s.r().True(common.IsOrderFilterError(err))
+	s.r().Equal("LOT_SIZE", err.(*common.OrderFilterError).Filter)
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+
+	s.client.OrderValidator.AutoRound = true
+	s.mockDo([]byte(`{"orderId": 22542179}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":           "BTCUSD_200925",
+			"side":             SideTypeBuy,
+			"type":             OrderTypeLimit,
+			"timeInForce":      TimeInForceTypeGTC,
+			"quantity":         "10",
+			"price":            "9000.1",
+			"newOrderRespType": "",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	_, err = s.client.NewCreateOrderService().Symbol("BTCUSD_200925").
+		Side(SideTypeBuy).Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("10.7").Price("9000.06").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *orderServiceTestSuite) TestCreateOrderRetry() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -2013, "msg": "Order does not exist."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"orderId": 22542179, "clientOrderId": "testOrder"}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSD_200925").
+		Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1").
+		NewClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().EqualValues(22542179, res.OrderID)
+	s.client.AssertNumberOfCalls(s.T(), "do", 3)
+}
+
+func (s *orderServiceTestSuite) TestCreateOrderRetryFound() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"orderId": 22542179, "clientOrderId": "testOrder", "status": "FILLED"}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSD_200925").
+		Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1").
+		NewClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().EqualValues(22542179, res.OrderID)
+	s.r().Equal(OrderStatusTypeFilled, res.Status)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
+
+func (s *orderServiceTestSuite) TestCreateOrderNotRetried() {
+	s.mockDo([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), nil, http.StatusBadRequest)
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	_, err := s.client.NewCreateOrderService().Symbol("BTCUSD_200925").
+		Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1").Do(newContext())
+	s.r().ErrorIs(err, common.ErrUnknownExecutionStatus)
+	s.client.AssertNumberOfCalls(s.T(), "do", 1)
+}
+
 func (s *baseOrderTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderResponse) {
 	r := s.r()
 	r.Equal(e.ClientOrderID, a.ClientOrderID, "ClientOrderID")
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -31,6 +30,7 @@ func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption)
 		method:   http.MethodGet,
 		endpoint: "/dapi/v1/positionRisk",
 		secType:  secTypeSigned,
+		wsMethod: "account.position",
 	}
 	if s.marginAsset != nil {
 		r.setParam("marginAsset", *s.marginAsset)
@@ -43,7 +43,7 @@ func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption)
 		return []*PositionRisk{}, err
 	}
 	res = make([]*PositionRisk, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*PositionRisk{}, err
 	}
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -41,7 +40,7 @@ func (s *ChangeLeverageService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(SymbolLeverage)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -205,7 +204,7 @@ func (s *GetPositionModeService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = &PositionMode{}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -35,7 +34,7 @@ func (s *GetPremiumIndexService) Do(ctx context.Context, opts ...RequestOption)
 	}
 
 	res = make([]*PremiumIndex, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
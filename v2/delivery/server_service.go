@@ -3,6 +3,8 @@ package delivery
 import (
 	"context"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // PingService ping server
@@ -35,7 +37,7 @@ func (s *ServerTimeService) Do(ctx context.Context, opts ...RequestOption) (serv
 	if err != nil {
 		return 0, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return 0, err
 	}
@@ -55,6 +57,16 @@ func (s *SetServerTimeService) Do(ctx context.Context, opts ...RequestOption) (t
 		return 0, err
 	}
 	timeOffset = currentTimestamp() - serverTime
-	s.c.TimeOffset = timeOffset
+	s.c.setTimeOffset(timeOffset)
 	return timeOffset, nil
 }
+
+// NewTimeSync create a TimeSync which samples the server time of the client,
+// to be set as its TimeSync
+func (c *Client) NewTimeSync() *common.TimeSync {
+	return &common.TimeSync{
+		ServerTime: func(ctx context.Context) (int64, error) {
+			return c.NewServerTimeService().Do(ctx)
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// StreamManager subscribe the market streams of a client over a few
+// connections to its combined streams endpoint. The streams can be added
+// and removed without reconnecting, by their names, e.g.
+// btcusd_perp@aggTrade.
+type StreamManager struct {
+	*common.StreamManager
+}
+
+// NewStreamManager create a stream manager of the combined streams endpoint
+// of the client, the connections are dialed by the first subscriptions
+func (c *Client) NewStreamManager() *StreamManager {
+	endpoint := strings.TrimSuffix(c.combinedEndpoint(), "?streams=")
+	return &StreamManager{&common.StreamManager{
+		Dial: func(ctx context.Context) (common.StreamConn, error) {
+			return dialStream(ctx, endpoint)
+		},
+		ErrHandler: func(err error) {
+			websocketLogger().Error("stream manager error", "endpoint", endpoint, "error", err)
+		},
+	}}
+}
+
+// SetErrHandler set the handler of the errors of the connections, a
+// *common.StreamsDroppedError when one drops
+func (m *StreamManager) SetErrHandler(errHandler ErrHandler) *StreamManager {
+	m.ErrHandler = errHandler
+	return m
+}
+
+// symbolStreams return the names of the stream of the symbols
+func symbolStreams(symbols []string, format string, args ...interface{}) []string {
+	suffix := fmt.Sprintf(format, args...)
+	streams := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		streams[i] = strings.ToLower(symbol) + suffix
+	}
+	return streams
+}
+
+// streamErrHandler log the errors of the streams without error handler
+func streamErrHandler(errHandler ErrHandler) ErrHandler {
+	if errHandler != nil {
+		return errHandler
+	}
+	return func(err error) {
+		websocketLogger().Error("stream error", "error", err)
+	}
+}
+
+// SubscribeAggTrade subscribe the <symbol>@aggTrade streams
+func (m *StreamManager) SubscribeAggTrade(ctx context.Context, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsAggTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@aggTrade")...)
+}
+
+// SubscribeMarkPrice subscribe the <symbol>@markPrice streams
+func (m *StreamManager) SubscribeMarkPrice(ctx context.Context, symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMarkPriceEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@markPrice")...)
+}
+
+// SubscribeKline subscribe the <symbol>@kline_<interval> streams
+func (m *StreamManager) SubscribeKline(ctx context.Context, symbols []string, interval string, handler WsKlineHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsKlineEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@kline_%s", interval)...)
+}
+
+// SubscribeMiniMarketTicker subscribe the <symbol>@miniTicker streams
+func (m *StreamManager) SubscribeMiniMarketTicker(ctx context.Context, symbols []string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMiniMarketTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@miniTicker")...)
+}
+
+// SubscribeMarketTicker subscribe the <symbol>@ticker streams
+func (m *StreamManager) SubscribeMarketTicker(ctx context.Context, symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMarketTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@ticker")...)
+}
+
+// SubscribeBookTicker subscribe the <symbol>@bookTicker streams
+func (m *StreamManager) SubscribeBookTicker(ctx context.Context, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsBookTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@bookTicker")...)
+}
+
+// SubscribeDiffDepth subscribe the <symbol>@depth streams
+func (m *StreamManager) SubscribeDiffDepth(ctx context.Context, symbols []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth"), handler, errHandler)
+}
+
+// SubscribePartialDepth subscribe the <symbol>@depth<levels> streams, the
+// levels are 5, 10 or 20
+func (m *StreamManager) SubscribePartialDepth(ctx context.Context, symbols []string, levels int, handler WsDepthHandler, errHandler ErrHandler) error {
+	if levels != 5 && levels != 10 && levels != 20 {
+		return errors.New("Invalid levels")
+	}
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth%d", levels), handler, errHandler)
+}
+
+func (m *StreamManager) subscribeDepth(ctx context.Context, streams []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		j, err := newJSON(data)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(newWsDepthEvent(j))
+	}, streams...)
+}
@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamConn acknowledge the methods, the streams are pushed with push
+type fakeStreamConn struct {
+	readC  chan []byte
+	closeC chan struct{}
+	once   sync.Once
+	params [][]string
+}
+
+func (c *fakeStreamConn) ReadMessage() (int, []byte, error) {
+	select {
+	case message := <-c.readC:
+		return 1, message, nil
+	case <-c.closeC:
+		return 0, nil, errors.New("connection closed")
+	}
+}
+
+func (c *fakeStreamConn) WriteMessage(messageType int, data []byte) error {
+	var req struct {
+		Params []string `json:"params"`
+		ID     int64    `json:"id"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	c.params = append(c.params, req.Params)
+	c.readC <- []byte(fmt.Sprintf(`{"result":null,"id":%d}`, req.ID))
+	return nil
+}
+
+func (c *fakeStreamConn) Close() error {
+	c.once.Do(func() { close(c.closeC) })
+	return nil
+}
+
+func TestStreamManagerSubscribeAggTrade(t *testing.T) {
+	conn := &fakeStreamConn{readC: make(chan []byte, 10), closeC: make(chan struct{})}
+	m := NewClientWithEnvironment("apiKey", "secretKey", MainEnvironment()).NewStreamManager()
+	m.Dial = func(ctx context.Context) (common.StreamConn, error) {
+		return conn, nil
+	}
+	defer m.Close()
+
+	eventC := make(chan *WsAggTradeEvent, 1)
+	err := m.SubscribeAggTrade(context.Background(), []string{"BTCUSD_PERP"}, func(event *WsAggTradeEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusd_perp@aggTrade"}}, conn.params)
+
+	conn.readC <- []byte(`{"stream":"btcusd_perp@aggTrade","data":{"e":"aggTrade","E":1591261134288,"a":424951,"s":"BTCUSD_PERP","p":"9643.5","q":"2","f":606073,"l":606073,"T":1591261134199,"m":false}}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSD_PERP", event.Symbol)
+		assert.Equal(t, "9643.5", event.Price)
+		assert.Equal(t, int64(424951), event.AggregateTradeID)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+
+	err = m.SubscribeDiffDepth(context.Background(), []string{"BTCUSD_PERP"}, func(event *WsDepthEvent) {}, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"btcusd_perp@aggTrade", "btcusd_perp@depth"}, m.Streams())
+}
@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -43,7 +42,7 @@ func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption)
 		return []*BookTicker{}, err
 	}
 	res = make([]*BookTicker, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*BookTicker{}, err
 	}
@@ -97,7 +96,7 @@ func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*SymbolPrice{}, err
 	}
 	res = make([]*SymbolPrice, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*SymbolPrice{}, err
 	}
@@ -148,7 +147,7 @@ func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOpt
 		return res, err
 	}
 	res = make([]*PriceChangeStats, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -15,13 +15,14 @@ func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		secType:  secTypeAPIKey,
+		wsMethod: "userDataStream.start",
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return "", err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return "", err
 	}
@@ -46,7 +47,8 @@ func (s *KeepaliveUserStreamService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodPut,
 		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		secType:  secTypeAPIKey,
+		wsMethod: "userDataStream.ping",
 	}
 	r.setFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
@@ -70,7 +72,8 @@ func (s *CloseUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/dapi/v1/listenKey",
-		secType:  secTypeSigned,
+		secType:  secTypeAPIKey,
+		wsMethod: "userDataStream.stop",
 	}
 	r.setFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/bitly/go-simplejson"
 )
 
 // Endpoints
@@ -60,7 +62,12 @@ type WsAggTradeHandler func(event *WsAggTradeEvent)
 
 // WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
 func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@aggTrade", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsAggTradeServe serve websocket that push trade information that is aggregated for a single taker order.
+func (c *Client) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@aggTrade", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
@@ -71,7 +78,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsIndexPriceEvent define websocket indexPriceUpdate event.
@@ -87,7 +94,12 @@ type WsIndexPriceHandler func(event *WsIndexPriceEvent)
 
 // WsIndexPriceServe serve websocket that pushes index price for a pair.
 func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@indexPrice", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsIndexPriceServe(symbol, handler, errHandler)
+}
+
+// WsIndexPriceServe serve websocket that pushes index price for a pair.
+func (c *Client) WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@indexPrice", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceEvent)
@@ -98,7 +110,7 @@ func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler Er
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsMarkPriceEvent define websocket markPriceUpdate event.
@@ -117,7 +129,12 @@ type WsMarkPriceHandler func(event *WsMarkPriceEvent)
 
 // WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
 func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPrice", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe serve websocket that pushes price and funding rate for a single symbol.
+func (c *Client) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPrice", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
@@ -128,7 +145,7 @@ func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrH
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsPairMarkPriceEvent defines an array of websocket markPriceUpdate events.
@@ -139,7 +156,12 @@ type WsPairMarkPriceHandler func(event WsPairMarkPriceEvent)
 
 // WsPairMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
 func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/markPrice@arr", getWsEndpoint())
+	return new(Client).WsPairMarkPriceServe(handler, errHandler)
+}
+
+// WsPairMarkPriceServe serve websocket that pushes price and funding rate for all symbol.
+func (c *Client) WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/markPrice@arr", c.wsEndpoint())
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsPairMarkPriceEvent
@@ -150,7 +172,7 @@ func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler)
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsKlineEvent define websocket kline event
@@ -186,7 +208,12 @@ type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
+	return new(Client).WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (c *Client) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@kline_%s", c.wsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
@@ -197,7 +224,7 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsContinuousKlineEvent define websocket continuous kline event
@@ -233,7 +260,12 @@ type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
 
 // WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
 func WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(pair), strings.ToLower(contractType), interval)
+	return new(Client).WsContinuousKlineServe(pair, contractType, interval, handler, errHandler)
+}
+
+// WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
+func (c *Client) WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", c.wsEndpoint(), strings.ToLower(pair), strings.ToLower(contractType), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
@@ -244,7 +276,7 @@ func WsContinuousKlineServe(pair string, contractType string, interval string, h
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsIndexPriceKlineEvent define websocket index price kline event
@@ -274,7 +306,12 @@ type WsIndexPriceKlineHandler func(event *WsIndexPriceKlineEvent)
 
 // WsIndexPriceKlineServe serve websocket kline handler with a pair and interval like 15m, 30s
 func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@indexPriceKline_%s", getWsEndpoint(), strings.ToLower(pair), interval)
+	return new(Client).WsIndexPriceKlineServe(pair, interval, handler, errHandler)
+}
+
+// WsIndexPriceKlineServe serve websocket kline handler with a pair and interval like 15m, 30s
+func (c *Client) WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@indexPriceKline_%s", c.wsEndpoint(), strings.ToLower(pair), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceKlineEvent)
@@ -285,7 +322,7 @@ func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKl
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsMarkPriceKlineEvent define websocket market price kline event
@@ -316,7 +353,12 @@ type WsMarkPriceKlineHandler func(event *WsMarkPriceKlineEvent)
 
 // WsMarkPriceKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
 func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@markPriceKline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
+	return new(Client).WsMarkPriceKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsMarkPriceKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
+func (c *Client) WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@markPriceKline_%s", c.wsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceKlineEvent)
@@ -327,7 +369,7 @@ func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKl
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsMiniMarketTickerEvent define websocket mini market ticker event.
@@ -349,7 +391,12 @@ type WsMiniMarketTickerHandler func(event *WsMiniMarketTickerEvent)
 
 // WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@miniTicker", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (c *Client) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@miniTicker", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
@@ -360,7 +407,7 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsAllMiniMarketTickerEvent define an array of websocket mini market ticker events.
@@ -371,7 +418,12 @@ type WsAllMiniMarketTickerHandler func(event WsAllMiniMarketTickerEvent)
 
 // WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!miniTicker@arr", getWsEndpoint())
+	return new(Client).WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (c *Client) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!miniTicker@arr", c.wsEndpoint())
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
@@ -382,7 +434,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsMarketTickerEvent define websocket market ticker event.
@@ -413,7 +465,12 @@ type WsMarketTickerHandler func(event *WsMarketTickerEvent)
 
 // WsCombinedMarketTickerServe is similar to WsMarketTickerServe, but it handles multiple symbols
 func WsCombinedMarketTickerServe(symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := getCombinedEndpoint()
+	return new(Client).WsCombinedMarketTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedMarketTickerServe is similar to WsMarketTickerServe, but it handles multiple symbols
+func (c *Client) WsCombinedMarketTickerServe(symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := c.combinedEndpoint()
 	for s := range symbols {
 		endpoint += fmt.Sprintf("%s@ticker", strings.ToLower(symbols[s])) + "/"
 	}
@@ -445,12 +502,17 @@ func WsCombinedMarketTickerServe(symbols []string, handler WsMarketTickerHandler
 
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
 func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@ticker", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsMarketTickerServe serve websocket that pushes 24hr rolling window mini-ticker statistics for a single symbol.
+func (c *Client) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@ticker", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
@@ -461,7 +523,7 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsAllMarketTickerEvent define an array of websocket mini ticker events.
@@ -472,7 +534,12 @@ type WsAllMarketTickerHandler func(event WsAllMarketTickerEvent)
 
 // WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
 func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!ticker@arr", getWsEndpoint())
+	return new(Client).WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsAllMarketTickerServe serve websocket that pushes price and funding rate for all markets.
+func (c *Client) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!ticker@arr", c.wsEndpoint())
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
@@ -483,7 +550,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsBookTickerEvent define websocket best book ticker event.
@@ -510,7 +577,12 @@ type WsBookTickerHandler func(event *WsBookTickerEvent)
 
 // WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
 func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@bookTicker", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for a specified symbol.
+func (c *Client) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@bookTicker", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
@@ -521,12 +593,17 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
 func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := baseCombinedMainURL
+	return new(Client).WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsCombinedBookTickerServe is similar to WsBookTickerServe, but it is for multiple symbols
+func (c *Client) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := c.combinedEndpoint()
 	for _, s := range symbols {
 		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
 	}
@@ -541,12 +618,17 @@ func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, er
 		}
 		handler(event.Data)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
 func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!bookTicker", getWsEndpoint())
+	return new(Client).WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsAllBookTickerServe serve websocket that pushes updates to the best bid or ask price or quantity in real-time for all symbols.
+func (c *Client) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!bookTicker", c.wsEndpoint())
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
@@ -557,7 +639,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsLiquidationOrderEvent define websocket liquidation order event.
@@ -588,7 +670,12 @@ type WsLiquidationOrderHandler func(event *WsLiquidationOrderEvent)
 
 // WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
 func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@forceOrder", getWsEndpoint(), strings.ToLower(symbol))
+	return new(Client).WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsLiquidationOrderServe serve websocket that pushes force liquidation order information for specific symbol.
+func (c *Client) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@forceOrder", c.wsEndpoint(), strings.ToLower(symbol))
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
@@ -599,12 +686,17 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
 func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/!forceOrder@arr", getWsEndpoint())
+	return new(Client).WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe serve websocket that pushes force liquidation order information for all symbols.
+func (c *Client) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!forceOrder@arr", c.wsEndpoint())
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
@@ -615,7 +707,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 		}
 		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
 }
 
 // WsDepthEvent define websocket depth book event
@@ -635,35 +727,55 @@ type WsDepthEvent struct {
 // WsDepthHandler handle websocket depth event
 type WsDepthHandler func(event *WsDepthEvent)
 
-func wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (c *Client) wsPartialDepthServe(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	if levels != 5 && levels != 10 && levels != 20 {
 		return nil, nil, errors.New("Invalid levels")
 	}
 	levelsStr := fmt.Sprintf("%d", levels)
-	return wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
+	return c.wsDepthServe(symbol, levelsStr, rate, handler, errHandler)
 }
 
 // WsPartialDepthServe serve websocket partial depth handler.
 func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
+	return new(Client).WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe serve websocket partial depth handler.
+func (c *Client) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return c.wsPartialDepthServe(symbol, levels, nil, handler, errHandler)
 }
 
 // WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
 func WsPartialDepthServeWithRate(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsPartialDepthServe(symbol, levels, rate, handler, errHandler)
+	return new(Client).WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
+func (c *Client) WsPartialDepthServeWithRate(symbol string, levels int, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return c.wsPartialDepthServe(symbol, levels, rate, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler.
 func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", nil, handler, errHandler)
+	return new(Client).WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsDiffDepthServe serve websocket diff. depth handler.
+func (c *Client) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return c.wsDepthServe(symbol, "", nil, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler with rate.
 func WsDiffDepthServeWithRate(symbol string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	return wsDepthServe(symbol, "", rate, handler, errHandler)
+	return new(Client).WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe serve websocket diff. depth handler with rate.
+func (c *Client) WsDiffDepthServeWithRate(symbol string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return c.wsDepthServe(symbol, "", rate, handler, errHandler)
 }
 
-func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func (c *Client) wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	var rateStr string
 	if rate != nil {
 		switch *rate {
@@ -678,7 +790,7 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 		}
 	}
 
-	endpoint := fmt.Sprintf("%s/%s@depth%s%s", getWsEndpoint(), strings.ToLower(symbol), levels, rateStr)
+	endpoint := fmt.Sprintf("%s/%s@depth%s%s", c.wsEndpoint(), strings.ToLower(symbol), levels, rateStr)
 	cfg := newWsConfig(endpoint)
 
 	wsHandler := func(message []byte) {
@@ -687,36 +799,41 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 			errHandler(err)
 			return
 		}
-		event := new(WsDepthEvent)
-		event.Event = j.Get("e").MustString()
-		event.Time = j.Get("E").MustInt64()
-		event.TransactionTime = j.Get("T").MustInt64()
-		event.Symbol = j.Get("s").MustString()
-		event.Pair = j.Get("ps").MustString()
-		event.FirstUpdateID = j.Get("U").MustInt64()
-		event.LastUpdateID = j.Get("u").MustInt64()
-		event.PrevLastUpdateID = j.Get("pu").MustInt64()
-		bidsLen := len(j.Get("b").MustArray())
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := j.Get("b").GetIndex(i)
-			event.Bids[i] = Bid{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
+		handler(newWsDepthEvent(j))
+	}
+	return c.wsServeMarket(cfg, wsHandler, errHandler)
+}
+
+// newWsDepthEvent decode a depth event
+func newWsDepthEvent(j *simplejson.Json) *WsDepthEvent {
+	event := new(WsDepthEvent)
+	event.Event = j.Get("e").MustString()
+	event.Time = j.Get("E").MustInt64()
+	event.TransactionTime = j.Get("T").MustInt64()
+	event.Symbol = j.Get("s").MustString()
+	event.Pair = j.Get("ps").MustString()
+	event.FirstUpdateID = j.Get("U").MustInt64()
+	event.LastUpdateID = j.Get("u").MustInt64()
+	event.PrevLastUpdateID = j.Get("pu").MustInt64()
+	bidsLen := len(j.Get("b").MustArray())
+	event.Bids = make([]Bid, bidsLen)
+	for i := 0; i < bidsLen; i++ {
+		item := j.Get("b").GetIndex(i)
+		event.Bids[i] = Bid{
+			Price:    item.GetIndex(0).MustString(),
+			Quantity: item.GetIndex(1).MustString(),
 		}
-		asksLen := len(j.Get("a").MustArray())
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := j.Get("a").GetIndex(i)
-			event.Asks[i] = Ask{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
+	}
+	asksLen := len(j.Get("a").MustArray())
+	event.Asks = make([]Ask, asksLen)
+	for i := 0; i < asksLen; i++ {
+		item := j.Get("a").GetIndex(i)
+		event.Asks[i] = Ask{
+			Price:    item.GetIndex(0).MustString(),
+			Quantity: item.GetIndex(1).MustString(),
 		}
-		handler(event)
 	}
-	return wsServe(cfg, wsHandler, errHandler)
+	return event
 }
 
 // WsUserDataEvent define user data event
@@ -808,7 +925,12 @@ type WsUserDataHandler func(event *WsUserDataEvent)
 
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
+	return new(Client).WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataServe serve user data handler with listen key
+func (c *Client) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", c.wsEndpoint(), listenKey)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
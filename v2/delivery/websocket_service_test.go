@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -109,6 +110,63 @@ func (s *websocketServiceTestSuite) assertWsAggTradeEvent(e, a *WsAggTradeEvent)
 	r.Equal(e.Maker, a.Maker, "Maker")
 }
 
+func (s *websocketServiceTestSuite) TestResilientAggTradeServe() {
+	data := []byte(`{"e":"aggTrade","E":1591261134288,"a":424951,"s":"BTCUSD_200626","p":"9643.5","q":"2"}`)
+	var endpoints []string
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, innerErr error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		first := len(endpoints) == 1
+		go func() {
+			handler(data)
+			if first {
+				// the server drops the first connection
+				errHandler(errors.New("connection reset"))
+				close(doneC)
+				return
+			}
+			<-stopC
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+
+	gapC := make(chan common.StreamGap, 1)
+	client := NewClient("apiKey", "secretKey")
+	client.ResilientStreams = &common.ResilientStreams{
+		ReconnectPolicy: &common.ReconnectPolicy{InitialBackoff: time.Millisecond, Multiplier: 1},
+		OnGap:           func(gap common.StreamGap) { gapC <- gap },
+	}
+	eventC := make(chan *WsAggTradeEvent, 2)
+	gapErrC := make(chan *common.StreamGapError, 1)
+	doneC, stopC, err := client.WsAggTradeServe("BTCUSD_200626", func(event *WsAggTradeEvent) {
+		eventC <- event
+	}, func(err error) {
+		// the gap is also given to the error handler of the stream
+		var gapErr *common.StreamGapError
+		if errors.As(err, &gapErr) {
+			gapErrC <- gapErr
+			return
+		}
+		s.r().EqualError(err, "connection reset")
+	})
+	s.r().NoError(err)
+
+	gap := <-gapC
+	s.r().Equal(gap, (<-gapErrC).StreamGap)
+	s.r().Equal(1, gap.Attempts)
+	s.r().Equal(gap.ReconnectedAt.Sub(gap.DisconnectedAt), gap.Duration)
+	for i := 0; i < 2; i++ {
+		s.r().Equal("BTCUSD_200626", (<-eventC).Symbol)
+	}
+	stopC <- struct{}{}
+	<-doneC
+	s.r().Len(endpoints, 2)
+	s.r().Equal(endpoints[0], endpoints[1])
+	s.r().Equal(endpoints[0], gap.Endpoint)
+}
+
 // https://binance-docs.github.io/apidocs/delivery/en/#index-price-stream
 func (s *websocketServiceTestSuite) TestIndexPriceServe() {
 	data := []byte(`{
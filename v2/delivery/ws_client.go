@@ -0,0 +1,543 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+type WsClientState int
+
+const (
+	WsInit         WsClientState = 0
+	WsConnecting   WsClientState = 1
+	WsConnected    WsClientState = 2
+	WsAdminClosing WsClientState = 3
+)
+
+// Endpoints
+var (
+	WsAPIMainURL    = "wss://ws-dapi.binance.com/ws-dapi/v1"
+	WsAPITestnetURL = "wss://testnet.binancefuture.com/ws-dapi/v1"
+)
+
+// _ResponseMap holds the pending requests of a WebSocket API connection
+type _ResponseMap struct {
+	lock   sync.Mutex
+	d      map[string]chan *WsApiResponse
+	closed bool
+}
+
+func newResponseMap() *_ResponseMap {
+	return &_ResponseMap{d: make(map[string]chan *WsApiResponse)}
+}
+
+func (m *_ResponseMap) LoadAndDelete(id string) chan *WsApiResponse {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if a := m.d[id]; a != nil {
+		delete(m.d, id)
+		return a
+	}
+	return nil
+}
+
+// Set register a pending request, return false if the connection is closed already
+func (m *_ResponseMap) Set(id string, ch chan *WsApiResponse) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.closed {
+		return false
+	}
+	m.d[id] = ch
+	return true
+}
+
+// Close fail all the pending requests by closing their channels
+func (m *_ResponseMap) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.closed = true
+	for id, ch := range m.d {
+		close(ch)
+		delete(m.d, id)
+	}
+}
+
+type WsApiResponse struct {
+	Id     string `json:"id"`
+	Status int    `json:"status"`
+	Error  struct {
+		Code int64  `json:"code"`
+		Msg  string `json:"msg"`
+		Data struct {
+			// RetryAfter is the time in ms when a 429 or 418 backoff ends
+			RetryAfter int64 `json:"retryAfter"`
+		} `json:"data"`
+	} `json:"error"`
+	Result     ObjectType `json:"result"`
+	RateLimits []struct {
+		RateLimitType string `json:"rateLimitType"`
+		Interval      string `json:"interval"`
+		IntervalNum   int    `json:"intervalNum"`
+		Limit         int    `json:"limit"`
+		Count         int    `json:"count"`
+	} `json:"rateLimits"`
+}
+
+// ObjectType keep the raw JSON of the result of a response
+type ObjectType string
+
+// UnmarshalJSON keep the raw JSON as it is
+func (o *ObjectType) UnmarshalJSON(data []byte) error {
+	*o = ObjectType(data)
+	return nil
+}
+
+type WsConnection struct {
+	*websocket.Conn
+	Done chan struct{}
+	Stop chan struct{}
+	// responses of the pending requests, by request id
+	responses *_ResponseMap
+	// err is the read error which closed the connection
+	err error
+	// loggedOn is set to 1 once session.logon succeeded on the connection
+	loggedOn int32
+}
+
+// LoggedOn return true if the connection is authenticated by session.logon
+func (c *WsConnection) LoggedOn() bool {
+	return atomic.LoadInt32(&c.loggedOn) == 1
+}
+
+// Err return the error which closed the connection after Done is closed,
+// nil if it is closed by Stop
+func (c *WsConnection) Err() error {
+	select {
+	case <-c.Done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *WsConnection) setLoggedOn(loggedOn bool) {
+	var v int32
+	if loggedOn {
+		v = 1
+	}
+	atomic.StoreInt32(&c.loggedOn, v)
+}
+
+func makeConn(endpoint string, logger func() common.Logger) (*WsConnection, error) {
+	Dialer := websocket.Dialer{
+		Proxy:             http.ProxyFromEnvironment,
+		HandshakeTimeout:  45 * time.Second,
+		EnableCompression: true, // important for huge size message
+	}
+
+	c, _, err := Dialer.Dial(endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.SetReadLimit(wsReadLimit)
+	doneC := make(chan struct{})
+	stopC := make(chan struct{})
+	conn := &WsConnection{
+		Conn:      c,
+		Done:      doneC,
+		Stop:      stopC,
+		responses: newResponseMap(),
+	}
+
+	go func() {
+		// This function will exit either on error from
+		// websocket.Conn.ReadMessage or when the stopC channel is
+		// closed by the client.
+		// The pending requests are failed once doneC is closed.
+		defer conn.responses.Close()
+		defer close(doneC)
+		if WebsocketKeepalive {
+			keepAlive(c, WebsocketTimeout)
+		}
+		// Wait for the stopC channel to be closed.  We do that in a
+		// separate goroutine because ReadMessage is a blocking
+		// operation.
+		adminForced := false
+		go func() {
+			select {
+			case <-stopC:
+				adminForced = true
+			case <-doneC:
+			}
+			_ = c.Close()
+
+		}()
+		for {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				if !adminForced {
+					conn.err = err
+					logger().Error("websocket error", "endpoint", endpoint, "error", err)
+				}
+				return
+			}
+			res := new(WsApiResponse)
+			err = json.Unmarshal(message, res)
+			if err != nil {
+				return
+			}
+			if a := conn.responses.LoadAndDelete(res.Id); a != nil {
+				a <- res
+				close(a)
+			} else {
+				logger().Warn("unexpected websocket api response, the request may be timed out", "endpoint", endpoint, "id", res.Id)
+			}
+		}
+	}()
+
+	return conn, nil
+}
+
+// SetReconnectPolicy set how the WebSocket API connection is re-established after it drops
+func (c *Client) SetReconnectPolicy(policy *common.ReconnectPolicy) *Client {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	c.reconnectPolicy = policy
+	return c
+}
+
+func (c *Client) getReconnectPolicy() *common.ReconnectPolicy {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.reconnectPolicy == nil {
+		return common.DefaultReconnectPolicy()
+	}
+	return c.reconnectPolicy
+}
+
+// wsConn return the WebSocket API connection, nil if it is not connected
+func (c *Client) wsConn() *WsConnection {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.wsState != WsConnected {
+		return nil
+	}
+	return c.WsConn
+}
+
+func (c *Client) handleDisconnected(conn *WsConnection) {
+	go func() {
+		<-conn.Done
+
+		c.wsLock.Lock()
+		// if it is triggered by AdminClose, just ignore
+		if c.wsState != WsConnected || c.WsConn != conn {
+			c.wsLock.Unlock()
+			return
+		}
+		c.wsState = WsConnecting
+		stopC := c.wsStopC
+		c.wsLock.Unlock()
+
+		policy := c.getReconnectPolicy()
+		c.logger().Warn("websocket api disconnected, try reconnecting later", "endpoint", c.WsURL, "error", conn.Err())
+		c.instrumentation().WsDisconnect(c.WsURL, conn.Err())
+		if policy.OnDisconnect != nil {
+			policy.OnDisconnect(conn.Err())
+		}
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = policy.Wait(attempt, stopC)
+			if err != nil {
+				break
+			}
+			err = c.reconnect()
+			if err != common.ErrReconnectStopped {
+				c.instrumentation().WsReconnect(c.WsURL, attempt, err)
+			}
+			if err == nil {
+				c.logger().Info("websocket api reconnected", "endpoint", c.WsURL, "attempt", attempt)
+				if policy.OnReconnect != nil {
+					policy.OnReconnect(attempt)
+				}
+				return
+			}
+			if err == common.ErrReconnectStopped {
+				break
+			}
+			c.logger().Warn("failed to reconnect websocket api", "endpoint", c.WsURL, "attempt", attempt, "error", err)
+			if !policy.ShouldRetry(attempt) {
+				break
+			}
+		}
+
+		if policy.Stopped(err) {
+			c.logger().Info("stop reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+			return
+		}
+		c.logger().Error("give up reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+		if policy.OnGiveUp != nil {
+			policy.OnGiveUp(err)
+		}
+	}()
+}
+
+// reconnect dial a new connection and restore the session of the dropped one
+// before using it
+func (c *Client) reconnect() error {
+	conn, err := makeConn(c.WsURL, c.logger)
+	if err != nil {
+		return err
+	}
+	c.wsLock.RLock()
+	wsSession := c.wsSession
+	c.wsLock.RUnlock()
+	if wsSession {
+		_, err = c.logonSession(context.Background(), conn)
+		if err != nil {
+			close(conn.Stop)
+			return err
+		}
+	}
+
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.wsState != WsConnecting {
+		close(conn.Stop)
+		return common.ErrReconnectStopped
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn)
+	return nil
+}
+
+// Connect dial the WebSocket API connection of the client, if it has an
+// endpoint and is not connected yet
+func (c *Client) Connect() error {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.WsURL == "" || c.wsState != WsInit {
+		return nil
+	}
+	conn, err := makeConn(c.WsURL, c.logger)
+	if err != nil {
+		return err
+	}
+	if c.wsStopC == nil {
+		c.wsStopC = make(chan struct{})
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn)
+	return nil
+}
+
+// Close close the WebSocket API connection and stop reconnecting
+func (c *Client) Close() {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	switch c.wsState {
+	case WsConnected:
+		close(c.WsConn.Stop)
+	case WsConnecting:
+	default:
+		return
+	}
+	c.wsState = WsAdminClosing
+	close(c.wsStopC)
+}
+
+// Encode encodes the values into “URL encoded” form
+// ("bar=baz&foo=quux") sorted by key.
+func (v params) Encode() string {
+	if len(v) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		vs := v[k]
+		if buf.Len() > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		buf.WriteString(fmt.Sprintf("%v", vs))
+	}
+	return buf.String()
+}
+
+func (c *Client) parseWsRequest(conn *WsConnection, r *request, opts ...RequestOption) (err error) {
+	// set request options from user
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.recvWindow > 0 {
+		r.setParam(recvWindowKey, r.recvWindow)
+	}
+	if r.wsParams == nil {
+		r.wsParams = params{}
+	}
+	// collect params from query & form, construct wsParams
+	for k, v := range r.query {
+		r.wsParams[k] = v[0]
+	}
+	for k, v := range r.form {
+		r.wsParams[k] = v[0]
+	}
+
+	if r.secType == secTypeAPIKey {
+		r.wsParams[apiKey] = c.APIKey
+	}
+	if r.secType == secTypeSigned {
+		r.wsParams[timestampKey] = currentTimestamp() - c.timeOffset()
+	}
+	// requests on an authenticated session are signed by session.logon already
+	if r.secType == secTypeSigned && (r.wsMethod == sessionLogonMethod || !conn.LoggedOn()) {
+		r.wsParams[apiKey] = c.APIKey
+		raw := r.wsParams.Encode()
+
+		sig, err := c.signer().Sign([]byte(raw))
+		if err != nil {
+			return err
+		}
+		r.wsParams[signatureKey] = sig
+	}
+
+	c.logger().Debug("ws request params", "method", r.wsMethod, "params", r.wsParams)
+
+	return nil
+}
+
+func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOption) ([]byte, error) {
+	conn := r.wsConn
+	if conn == nil {
+		conn = c.wsConn()
+	}
+	if conn == nil {
+		return nil, ErrWsAPINotConnected
+	}
+	return c.callWsConnAPI(ctx, conn, r, opts...)
+}
+
+// callWsConnAPI send the request over the given WebSocket API connection
+func (c *Client) callWsConnAPI(ctx context.Context, conn *WsConnection, r *request, opts ...RequestOption) ([]byte, error) {
+	err := c.parseWsRequest(conn, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// allocate channel, size 1
+	id, ch := uuid.NewString(), make(chan *WsApiResponse, 1)
+
+	req := map[string]interface{}{
+		"id":     id,
+		"method": r.wsMethod,
+	}
+	if len(r.wsParams) > 0 {
+		req["params"] = r.wsParams
+	}
+
+	c.logger().Debug("ws request", "request", req)
+
+	if !conn.responses.Set(id, ch) {
+		return nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+	}
+	c.Lock()
+	err = conn.WriteJSON(req)
+	c.Unlock()
+
+	if err != nil {
+		conn.responses.LoadAndDelete(id)
+		return nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: err}
+	}
+
+	// timeout context
+	ctx2, cancel := context.WithTimeout(context.TODO(), 15*time.Second)
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+		conn.responses.LoadAndDelete(id)
+		return nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx.Err()}
+
+	case <-ctx2.Done():
+		conn.responses.LoadAndDelete(id)
+		return nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx2.Err()}
+
+	case res, ok := <-ch:
+		if !ok {
+			return nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+		}
+		c.logger().Debug("ws response", "status", res.Status, "result", string(res.Result), "error", res.Error)
+
+		r.statusCode = res.Status
+		r.rateLimits = res.rateLimits()
+		if c.RateLimiter != nil {
+			c.updateWsRateLimits(res)
+		}
+		if res.Status >= http.StatusBadRequest {
+			apiErr := new(common.APIError)
+			apiErr.Code = res.Error.Code
+			apiErr.Message = res.Error.Msg
+			apiErr.StatusCode = res.Status
+			apiErr.Method = r.wsMethod
+			apiErr.Endpoint = r.endpoint
+			apiErr.RateLimits = r.rateLimits
+			if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+				apiErr.RetryAfter = res.retryAfter()
+			}
+			return nil, apiErr
+		}
+		return []byte(res.Result), nil
+	}
+}
+
+// updateWsRateLimits update the rate limiter with the usage and the backoff
+// of a WebSocket API response
+func (c *Client) updateWsRateLimits(res *WsApiResponse) {
+	c.RateLimiter.Update(res.rateLimits()...)
+	if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+		c.RateLimiter.Backoff(res.retryAfter())
+	}
+}
+
+// rateLimits return the usage reported by the response
+func (res *WsApiResponse) rateLimits() []common.RateLimit {
+	limits := make([]common.RateLimit, 0, len(res.RateLimits))
+	for _, l := range res.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, l.IntervalNum),
+			Limit:    l.Limit,
+			Count:    l.Count,
+		})
+	}
+	return limits
+}
+
+// retryAfter return the backoff required by a 429 or 418 response
+func (res *WsApiResponse) retryAfter() time.Duration {
+	if res.Error.Data.RetryAfter > 0 {
+		return time.Until(time.UnixMilli(res.Error.Data.RetryAfter))
+	}
+	return common.DefaultRateLimitBackoff
+}
@@ -0,0 +1,396 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPIReply struct {
+	Id     string           `json:"id"`
+	Status int              `json:"status"`
+	Result json.RawMessage  `json:"result,omitempty"`
+	Error  *common.APIError `json:"error,omitempty"`
+}
+
+type wsAPIRequest struct {
+	Id     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// wsAPIServer is a fake WebSocket API server, the response of each request is
+// generated by handle, nil for no response at all.
+type wsAPIServer struct {
+	*httptest.Server
+	sync.Mutex
+	requests []*wsAPIRequest
+	conns    []*websocket.Conn
+	handle   func(req *wsAPIRequest) *wsAPIReply
+}
+
+func newWsAPIServer(handle func(req *wsAPIRequest) *wsAPIReply) *wsAPIServer {
+	s := &wsAPIServer{handle: handle}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.Lock()
+		s.conns = append(s.conns, conn)
+		s.Unlock()
+		defer conn.Close()
+		for {
+			req := new(wsAPIRequest)
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			s.Lock()
+			s.requests = append(s.requests, req)
+			handle := s.handle
+			s.Unlock()
+			if res := handle(req); res != nil {
+				res.Id = req.Id
+				data, _ := json.Marshal(res)
+				s.Lock()
+				err := conn.WriteMessage(websocket.TextMessage, data)
+				s.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}))
+	return s
+}
+
+func (s *wsAPIServer) setHandle(handle func(req *wsAPIRequest) *wsAPIReply) {
+	s.Lock()
+	defer s.Unlock()
+	s.handle = handle
+}
+
+// wsURL return the websocket url of the server
+func (s *wsAPIServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+func (s *wsAPIServer) lastRequest() *wsAPIRequest {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// push send the message to all the connections
+func (s *wsAPIServer) push(message string) {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+}
+
+// dropConnections close all the connections accepted so far
+func (s *wsAPIServer) dropConnections() {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func wsAPIResult(result string) *wsAPIReply {
+	return &wsAPIReply{Status: http.StatusOK, Result: json.RawMessage(result)}
+}
+
+func wsAPIError(status int, code int64, msg string) *wsAPIReply {
+	return &wsAPIReply{Status: status, Error: &common.APIError{Code: code, Message: msg}}
+}
+
+// newWsAPIClient create a client connected to the fake WebSocket API server
+func newWsAPIClient(s *wsAPIServer) *Client {
+	url := WsAPIMainURL
+	defer func() { WsAPIMainURL = url }()
+	WsAPIMainURL = s.wsURL()
+	return NewClient("dummyAPIKey", "dummySecretKey")
+}
+
+type wsClientTestSuite struct {
+	suite.Suite
+}
+
+func TestWsClient(t *testing.T) {
+	suite.Run(t, new(wsClientTestSuite))
+}
+
+func (s *wsClientTestSuite) TestPendingRequestFailedOnDisconnect() {
+	r := s.Require()
+	received := make(chan struct{})
+	var once sync.Once
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		once.Do(func() { close(received) })
+		return nil
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+
+	go func() {
+		<-received
+		server.dropConnections()
+	}()
+	start := time.Now()
+	_, err := client.callWsAPI(newContext(), &request{wsMethod: "time"})
+	r.Less(time.Since(start), 5*time.Second)
+	var closedErr *common.WsConnectionClosedError
+	r.True(errors.As(err, &closedErr))
+	r.Equal("time", closedErr.Method)
+	r.Error(closedErr.Err)
+}
+
+func (s *wsClientTestSuite) TestReconnect() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"apiKey": "dummyAPIKey"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	disconnected, reconnected, gaveUp := make(chan error, 1), make(chan int, 1), make(chan error, 1)
+	client.SetReconnectPolicy(&common.ReconnectPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxAttempts:    3,
+		OnDisconnect:   func(err error) { disconnected <- err },
+		OnReconnect:    func(attempts int) { reconnected <- attempts },
+		OnGiveUp:       func(err error) { gaveUp <- err },
+	})
+	_, err := client.NewSessionLogonService().Do(newContext())
+	r.NoError(err)
+
+	server.dropConnections()
+	r.Error(<-disconnected)
+	select {
+	case attempts := <-reconnected:
+		r.Equal(1, attempts)
+	case <-time.After(time.Second):
+		r.FailNow("OnReconnect not called")
+	}
+	r.True(client.WsConnected())
+	r.Equal(sessionLogonMethod, server.lastRequest().Method)
+
+	server.dropConnections()
+	server.Close()
+	select {
+	case err := <-gaveUp:
+		r.Error(err)
+	case <-time.After(time.Second):
+		r.FailNow("OnGiveUp not called")
+	}
+	r.False(client.WsConnected())
+}
+
+func (s *wsClientTestSuite) TestCloseStopsReconnecting() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+
+	disconnected, gaveUp := make(chan error, 1), make(chan error, 1)
+	client.SetReconnectPolicy(&common.ReconnectPolicy{
+		InitialBackoff: time.Hour,
+		OnDisconnect:   func(err error) { disconnected <- err },
+		OnGiveUp:       func(err error) { gaveUp <- err },
+	})
+	server.dropConnections()
+	r.Error(<-disconnected)
+	client.Close()
+	select {
+	case err := <-gaveUp:
+		r.FailNow("OnGiveUp called after Close", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	r.False(client.WsConnected())
+}
+
+func (s *wsClientTestSuite) TestCreateOrder() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"orderId": 328971409, "symbol": "BTCUSD_PERP", "status": "NEW", "clientOrderId": "myOrder", "price": "43187.0", "origQty": "1"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	res, err := client.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("43187.0").
+		NewClientOrderID("myOrder").Do(newContext())
+	r.NoError(err)
+	r.Equal(int64(328971409), res.OrderID)
+	r.Equal("myOrder", res.ClientOrderID)
+
+	req := server.lastRequest()
+	r.Equal("order.place", req.Method)
+	r.Equal("BTCUSD_PERP", req.Params["symbol"])
+	r.Equal("dummyAPIKey", req.Params[apiKey])
+	r.NotEmpty(req.Params[signatureKey])
+}
+
+func (s *wsClientTestSuite) TestAccountMethods() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		switch req.Method {
+		case "account.balance":
+			return wsAPIResult(`[{"accountAlias": "fsXqTiSg", "asset": "BTC", "balance": "0.00250000"}]`)
+		case "account.position":
+			return wsAPIResult(`[{"symbol": "BTCUSD_PERP", "positionAmt": "1", "positionSide": "BOTH"}]`)
+		case "account.status":
+			return wsAPIResult(`{"canTrade": true, "assets": [], "positions": []}`)
+		}
+		return wsAPIError(http.StatusBadRequest, -1100, "unknown method")
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	balances, err := client.NewGetBalanceService().Do(newContext())
+	r.NoError(err)
+	r.Len(balances, 1)
+	r.Equal("0.00250000", balances[0].Balance)
+	r.Equal("account.balance", server.lastRequest().Method)
+
+	positions, err := client.NewGetPositionRiskService().Do(newContext())
+	r.NoError(err)
+	r.Len(positions, 1)
+	r.Equal("BTCUSD_PERP", positions[0].Symbol)
+	r.Equal("account.position", server.lastRequest().Method)
+
+	account, err := client.NewGetAccountService().Do(newContext())
+	r.NoError(err)
+	r.True(account.CanTrade)
+	r.Equal("account.status", server.lastRequest().Method)
+}
+
+func (s *wsClientTestSuite) TestDecodeError() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"symbol": "BTCUSD_PERP"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	_, err := client.NewGetBalanceService().Do(newContext())
+	var decodeErr *common.DecodeError
+	r.ErrorAs(err, &decodeErr)
+	r.Equal("account.balance", decodeErr.Method)
+	r.Equal("/dapi/v1/balance", decodeErr.Endpoint)
+	r.Equal(http.StatusOK, decodeErr.StatusCode)
+}
+
+func (s *wsClientTestSuite) TestUserDataStream() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"listenKey": "xs0mRXdAKlIPDRFrlPcw0qI41Eh3ixNntmymGyhrhgqo7L6FuLaWArTD7RLP"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	listenKey, err := client.NewStartUserStreamService().Do(newContext())
+	r.NoError(err)
+	r.Equal("xs0mRXdAKlIPDRFrlPcw0qI41Eh3ixNntmymGyhrhgqo7L6FuLaWArTD7RLP", listenKey)
+	req := server.lastRequest()
+	r.Equal("userDataStream.start", req.Method)
+	r.Equal("dummyAPIKey", req.Params[apiKey])
+	r.NotContains(req.Params, signatureKey)
+
+	r.NoError(client.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(newContext()))
+	r.Equal("userDataStream.ping", server.lastRequest().Method)
+	r.NoError(client.NewCloseUserStreamService().ListenKey(listenKey).Do(newContext()))
+	r.Equal("userDataStream.stop", server.lastRequest().Method)
+}
+
+func (s *wsClientTestSuite) TestManagedUserDataStream() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"listenKey": "dummyListenKey"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	origWsServe := wsServe
+	defer func() { wsServe = origWsServe }()
+	endpointC := make(chan string, 1)
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpointC <- cfg.Endpoint
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			handler([]byte(`{"e": "ACCOUNT_UPDATE", "E": 1564745798939}`))
+			<-stopC
+			close(doneC)
+		}()
+		return doneC, stopC, nil
+	}
+
+	eventC := make(chan *WsUserDataEvent, 1)
+	stream := client.NewUserDataStream(func(event *WsUserDataEvent) {
+		eventC <- event
+	}, func(err error) {})
+	r.NoError(stream.Start(newContext()))
+	// the listen key is started over the WebSocket API and the events are
+	// received from the user data stream
+	r.Equal("userDataStream.start", server.lastRequest().Method)
+	r.Equal(client.wsEndpoint()+"/dummyListenKey", <-endpointC)
+	select {
+	case event := <-eventC:
+		r.Equal(UserDataEventTypeAccountUpdate, event.Event)
+	case <-time.After(time.Second):
+		r.Fail("timeout waiting for event")
+	}
+
+	stream.Stop()
+	<-stream.Done()
+	r.Equal("userDataStream.stop", server.lastRequest().Method)
+}
+
+func (s *wsClientTestSuite) TestFallbackToREST() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`[]`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	client.Close()
+	r.False(client.WsConnected())
+
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.Equal("/dapi/v1/balance", req.URL.Path)
+		_, _ = w.Write([]byte(`[{"asset": "BTC", "balance": "1.0"}]`))
+	}))
+	defer rest.Close()
+	client.BaseURL = rest.URL
+
+	balances, err := client.NewGetBalanceService().Do(newContext())
+	r.NoError(err)
+	r.Len(balances, 1)
+	r.Equal("1.0", balances[0].Balance)
+	r.Empty(server.lastRequest())
+}
@@ -96,7 +96,7 @@ func (s *ListDepositsService) Do(ctx context.Context) (res []*Deposit, err error
 		return
 	}
 	res = make([]*Deposit, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -157,7 +157,7 @@ func (s *GetDepositsAddressService) Do(ctx context.Context) (*GetDepositAddressR
 	}
 
 	res := &GetDepositAddressResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -41,7 +41,7 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	if err != nil {
 		return nil, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (s *ListDustLogService) Do(ctx context.Context) (withdraws *DustResult, err
 		return
 	}
 	res := new(DustResult)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -114,7 +114,7 @@ func (s *DustTransferService) Do(ctx context.Context) (withdraws *DustTransferRe
 		return
 	}
 	res := new(DustTransferResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -156,7 +156,7 @@ func (s *ListDustService) Do(ctx context.Context) (res *ListDustResponse, err er
 		return
 	}
 	res = new(ListDustResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -59,7 +59,7 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(ExchangeInfo)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +119,14 @@ type PriceFilter struct {
 	TickSize string `json:"tickSize"`
 }
 
+// PercentPriceFilter define the legacy percent price filter of symbol, the
+// same for both sides
+type PercentPriceFilter struct {
+	AveragePriceMins int    `json:"avgPriceMins"`
+	MultiplierUp     string `json:"multiplierUp"`
+	MultiplierDown   string `json:"multiplierDown"`
+}
+
 // PERCENT_PRICE_BY_SIDE define percent price filter of symbol by side
 type PercentPriceBySideFilter struct {
 	AveragePriceMins  int    `json:"avgPriceMins"`
@@ -210,6 +218,28 @@ func (s *Symbol) PriceFilter() *PriceFilter {
 	return nil
 }
 
+// PercentPriceFilter return the legacy percent price filter of symbol
+func (s *Symbol) PercentPriceFilter() *PercentPriceFilter {
+	for _, filter := range s.Filters {
+		if filter["filterType"].(string) == string(SymbolFilterTypePercentPrice) {
+			f := &PercentPriceFilter{}
+			if i, ok := filter["avgPriceMins"]; ok {
+				if apm, okk := common.ToInt(i); okk == nil {
+					f.AveragePriceMins = apm
+				}
+			}
+			if i, ok := filter["multiplierUp"]; ok {
+				f.MultiplierUp = i.(string)
+			}
+			if i, ok := filter["multiplierDown"]; ok {
+				f.MultiplierDown = i.(string)
+			}
+			return f
+		}
+	}
+	return nil
+}
+
 // PercentPriceBySideFilter return percent price filter of symbol
 func (s *Symbol) PercentPriceBySideFilter() *PercentPriceBySideFilter {
 	for _, filter := range s.Filters {
@@ -364,3 +394,72 @@ func (s *Symbol) TrailingDeltaFilter() *TrailingDeltaFilter {
 	}
 	return nil
 }
+
+// OrderFilters return the filters of symbol checked by common.OrderValidator
+func (s *Symbol) OrderFilters() *common.SymbolFilters {
+	f := &common.SymbolFilters{Symbol: s.Symbol}
+	if pf := s.PriceFilter(); pf != nil {
+		f.MinPrice = common.DecimalFromString(pf.MinPrice)
+		f.MaxPrice = common.DecimalFromString(pf.MaxPrice)
+		f.TickSize = common.DecimalFromString(pf.TickSize)
+	}
+	if lf := s.LotSizeFilter(); lf != nil {
+		f.MinQuantity = common.DecimalFromString(lf.MinQuantity)
+		f.MaxQuantity = common.DecimalFromString(lf.MaxQuantity)
+		f.StepSize = common.DecimalFromString(lf.StepSize)
+	}
+	if mf := s.MarketLotSizeFilter(); mf != nil {
+		f.MarketMinQuantity = common.DecimalFromString(mf.MinQuantity)
+		f.MarketMaxQuantity = common.DecimalFromString(mf.MaxQuantity)
+		f.MarketStepSize = common.DecimalFromString(mf.StepSize)
+	}
+	if nf := s.NotionalFilter(); nf != nil {
+		f.MinNotional = common.DecimalFromString(nf.MinNotional)
+		f.MaxNotional = common.DecimalFromString(nf.MaxNotional)
+		f.ApplyMinToMarket = nf.ApplyMinToMarket
+		f.ApplyMaxToMarket = nf.ApplyMaxToMarket
+	}
+	if pf := s.PercentPriceBySideFilter(); pf != nil {
+		f.BidMultiplierUp = common.DecimalFromString(pf.BidMultiplierUp)
+		f.BidMultiplierDown = common.DecimalFromString(pf.BidMultiplierDown)
+		f.AskMultiplierUp = common.DecimalFromString(pf.AskMultiplierUp)
+		f.AskMultiplierDown = common.DecimalFromString(pf.AskMultiplierDown)
+	} else if pf := s.PercentPriceFilter(); pf != nil {
+		f.BidMultiplierUp = common.DecimalFromString(pf.MultiplierUp)
+		f.BidMultiplierDown = common.DecimalFromString(pf.MultiplierDown)
+		f.AskMultiplierUp = f.BidMultiplierUp
+		f.AskMultiplierDown = f.BidMultiplierDown
+	}
+	if mf := s.MaxNumOrdersFilter(); mf != nil {
+		f.MaxNumOrders = mf.MaxNumOrders
+	}
+	if mf := s.MaxNumAlgoOrdersFilter(); mf != nil {
+		f.MaxNumAlgoOrders = mf.MaxNumAlgoOrders
+	}
+	return f
+}
+
+// NewOrderValidator create an order validator with the filters of the symbols
+// of the exchange info, set it as Client.OrderValidator to check the orders
+// before they are sent
+func NewOrderValidator(info *ExchangeInfo) *common.OrderValidator {
+	v := common.NewOrderValidator()
+	for i := range info.Symbols {
+		v.SetFilters(info.Symbols[i].OrderFilters())
+	}
+	return v
+}
+
+// NewRateLimitGovernor create a rate limit governor with the limits of the
+// exchange info, set it as Client.RateLimiter to keep the calls under them
+func NewRateLimitGovernor(info *ExchangeInfo) *common.RateLimitGovernor {
+	limits := make([]common.RateLimit, 0, len(info.RateLimits))
+	for _, l := range info.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, int(l.IntervalNum)),
+			Limit:    int(l.Limit),
+		})
+	}
+	return common.NewRateLimitGovernor(limits...)
+}
@@ -218,6 +218,26 @@ func (s *exchangeInfoServiceTestSuite) TestExchangeInfo() {
 	s.assertMaxNumAlgoOrdersFilterEqual(eMaxNumAlgoOrdersFilter, res.Symbols[0].MaxNumAlgoOrdersFilter())
 }
 
+func (s *exchangeInfoServiceTestSuite) TestSymbolOrderFilters() {
+	symbol := &Symbol{
+		Symbol: "BTCUSDT",
+		Filters: []map[string]interface{}{
+			{"filterType": "PERCENT_PRICE", "multiplierUp": "5", "multiplierDown": "0.2", "avgPriceMins": float64(5)},
+			{"filterType": "MAX_NUM_ORDERS", "maxNumOrders": float64(200)},
+			{"filterType": "MAX_NUM_ALGO_ORDERS", "maxNumAlgoOrders": float64(5)},
+		},
+	}
+	s.r().Equal(&PercentPriceFilter{AveragePriceMins: 5, MultiplierUp: "5", MultiplierDown: "0.2"}, symbol.PercentPriceFilter())
+
+	f := symbol.OrderFilters()
+	s.r().Equal("5", f.BidMultiplierUp.String())
+	s.r().Equal("0.2", f.BidMultiplierDown.String())
+	s.r().Equal("5", f.AskMultiplierUp.String())
+	s.r().Equal("0.2", f.AskMultiplierDown.String())
+	s.r().Equal(200, f.MaxNumOrders)
+	s.r().Equal(5, f.MaxNumAlgoOrders)
+}
+
 func (s *exchangeInfoServiceTestSuite) assertExchangeInfoEqual(e, a *ExchangeInfo) {
 	r := s.r()
 
@@ -70,7 +70,7 @@ func (s *FiatDepositWithdrawHistoryService) Do(ctx context.Context, opts ...Requ
 		return nil, err
 	}
 	res := FiatDepositWithdrawHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -163,7 +163,7 @@ func (s *FiatPaymentsHistoryService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res := FiatPaymentsHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
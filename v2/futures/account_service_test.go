@@ -137,16 +137,10 @@ func (s *accountServiceTestSuite) TestGetAccount() {
 				CrossWalletBalance:     "23.72469206",
 				CrossUnPnl:             "0.00000000",
 				AvailableBalance:       "126.72469206",
-				MarginAvailable:        true,
 				UpdateTime:             1625474304765,
 			},
 		},
-		CanTrade:          true,
-		CanWithdraw:       true,
-		CanDeposit:        true,
-		FeeTier:           2,
 		MaxWithdrawAmount: "8.41264592",
-		MultiAssetsMargin: false,
 		Positions: []*AccountPosition{
 			{
 				Isolated:               false,
@@ -173,17 +167,12 @@ func (s *accountServiceTestSuite) TestGetAccount() {
 		TotalPositionInitialMargin:  "0.33683000",
 		TotalUnrealizedProfit:       "-0.44537584",
 		TotalWalletBalance:          "9.19485176",
-		UpdateTime:                  0,
 	}
 	s.assertAccountEqual(e, res)
 }
 
 func (s *accountServiceTestSuite) assertAccountEqual(e, a *Account) {
 	r := s.r()
-	r.Equal(e.CanDeposit, a.CanDeposit, "CanDeposit")
-	r.Equal(e.CanTrade, a.CanTrade, "CanTrade")
-	r.Equal(e.CanWithdraw, a.CanWithdraw, "CanWithdraw")
-	r.Equal(e.FeeTier, a.FeeTier, "FeeTier")
 	r.Equal(e.MaxWithdrawAmount, a.MaxWithdrawAmount, "MaxWithdrawAmount")
 	r.Equal(e.TotalInitialMargin, a.TotalInitialMargin, "TotalInitialMargin")
 	r.Equal(e.TotalMaintMargin, a.TotalMaintMargin, "TotalMaintMargin")
@@ -192,8 +181,6 @@ func (s *accountServiceTestSuite) assertAccountEqual(e, a *Account) {
 	r.Equal(e.TotalPositionInitialMargin, a.TotalPositionInitialMargin, "TotalPositionInitialMargin")
 	r.Equal(e.TotalUnrealizedProfit, a.TotalUnrealizedProfit, "TotalUnrealizedProfit")
 	r.Equal(e.TotalWalletBalance, a.TotalWalletBalance, "TotalWalletBalance")
-	r.Equal(e.UpdateTime, a.UpdateTime, "UpdateTime")
-	r.Equal(e.MultiAssetsMargin, a.MultiAssetsMargin, "MultiAssetsMargin")
 
 	r.Len(a.Assets, len(e.Assets))
 	for i := 0; i < len(a.Assets); i++ {
@@ -209,7 +196,6 @@ func (s *accountServiceTestSuite) assertAccountEqual(e, a *Account) {
 		r.Equal(e.Assets[i].CrossWalletBalance, a.Assets[i].CrossWalletBalance, "CrossWalletBalance")
 		r.Equal(e.Assets[i].CrossUnPnl, a.Assets[i].CrossUnPnl, "CrossUnPnl")
 		r.Equal(e.Assets[i].AvailableBalance, a.Assets[i].AvailableBalance, "AvailableBalance")
-		r.Equal(e.Assets[i].MarginAvailable, a.Assets[i].MarginAvailable, "MarginAvailable")
 		r.Equal(e.Assets[i].UpdateTime, a.Assets[i].UpdateTime, "UpdateTime")
 	}
 
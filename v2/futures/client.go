@@ -3,9 +3,8 @@ package futures
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -30,6 +29,16 @@ type PositionSideType string
 // OrderType define order type
 type OrderType string
 
+// isAlgo return true for the conditional orders, counted by the
+// MAX_NUM_ALGO_ORDERS filter
+func (t OrderType) isAlgo() bool {
+	switch t {
+	case OrderTypeStop, OrderTypeStopMarket, OrderTypeTakeProfit, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+		return true
+	}
+	return false
+}
+
 // TimeInForceType define time in force type of order
 type TimeInForceType string
 
@@ -72,10 +81,13 @@ type UserDataEventReasonType string
 // ForceOrderCloseType define reason type for force order
 type ForceOrderCloseType string
 
+// PriceMatchType define price match type of order
+type PriceMatchType string
+
 // Endpoints
-const (
-	baseApiMainUrl    = "https://fapi.binance.com"
-	baseApiTestnetUrl = "https://testnet.binancefuture.com"
+var (
+	BaseAPIMainURL    = "https://fapi.binance.com"
+	BaseAPITestnetURL = "https://testnet.binancefuture.com"
 )
 
 // Global enums
@@ -175,6 +187,16 @@ const (
 	ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
 	ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
 
+	PriceMatchTypeNone       PriceMatchType = "NONE"
+	PriceMatchTypeOpponent   PriceMatchType = "OPPONENT"
+	PriceMatchTypeOpponent5  PriceMatchType = "OPPONENT_5"
+	PriceMatchTypeOpponent10 PriceMatchType = "OPPONENT_10"
+	PriceMatchTypeOpponent20 PriceMatchType = "OPPONENT_20"
+	PriceMatchTypeQueue      PriceMatchType = "QUEUE"
+	PriceMatchTypeQueue5     PriceMatchType = "QUEUE_5"
+	PriceMatchTypeQueue10    PriceMatchType = "QUEUE_10"
+	PriceMatchTypeQueue20    PriceMatchType = "QUEUE_20"
+
 	apiKey        = "apiKey"
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
@@ -233,37 +255,34 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 	return j, nil
 }
 
-// getApiEndpoint return the base endpoint of the WS according the UseTestnet flag
-func getApiEndpoint() string {
-	if UseTestnet {
-		return baseApiTestnetUrl
-	}
-	return baseApiMainUrl
-}
-
 // NewClient initialize an API client instance with API key and secret key.
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewClient(apiKey, secretKey string) *Client {
-	wsState := WsInit
-	c := makeConn()
-	if c != nil {
-		wsState = WsConnected
-	}
+	return NewClientWithEnvironment(apiKey, secretKey, DefaultEnvironment())
+}
+
+// NewClientWithEnvironment initialize an API client instance with the
+// endpoints of the environment instead of the ones of the UseTestnet flag
+func NewClientWithEnvironment(apiKey, secretKey string, env Environment) *Client {
 	client := &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getApiEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
-		WsURL:      getWsAPIEndpoint(),
-		WsConn:     c,
-		wsState:    wsState,
-	}
-	if c != nil {
-		client.handleDisconnected(c.Done)
+		APIKey:                  apiKey,
+		SecretKey:               secretKey,
+		BaseURL:                 env.BaseURL,
+		UserAgent:               "Binance/golang",
+		HTTPClient:              http.DefaultClient,
+		Logger:                  log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		WsURL:                   env.WsAPIURL,
+		WsStreamURL:             env.WsStreamURL,
+		WsMarketStreamURL:       env.WsMarketStreamURL,
+		WsPrivateStreamURL:      env.WsPrivateStreamURL,
+		CombinedStreamURL:       env.CombinedStreamURL,
+		CombinedMarketStreamURL: env.CombinedMarketStreamURL,
+		wsState:                 WsInit,
+		wsStopC:                 make(chan struct{}),
 	}
+	// the errors of the connection are logged by the logger of the client
+	client.Connect()
 
 	return client
 }
@@ -275,27 +294,105 @@ type Client struct {
 	sync.Mutex
 	APIKey     string
 	SecretKey  string
+	Signer     common.Signer
 	BaseURL    string
 	UserAgent  string
 	HTTPClient *http.Client
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	timeLock   sync.RWMutex
 	do         doFunc
 	WsURL      string
 	WsConn     *WsConnection
-	StopC      chan struct{}
-	wsState    WsClientState // init/connecting/connected
+	// WsStreamURL, WsMarketStreamURL, WsPrivateStreamURL, CombinedStreamURL
+	// and CombinedMarketStreamURL are the base endpoints of the websocket
+	// streams of the client, the ones of the UseTestnet flag if they are
+	// empty
+	WsStreamURL             string
+	WsMarketStreamURL       string
+	WsPrivateStreamURL      string
+	CombinedStreamURL       string
+	CombinedMarketStreamURL string
+	StopC                   chan struct{}
+	wsLock                  sync.RWMutex
+	wsState                 WsClientState // init/connecting/connected
+	wsSession               bool          // logon the session again after reconnecting
+	wsStopC                 chan struct{} // closed by Close to stop reconnecting
+
+	reconnectPolicy *common.ReconnectPolicy
+
+	// OrderValidator checks the orders against the symbol filters before they
+	// are sent, nil to let the server check them
+	OrderValidator *common.OrderValidator
+	// RateLimiter keeps the REST and WebSocket API calls under the rate
+	// limits, nil to let the server enforce them
+	RateLimiter *common.RateLimitGovernor
+	// RetryPolicy sends the failed requests again when it is safe, nil to
+	// send them once
+	RetryPolicy *common.RetryPolicy
+	// Interceptors wrap every REST and WebSocket API call, the first one is
+	// the outermost
+	Interceptors []common.Interceptor
+	// Instrumentation receives the spans of the REST and WebSocket API
+	// calls, nil to ignore them
+	Instrumentation common.Instrumentation
+	// StructuredLogger receives the leveled logs of the client, with the
+	// secrets redacted. Logger is used if it is nil, from the debug level if
+	// Debug is set.
+	StructuredLogger common.Logger
+	// TimeSync, if set and synced, gives the time offset of the signed
+	// requests instead of TimeOffset. It is synced again when a request is
+	// rejected for its timestamp, and the request is sent once again.
+	TimeSync *common.TimeSync
+	// ResilientStreams, if set, reconnects the market data streams of the
+	// client when they drop and signals the gaps, nil to end them on the
+	// first error
+	ResilientStreams *common.ResilientStreams
 }
 
 func (c *Client) WsConnected() bool {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
 	return c.wsState == WsConnected
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
+// timeOffset return the offset of the local time to the server time in
+// milliseconds
+func (c *Client) timeOffset() int64 {
+	if c.TimeSync != nil && c.TimeSync.Synced() {
+		return c.TimeSync.Offset().Milliseconds()
+	}
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.TimeOffset
+}
+
+// setTimeOffset set TimeOffset, which the requests may be reading
+func (c *Client) setTimeOffset(offset int64) {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+	c.TimeOffset = offset
+}
+
+// logger return the logger of the client, which redacts the secrets
+func (c *Client) logger() common.Logger {
+	if c.StructuredLogger != nil {
+		return common.NewRedactingLogger(c.StructuredLogger)
+	}
+	level := common.LogLevelInfo
 	if c.Debug {
-		c.Logger.Printf(format, v...)
+		level = common.LogLevelDebug
+	}
+	return common.NewRedactingLogger(common.NewStdLogger(c.Logger, level))
+}
+
+// signer return the signer of SIGNED requests, HMAC with SecretKey by default
+func (c *Client) signer() common.Signer {
+	if c.Signer != nil {
+		return c.Signer
 	}
+	return common.NewHMACSigner(c.SecretKey)
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
@@ -313,7 +410,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		r.setParam(recvWindowKey, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+		r.setParam(timestampKey, currentTimestamp()-c.timeOffset())
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -332,13 +429,12 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, sig)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -348,7 +444,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.logger().Debug("request url", "url", fullURL, "body", bodyString)
 
 	r.fullURL = fullURL
 	r.header = header
@@ -356,14 +452,135 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	return nil
 }
 
-func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
+// callAPI send the request, and send it once again after syncing TimeSync
+// if its timestamp is outside of the recvWindow
+func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	if c.TimeSync == nil || r.secType != secTypeSigned {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	rc := r.clone()
+	data, limits, err = c.retryAPI(ctx, rc, opts...)
+	r.ws, r.statusCode = rc.ws, rc.statusCode
+	if errors.Is(err, common.ErrTimestampOutsideRecvWindow) && c.TimeSync.Resync(ctx) == nil {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	return data, limits, err
+}
+
+// retryAPI send the request, and send it again according to RetryPolicy
+func (c *Client) retryAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	if c.RetryPolicy == nil {
+		return c.callAPIOnce(ctx, r, opts...)
+	}
+	err = c.RetryPolicy.Do(ctx, &common.RetryRequest{
+		Idempotent:    r.idempotent(),
+		PlacesOrder:   r.orderCount() > 0,
+		ClientOrderID: r.param("newClientOrderId"),
+		Send: func() (err error) {
+			rc := r.clone()
+			data, limits, err = c.callAPIOnce(ctx, rc, opts...)
+			r.ws, r.statusCode = rc.ws, rc.statusCode
+			return err
+		},
+		LookupOrder: c.lookupOrder(ctx, r, &data),
+	})
+	return data, limits, err
+}
+
+// lookupOrder return a function which finds the order placed by the request
+// by its client order id, nil if the order cannot be queried
+func (c *Client) lookupOrder(ctx context.Context, r *request, data *[]byte) func() (bool, error) {
+	if r.endpoint != "/fapi/v1/order" {
+		return nil
+	}
+	return func() (bool, error) {
+		order, err := c.NewGetOrderService().Symbol(r.param("symbol")).OrigClientOrderID(r.param("newClientOrderId")).Do(ctx)
+		if errors.Is(err, common.ErrUnknownOrder) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		*data, err = json.Marshal(order)
+		return err == nil, err
+	}
+}
+
+// callAPIOnce send the request without retrying it
+func (c *Client) callAPIOnce(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
 	var err error
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Acquire(ctx, r.method, r.endpoint, r.weight(), r.orderCount()); err != nil {
+			return nil, nil, err
+		}
+	}
 	// prefer to WS API
-	if c.WsConnected() && r.wsMethod != "" {
-		return c.callWsAPI(ctx, r, opts...)
+	ws := c.WsConnected() && r.wsMethod != ""
+	return c.invoke(ctx, r, ws, opts...)
+}
+
+// invoke send the request through the instrumentation and the Interceptors,
+// over the WebSocket API if ws is true
+func (c *Client) invoke(ctx context.Context, r *request, ws bool, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
+	ctx, span := c.startSpan(ctx, r, ws)
+	if len(c.Interceptors) > 0 {
+		data, limits, err = c.intercept(ctx, r, ws, opts...)
+	} else {
+		data, limits, err = c.sendAPI(ctx, r, ws, opts...)
 	}
+	span.Finish(common.NewSpanEnd(r.statusCode, r.rateLimits, err))
+	return data, limits, err
+}
+
+func (c *Client) instrumentation() common.Instrumentation {
+	return common.InstrumentationOrNoop(c.Instrumentation)
+}
+
+// startSpan start the span of the request, sent over the WebSocket API if
+// ws is true
+func (c *Client) startSpan(ctx context.Context, r *request, ws bool) (context.Context, common.Span) {
+	start := &common.SpanStart{BaseURL: c.BaseURL, Method: r.method, Endpoint: r.endpoint, WebSocket: ws, Time: time.Now()}
+	if ws {
+		start.BaseURL = c.WsURL
+		start.WsMethod = r.wsMethod
+	}
+	return c.instrumentation().StartSpan(ctx, start)
+}
+
+// intercept send the request through the Interceptors
+func (c *Client) intercept(ctx context.Context, r *request, ws bool, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	// the interceptors see the request options
+	for _, opt := range opts {
+		opt(r)
+	}
+	var limits *RateLimits
+	invoker := common.ChainInterceptors(c.Interceptors, func(ctx context.Context, req *common.APIRequest) (*common.APIResponse, error) {
+		r.setAPIRequest(req)
+		data, l, err := c.sendAPI(ctx, r, req.WebSocket)
+		if err != nil {
+			return nil, err
+		}
+		limits = l
+		return &common.APIResponse{Data: data, StatusCode: r.statusCode, RateLimits: r.rateLimits}, nil
+	})
+	res, err := invoker(ctx, r.apiRequest(ws))
+	if err != nil {
+		return nil, nil, err
+	}
+	if res == nil {
+		return nil, limits, nil
+	}
+	r.statusCode, r.rateLimits = res.StatusCode, res.RateLimits
+	return res.Data, limits, nil
+}
 
-	err = c.parseRequest(r, opts...)
+// sendAPI send the request over the WebSocket API, or the REST API
+func (c *Client) sendAPI(ctx context.Context, r *request, ws bool, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	r.ws = ws
+	if ws {
+		return c.callWsAPI(ctx, r, opts...)
+	}
+	err := c.parseRequest(r, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -373,18 +590,18 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.logger().Debug("request", "method", req.Method, "url", req.URL.String(), "header", req.Header)
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
 	res, err := f(req)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	defer func() {
 		cerr := res.Body.Close()
@@ -394,17 +611,30 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 			err = cerr
 		}
 	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
+	c.logger().Debug("response", "status", res.StatusCode, "header", res.Header, "body", string(data))
+
+	r.statusCode = res.StatusCode
+	r.rateLimits = common.RateLimitsFromHeader(res.Header)
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(r.rateLimits...)
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			c.RateLimiter.Backoff(common.RetryAfterFromHeader(res.Header))
+		}
+	}
 	if res.StatusCode >= http.StatusBadRequest {
 		apiErr := new(common.APIError)
 		e := json.Unmarshal(data, apiErr)
 		if e != nil {
 			apiErr.Code = int64(res.StatusCode)
 			apiErr.Message = string(data)
-			c.debug("failed to unmarshal json: %s", e)
+			c.logger().Debug("failed to unmarshal json", "error", e)
+		}
+		apiErr.StatusCode = res.StatusCode
+		apiErr.Method = r.method
+		apiErr.Endpoint = r.endpoint
+		apiErr.RateLimits = r.rateLimits
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			apiErr.RetryAfter = common.RetryAfterFromHeader(res.Header)
 		}
 		return nil, nil, apiErr
 	}
@@ -432,6 +662,21 @@ func (c *Client) NewSetServerTimeService() *SetServerTimeService {
 	return &SetServerTimeService{c: c}
 }
 
+// NewSessionLogonService init session logon service of WebSocket API
+func (c *Client) NewSessionLogonService() *SessionLogonService {
+	return &SessionLogonService{c: c}
+}
+
+// NewSessionStatusService init session status service of WebSocket API
+func (c *Client) NewSessionStatusService() *SessionStatusService {
+	return &SessionStatusService{c: c}
+}
+
+// NewSessionLogoutService init session logout service of WebSocket API
+func (c *Client) NewSessionLogoutService() *SessionLogoutService {
+	return &SessionLogoutService{c: c}
+}
+
 // NewDepthService init depth service
 func (c *Client) NewDepthService() *DepthService {
 	return &DepthService{c: c}
@@ -492,6 +737,21 @@ func (c *Client) NewCreateBatchOrdersService() *CreateBatchOrdersService {
 	return &CreateBatchOrdersService{c: c}
 }
 
+// NewModifyOrderService init modify order service
+func (c *Client) NewModifyOrderService() *ModifyOrderService {
+	return &ModifyOrderService{c: c}
+}
+
+// NewModifyBatchOrdersService init modify batch orders service
+func (c *Client) NewModifyBatchOrdersService() *ModifyBatchOrdersService {
+	return &ModifyBatchOrdersService{c: c}
+}
+
+// NewListOrderAmendmentsService init list order amendments service
+func (c *Client) NewListOrderAmendmentsService() *ListOrderAmendmentsService {
+	return &ListOrderAmendmentsService{c: c}
+}
+
 // NewGetOrderService init get order service
 func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
@@ -512,6 +772,11 @@ func (c *Client) NewCancelMultipleOrdersService() *CancelMultiplesOrdersService
 	return &CancelMultiplesOrdersService{c: c}
 }
 
+// NewCountdownCancelAllService init countdown cancel all open orders service
+func (c *Client) NewCountdownCancelAllService() *CountdownCancelAllService {
+	return &CountdownCancelAllService{c: c}
+}
+
 // NewGetOpenOrderService init get open order service
 func (c *Client) NewGetOpenOrderService() *GetOpenOrderService {
 	return &GetOpenOrderService{c: c}
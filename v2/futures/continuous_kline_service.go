@@ -75,7 +75,7 @@ func (s *ContinuousKlinesService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return []*ContinuousKline{}, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return []*ContinuousKline{}, err
 	}
@@ -0,0 +1,115 @@
+package futures
+
+// Environment define the endpoints of a client, e.g. of the production, the
+// testnet or a regional host
+type Environment struct {
+	// BaseURL of the REST API
+	BaseURL string
+	// WsStreamURL of the public websocket streams, e.g. the depth
+	WsStreamURL string
+	// WsMarketStreamURL of the market websocket streams, e.g. the klines
+	WsMarketStreamURL string
+	// WsPrivateStreamURL of the user data streams
+	WsPrivateStreamURL string
+	// CombinedStreamURL of the public combined streams, ending with
+	// ?streams=
+	CombinedStreamURL string
+	// CombinedMarketStreamURL of the market combined streams, ending with
+	// ?streams=
+	CombinedMarketStreamURL string
+	// WsAPIURL of the WebSocket API, the client does not connect to it if
+	// it is empty
+	WsAPIURL string
+}
+
+// MainEnvironment return the endpoints of the production
+func MainEnvironment() Environment {
+	return Environment{
+		BaseURL:                 BaseAPIMainURL,
+		WsStreamURL:             baseWsMainPublicUrl,
+		WsMarketStreamURL:       baseWsMainMarketeUrl,
+		WsPrivateStreamURL:      baseWsMainMarketeUrl,
+		CombinedStreamURL:       baseCombinedMainPublicURL,
+		CombinedMarketStreamURL: baseCombinedMainMarketURL,
+		WsAPIURL:                WsAPIMainURL,
+	}
+}
+
+// TestnetEnvironment return the endpoints of the testnet
+func TestnetEnvironment() Environment {
+	return Environment{
+		BaseURL:                 BaseAPITestnetURL,
+		WsStreamURL:             baseWsTestnetUrl,
+		WsMarketStreamURL:       baseWsTestnetUrl,
+		WsPrivateStreamURL:      baseWsTestnetUrl,
+		CombinedStreamURL:       baseCombinedTestnetURL,
+		CombinedMarketStreamURL: baseCombinedTestnetURL,
+		WsAPIURL:                WsAPITestnetURL,
+	}
+}
+
+// DefaultEnvironment return the endpoints according the UseTestnet flag
+func DefaultEnvironment() Environment {
+	if UseTestnet {
+		return TestnetEnvironment()
+	}
+	return MainEnvironment()
+}
+
+// Environment return the endpoints of the client
+func (c *Client) Environment() Environment {
+	return Environment{
+		BaseURL:                 c.BaseURL,
+		WsStreamURL:             c.wsEndpoint(),
+		WsMarketStreamURL:       c.wsMarketEndpoint(),
+		WsPrivateStreamURL:      c.wsPrivateEndpoint(),
+		CombinedStreamURL:       c.combinedEndpoint(),
+		CombinedMarketStreamURL: c.combinedMarketEndpoint(),
+		WsAPIURL:                c.WsURL,
+	}
+}
+
+// wsEndpoint return the base endpoint of the public websocket streams of
+// the client, according the UseTestnet flag if it has none
+func (c *Client) wsEndpoint() string {
+	if c.WsStreamURL != "" {
+		return c.WsStreamURL
+	}
+	return getWsEndpoint()
+}
+
+// wsMarketEndpoint return the base endpoint of the market websocket streams
+// of the client, according the UseTestnet flag if it has none
+func (c *Client) wsMarketEndpoint() string {
+	if c.WsMarketStreamURL != "" {
+		return c.WsMarketStreamURL
+	}
+	return getWsMarketEndpoint()
+}
+
+// wsPrivateEndpoint return the base endpoint of the user data streams of
+// the client, according the UseTestnet flag if it has none
+func (c *Client) wsPrivateEndpoint() string {
+	if c.WsPrivateStreamURL != "" {
+		return c.WsPrivateStreamURL
+	}
+	return getWsPrivateEndpoint()
+}
+
+// combinedEndpoint return the base endpoint of the public combined streams
+// of the client, according the UseTestnet flag if it has none
+func (c *Client) combinedEndpoint() string {
+	if c.CombinedStreamURL != "" {
+		return c.CombinedStreamURL
+	}
+	return getCombinedEndpoint()
+}
+
+// combinedMarketEndpoint return the base endpoint of the market combined
+// streams of the client, according the UseTestnet flag if it has none
+func (c *Client) combinedMarketEndpoint() string {
+	if c.CombinedMarketStreamURL != "" {
+		return c.CombinedMarketStreamURL
+	}
+	return getCombinedMarketEndpoint()
+}
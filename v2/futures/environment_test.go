@@ -0,0 +1,51 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithEnvironment(t *testing.T) {
+	env := Environment{
+		BaseURL:                 "https://fapi.example.com",
+		WsStreamURL:             "wss://fstream.example.com/public/ws",
+		WsMarketStreamURL:       "wss://fstream.example.com/market/ws",
+		WsPrivateStreamURL:      "wss://fstream.example.com/private/ws",
+		CombinedStreamURL:       "wss://fstream.example.com/public/stream?streams=",
+		CombinedMarketStreamURL: "wss://fstream.example.com/market/stream?streams=",
+	}
+	c := NewClientWithEnvironment("apiKey", "secretKey", env)
+	assert.Equal(t, env, c.Environment())
+	assert.False(t, c.WsConnected())
+
+	var endpoints []string
+	origWsServe := wsServe
+	defer func() { wsServe = origWsServe }()
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+
+	_, _, err := c.WsAggTradeServe("BTCUSDT", func(event *WsAggTradeEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = c.WsBookTickerServe("BTCUSDT", func(event *WsBookTickerEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = c.WsDiffDepthServe("BTCUSDT", func(event *WsDepthEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = c.WsUserDataServe("listenKey", func(event *WsUserDataEvent) {}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"wss://fstream.example.com/market/ws/btcusdt@aggTrade",
+		"wss://fstream.example.com/public/ws/btcusdt@bookTicker",
+		"wss://fstream.example.com/public/ws/btcusdt@depth",
+		"wss://fstream.example.com/private/ws/listenKey",
+	}, endpoints)
+}
+
+func TestClientEnvironmentDefault(t *testing.T) {
+	UseTestnet = true
+	defer func() { UseTestnet = false }()
+	c := &Client{BaseURL: BaseAPITestnetURL, WsURL: WsAPITestnetURL}
+	assert.Equal(t, TestnetEnvironment(), c.Environment())
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -25,7 +24,7 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(ExchangeInfo)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -246,3 +245,65 @@ func (s *Symbol) MinNotionalFilter() *MinNotionalFilter {
 	}
 	return nil
 }
+
+// OrderFilters return the filters of symbol checked by common.OrderValidator
+func (s *Symbol) OrderFilters() *common.SymbolFilters {
+	f := &common.SymbolFilters{Symbol: s.Symbol}
+	if pf := s.PriceFilter(); pf != nil {
+		f.MinPrice = common.DecimalFromString(pf.MinPrice)
+		f.MaxPrice = common.DecimalFromString(pf.MaxPrice)
+		f.TickSize = common.DecimalFromString(pf.TickSize)
+	}
+	if lf := s.LotSizeFilter(); lf != nil {
+		f.MinQuantity = common.DecimalFromString(lf.MinQuantity)
+		f.MaxQuantity = common.DecimalFromString(lf.MaxQuantity)
+		f.StepSize = common.DecimalFromString(lf.StepSize)
+	}
+	if mf := s.MarketLotSizeFilter(); mf != nil {
+		f.MarketMinQuantity = common.DecimalFromString(mf.MinQuantity)
+		f.MarketMaxQuantity = common.DecimalFromString(mf.MaxQuantity)
+		f.MarketStepSize = common.DecimalFromString(mf.StepSize)
+	}
+	if nf := s.MinNotionalFilter(); nf != nil {
+		f.MinNotional = common.DecimalFromString(nf.Notional)
+		f.ApplyMinToMarket = true
+	}
+	if pf := s.PercentPriceFilter(); pf != nil {
+		f.BidMultiplierUp = common.DecimalFromString(pf.MultiplierUp)
+		f.BidMultiplierDown = common.DecimalFromString(pf.MultiplierDown)
+		f.AskMultiplierUp = f.BidMultiplierUp
+		f.AskMultiplierDown = f.BidMultiplierDown
+	}
+	if mf := s.MaxNumOrdersFilter(); mf != nil {
+		f.MaxNumOrders = int(mf.Limit)
+	}
+	if mf := s.MaxNumAlgoOrdersFilter(); mf != nil {
+		f.MaxNumAlgoOrders = int(mf.Limit)
+	}
+	return f
+}
+
+// NewOrderValidator create an order validator with the filters of the symbols
+// of the exchange info, set it as Client.OrderValidator to check the orders
+// before they are sent
+func NewOrderValidator(info *ExchangeInfo) *common.OrderValidator {
+	v := common.NewOrderValidator()
+	for i := range info.Symbols {
+		v.SetFilters(info.Symbols[i].OrderFilters())
+	}
+	return v
+}
+
+// NewRateLimitGovernor create a rate limit governor with the limits of the
+// exchange info, set it as Client.RateLimiter to keep the calls under them
+func NewRateLimitGovernor(info *ExchangeInfo) *common.RateLimitGovernor {
+	limits := make([]common.RateLimit, 0, len(info.RateLimits))
+	for _, l := range info.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, int(l.IntervalNum)),
+			Limit:    int(l.Limit),
+		})
+	}
+	return common.NewRateLimitGovernor(limits...)
+}
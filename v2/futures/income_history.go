@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -72,7 +71,7 @@ func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = make([]*IncomeHistory, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
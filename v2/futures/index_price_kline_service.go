@@ -67,7 +67,7 @@ func (ipks *IndexPriceKlinesService) Do(ctx context.Context, opts ...RequestOpti
 	if err != nil {
 		return []*Kline{}, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return []*Kline{}, err
 	}
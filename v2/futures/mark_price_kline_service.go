@@ -67,7 +67,7 @@ func (mpks *MarkPriceKlinesService) Do(ctx context.Context, opts ...RequestOptio
 	if err != nil {
 		return []*Kline{}, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return []*Kline{}, err
 	}
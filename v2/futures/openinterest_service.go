@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -31,7 +30,7 @@ func (s *GetOpenInterestService) Do(ctx context.Context, opts ...RequestOption)
 	}
 
 	res = new(OpenInterest)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +110,7 @@ func (s *OpenInterestStatisticsService) Do(ctx context.Context, opts ...RequestO
 	}
 
 	res = make([]*OpenInterestStatistic, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*OpenInterestStatistic{}, err
 	}
@@ -0,0 +1,25 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderBookTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderBook(t *testing.T) {
+	suite.Run(t, new(orderBookTestSuite))
+}
+
+func (s *orderBookTestSuite) TestOrderBookOwnReconnectPolicy() {
+	policy := common.DefaultReconnectPolicy()
+	policy.OnDisconnect = func(err error) {}
+	s.client.SetReconnectPolicy(policy)
+	book := s.client.NewOrderBook("BTCUSDT")
+	s.r().NotSame(policy, book.ReconnectPolicy)
+	s.r().Nil(book.ReconnectPolicy.OnDisconnect)
+}
@@ -128,9 +128,23 @@ func (s *CreateOrderService) ClosePosition(closePosition bool) *CreateOrderServi
 	return s
 }
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, rateLimits *RateLimits, err error) {
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, rateLimits *RateLimits, r *request, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:    s.symbol,
+			Side:      string(s.side),
+			Market:    s.price == nil,
+			Algo:      s.orderType.isAlgo(),
+			Price:     s.price,
+			StopPrice: s.stopPrice,
+			Quantity:  &s.quantity,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 
-	r := &request{
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
@@ -179,22 +193,26 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod
 		m["closePosition"] = *s.closePosition
 	}
 	r.setFormParams(m)
-	return s.c.callAPI(ctx, r, opts...)
+	data, rateLimits, err = s.c.callAPI(ctx, r, opts...)
+	return data, rateLimits, r, err
 }
 
 // Do send request
 func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
-	data, rateLimits, err := s.createOrder(ctx, "/fapi/v1/order", "order.place", opts...)
+	data, rateLimits, r, err := s.createOrder(ctx, "/fapi/v1/order", "order.place", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
-	res.RateLimitOrder10s = rateLimits.Order10s
-	res.RateLimitOrder1m = rateLimits.Order1m
+	// an order found by its client order id after a failure has no limits
+	if rateLimits != nil {
+		res.RateLimitOrder10s = rateLimits.Order10s
+		res.RateLimitOrder1m = rateLimits.Order1m
+	}
 
 	return res, nil
 }
@@ -258,7 +276,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -309,7 +327,7 @@ func (s *GetOpenOrderService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -362,7 +380,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -465,7 +483,7 @@ func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -518,7 +536,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -624,7 +642,7 @@ func (s *CancelMultiplesOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return nil, err
 	}
 	res = make([]*CancelOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*CancelOrderResponse{}, err
 	}
@@ -688,7 +706,7 @@ func (s *ListLiquidationOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return []*LiquidationOrder{}, err
 	}
 	res = make([]*LiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*LiquidationOrder{}, err
 	}
@@ -775,7 +793,7 @@ func (s *ListUserLiquidationOrdersService) Do(ctx context.Context, opts ...Reque
 		return []*UserLiquidationOrder{}, err
 	}
 	res = make([]*UserLiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*UserLiquidationOrder{}, err
 	}
@@ -903,9 +921,15 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 		return &CreateBatchOrdersResponse{}, err
 	}
 
+	return parseBatchOrdersResponse(r, data)
+}
+
+// parseBatchOrdersResponse parse the response of a batch of orders, in which
+// each item is either an order or an API error
+func parseBatchOrdersResponse(r *request, data []byte) (*CreateBatchOrdersResponse, error) {
 	rawMessages := make([]*json.RawMessage, 0)
 
-	err = json.Unmarshal(data, &rawMessages)
+	err := r.decode(data, &rawMessages)
 	if err != nil {
 		return &CreateBatchOrdersResponse{}, err
 	}
@@ -933,3 +957,309 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 
 	return batchCreateOrdersResponse, nil
 }
+
+// ModifyOrderService modify the price or quantity of an open LIMIT order
+// without canceling it, the order keeps its order id
+type ModifyOrderService struct {
+	c                 *Client
+	symbol            string
+	side              SideType
+	orderID           *int64
+	origClientOrderID *string
+	quantity          string
+	price             *string
+	priceMatch        *PriceMatchType
+}
+
+// Symbol set symbol
+func (s *ModifyOrderService) Symbol(symbol string) *ModifyOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *ModifyOrderService) Side(side SideType) *ModifyOrderService {
+	s.side = side
+	return s
+}
+
+// OrderID set orderID
+func (s *ModifyOrderService) OrderID(orderID int64) *ModifyOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *ModifyOrderService) OrigClientOrderID(origClientOrderID string) *ModifyOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Quantity set quantity
+func (s *ModifyOrderService) Quantity(quantity string) *ModifyOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *ModifyOrderService) Price(price string) *ModifyOrderService {
+	s.price = &price
+	return s
+}
+
+// PriceMatch set priceMatch, it can not be set together with price
+func (s *ModifyOrderService) PriceMatch(priceMatch PriceMatchType) *ModifyOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+// validate check the new price and quantity with the validator of the client
+func (s *ModifyOrderService) validate() error {
+	if s.c.OrderValidator == nil {
+		return nil
+	}
+	return s.c.OrderValidator.Validate(&common.OrderParams{
+		Symbol:   s.symbol,
+		Side:     string(s.side),
+		Replace:  true,
+		Price:    s.price,
+		Quantity: &s.quantity,
+	})
+}
+
+func (s *ModifyOrderService) params() params {
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	return m
+}
+
+// Do send request
+func (s *ModifyOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/order",
+		secType:  secTypeSigned,
+		wsMethod: "order.modify",
+	}
+	r.setFormParams(s.params())
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ModifyBatchOrdersService modify a batch of orders, 5 orders at most
+type ModifyBatchOrdersService struct {
+	c      *Client
+	orders []*ModifyOrderService
+}
+
+// OrderList set the orders to modify
+func (s *ModifyBatchOrdersService) OrderList(orders []*ModifyOrderService) *ModifyBatchOrdersService {
+	s.orders = orders
+	return s
+}
+
+// Do send request, the orders and the errors of the response have the same
+// layout as those of CreateBatchOrdersService
+func (s *ModifyBatchOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CreateBatchOrdersResponse, err error) {
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/fapi/v1/batchOrders",
+		secType:  secTypeSigned,
+	}
+	orders := make([]params, 0, len(s.orders))
+	for _, order := range s.orders {
+		if err := order.validate(); err != nil {
+			return &CreateBatchOrdersResponse{}, err
+		}
+		orders = append(orders, order.params())
+	}
+	b, err := json.Marshal(orders)
+	if err != nil {
+		return &CreateBatchOrdersResponse{}, err
+	}
+	r.setFormParam("batchOrders", string(b))
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &CreateBatchOrdersResponse{}, err
+	}
+	return parseBatchOrdersResponse(r, data)
+}
+
+// ListOrderAmendmentsService list the amendment history of an order
+type ListOrderAmendmentsService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	startTime         *int64
+	endTime           *int64
+	limit             *int
+}
+
+// Symbol set symbol
+func (s *ListOrderAmendmentsService) Symbol(symbol string) *ListOrderAmendmentsService {
+	s.symbol = symbol
+	return s
+}
+
+// OrderID set orderID
+func (s *ListOrderAmendmentsService) OrderID(orderID int64) *ListOrderAmendmentsService {
+	s.orderID = &orderID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderID
+func (s *ListOrderAmendmentsService) OrigClientOrderID(origClientOrderID string) *ListOrderAmendmentsService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListOrderAmendmentsService) StartTime(startTime int64) *ListOrderAmendmentsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListOrderAmendmentsService) EndTime(endTime int64) *ListOrderAmendmentsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListOrderAmendmentsService) Limit(limit int) *ListOrderAmendmentsService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListOrderAmendmentsService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderAmendment, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/orderAmendment",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	res = make([]*OrderAmendment, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*OrderAmendment{}, err
+	}
+	return res, nil
+}
+
+// OrderAmendment define an amendment of an order
+type OrderAmendment struct {
+	AmendmentID   int64  `json:"amendmentId"`
+	Symbol        string `json:"symbol"`
+	Pair          string `json:"pair"`
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Time          int64  `json:"time"`
+	Amendment     struct {
+		Price        OrderAmendmentChange `json:"price"`
+		OrigQuantity OrderAmendmentChange `json:"origQty"`
+		Count        int                  `json:"count"`
+	} `json:"amendment"`
+}
+
+// OrderAmendmentChange define the value of a field before and after an amendment
+type OrderAmendmentChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// CountdownCancelAllService cancel all open orders of a symbol when the
+// countdown expires, the countdown is reset by each call and canceled by a
+// countdown time of 0, so it can serve as a dead man's switch
+type CountdownCancelAllService struct {
+	c             *Client
+	symbol        string
+	countdownTime int64
+}
+
+// Symbol set symbol
+func (s *CountdownCancelAllService) Symbol(symbol string) *CountdownCancelAllService {
+	s.symbol = symbol
+	return s
+}
+
+// CountdownTime set countdownTime in milliseconds, 0 to cancel the countdown
+func (s *CountdownCancelAllService) CountdownTime(countdownTime int64) *CountdownCancelAllService {
+	s.countdownTime = countdownTime
+	return s
+}
+
+// Do send request
+func (s *CountdownCancelAllService) Do(ctx context.Context, opts ...RequestOption) (res *CountdownCancelAllResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/fapi/v1/countdownCancelAll",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"symbol":        s.symbol,
+		"countdownTime": s.countdownTime,
+	})
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CountdownCancelAllResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CountdownCancelAllResponse define response of countdown cancel all
+type CountdownCancelAllResponse struct {
+	Symbol        string `json:"symbol"`
+	CountdownTime string `json:"countdownTime"`
+}
@@ -708,3 +708,216 @@ func (s *orderServiceTestSuite) TestCreateBatchOrders() {
 	}
 	r.EqualValues(e, res)
 }
+
+func (s *orderServiceTestSuite) TestModifyOrder() {
+	data := []byte(`{
+		"orderId": 20072994037,
+		"symbol": "BTCUSDT",
+		"status": "NEW",
+		"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+		"price": "30005",
+		"avgPrice": "0.0",
+		"origQty": "1",
+		"executedQty": "0",
+		"cumQty": "0",
+		"cumQuote": "0",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"reduceOnly": false,
+		"closePosition": false,
+		"side": "BUY",
+		"positionSide": "LONG",
+		"stopPrice": "0",
+		"workingType": "CONTRACT_PRICE",
+		"priceProtect": false,
+		"origType": "LIMIT",
+		"priceMatch": "NONE",
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0,
+		"updateTime": 1629182711600
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   symbol,
+			"side":     SideTypeBuy,
+			"orderId":  orderID,
+			"quantity": "1",
+			"price":    "30005",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyOrderService().Symbol(symbol).Side(SideTypeBuy).
+		OrderID(orderID).Quantity("1").Price("30005").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(orderID, res.OrderID)
+	r.Equal("30005", res.Price)
+	r.Equal(OrderStatusTypeNew, res.Status)
+	r.Equal(PositionSideTypeLong, res.PositionSide)
+	r.Equal(int64(1629182711600), res.UpdateTime)
+}
+
+func (s *orderServiceTestSuite) TestModifyOrderValidation() {
+	s.client.OrderValidator = common.NewOrderValidator(&common.SymbolFilters{
+		Symbol:       "BTCUSDT",
+		TickSize:     common.MustParseDecimal("0.1"),
+		MinQuantity:  common.MustParseDecimal("0.001"),
+		StepSize:     common.MustParseDecimal("0.001"),
+		MaxNumOrders: 200,
+	})
+	s.client.OrderValidator.OpenOrders = func(symbol string) (int, int, bool) {
+		return 200, 0, true
+	}
+	_, err := s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderID(20072994037).Quantity("0.0001").Price("30005").Do(newContext())
+	s.r().True(common.IsOrderFilterError(err))
+	s.r().Equal("LOT_SIZE", err.(*common.OrderFilterError).Filter)
+	s.client.AssertNotCalled(s.T(), "do", anyHTTPRequest())
+
+	// the modified order is not counted by MAX_NUM_ORDERS
+	s.client.OrderValidator.AutoRound = true
+	s.mockDo([]byte(`{"orderId": 20072994037}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeBuy,
+			"orderId":  20072994037,
+			"quantity": "1.234",
+			"price":    "30005.1",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	_, err = s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderID(20072994037).Quantity("1.2345").Price("30005.06").Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *orderServiceTestSuite) TestModifyBatchOrders() {
+	data := []byte(`[
+		{
+			"orderId": 42042723,
+			"symbol": "BTCUSDT",
+			"status": "NEW",
+			"clientOrderId": "Ne7DGmj13MAtMiOpCjlpNV",
+			"price": "30000",
+			"origQty": "0.01",
+			"side": "BUY",
+			"type": "LIMIT",
+			"priceMatch": "QUEUE"
+		},
+		{
+			"code": -2013,
+			"msg": "Order does not exist."
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"batchOrders": `[{"orderId":42042723,"priceMatch":"QUEUE","quantity":"0.01","side":"BUY","symbol":"BTCUSDT"},` +
+				`{"origClientOrderId":"myOrder","price":"29000","quantity":"0.02","side":"SELL","symbol":"BTCUSDT"}]`,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewModifyBatchOrdersService().OrderList([]*ModifyOrderService{
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+			OrderID(42042723).Quantity("0.01").PriceMatch(PriceMatchTypeQueue),
+		s.client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+			OrigClientOrderID("myOrder").Quantity("0.02").Price("29000"),
+	}).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(2, res.N)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(42042723), res.Orders[0].OrderID)
+	r.Equal("QUEUE", res.Orders[0].PriceMatch)
+	r.Nil(res.Errors[0])
+	r.Equal(&common.APIError{Code: -2013, Message: "Order does not exist."}, res.Errors[1])
+}
+
+func (s *orderServiceTestSuite) TestListOrderAmendments() {
+	data := []byte(`[
+		{
+			"amendmentId": 5363,
+			"symbol": "BTCUSDT",
+			"pair": "BTCUSDT",
+			"orderId": 20072994037,
+			"clientOrderId": "LJ9R4QZDihCaS8UAOOLpgW",
+			"time": 1629184560899,
+			"amendment": {
+				"price": {
+					"before": "30004",
+					"after": "30003.2"
+				},
+				"origQty": {
+					"before": "1",
+					"after": "1"
+				},
+				"count": 3
+			}
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	symbol := "BTCUSDT"
+	orderID := int64(20072994037)
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":  symbol,
+			"orderId": orderID,
+			"limit":   50,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListOrderAmendmentsService().Symbol(symbol).
+		OrderID(orderID).Limit(50).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	e := &OrderAmendment{
+		AmendmentID:   5363,
+		Symbol:        symbol,
+		Pair:          "BTCUSDT",
+		OrderID:       orderID,
+		ClientOrderID: "LJ9R4QZDihCaS8UAOOLpgW",
+		Time:          1629184560899,
+	}
+	e.Amendment.Price = OrderAmendmentChange{Before: "30004", After: "30003.2"}
+	e.Amendment.OrigQuantity = OrderAmendmentChange{Before: "1", After: "1"}
+	e.Amendment.Count = 3
+	r.Equal(e, res[0])
+}
+
+func (s *orderServiceTestSuite) TestCountdownCancelAll() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"countdownTime": "100000"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":        "BTCUSDT",
+			"countdownTime": 100000,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCountdownCancelAllService().Symbol("BTCUSDT").
+		CountdownTime(100000).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CountdownCancelAllResponse{Symbol: "BTCUSDT", CountdownTime: "100000"}, res)
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -34,7 +33,7 @@ func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption)
 		return []*PositionRisk{}, err
 	}
 	res = make([]*PositionRisk, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*PositionRisk{}, err
 	}
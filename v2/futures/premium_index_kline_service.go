@@ -67,7 +67,7 @@ func (piks *PremiumIndexKlinesService) Do(ctx context.Context, opts ...RequestOp
 	if err != nil {
 		return []*Kline{}, err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return []*Kline{}, err
 	}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -45,7 +44,7 @@ func (s *GetRebateNewUserService) Do(ctx context.Context, opts ...RequestOption)
 		return &RebateNewUser{}, err
 	}
 
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return &RebateNewUser{}, err
 	}
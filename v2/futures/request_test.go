@@ -1,8 +1,10 @@
 package futures
 
 import (
-	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWithExtraForm(t *testing.T) {
@@ -44,3 +46,81 @@ func TestWithExtraForm(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      *request
+		weight int
+	}{
+		{
+			name:   "depth default limit",
+			r:      &request{method: http.MethodGet, endpoint: "/fapi/v1/depth"},
+			weight: 10,
+		},
+		{
+			name:   "depth limit 1000",
+			r:      (&request{method: http.MethodGet, endpoint: "/fapi/v1/depth"}).setParam("limit", 1000),
+			weight: 20,
+		},
+		{
+			name:   "klines limit 1500",
+			r:      (&request{method: http.MethodGet, endpoint: "/fapi/v1/klines"}).setParam("limit", 1500),
+			weight: 10,
+		},
+		{
+			name:   "open orders of all symbols",
+			r:      &request{method: http.MethodGet, endpoint: "/fapi/v1/openOrders"},
+			weight: 40,
+		},
+		{
+			name:   "unknown endpoint",
+			r:      &request{method: http.MethodGet, endpoint: "/fapi/v1/time"},
+			weight: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.weight, tt.r.weight())
+		})
+	}
+}
+
+func TestRequestOrderCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      *request
+		orders int
+	}{
+		{
+			name:   "order",
+			r:      &request{method: http.MethodPost, endpoint: "/fapi/v1/order"},
+			orders: 1,
+		},
+		{
+			name:   "batch orders",
+			r:      (&request{method: http.MethodPost, endpoint: "/fapi/v1/batchOrders"}).setFormParam("batchOrders", `[{"symbol":"BTCUSDT"},{"symbol":"ETHUSDT"},{"symbol":"BNBUSDT"}]`),
+			orders: 3,
+		},
+		{
+			name:   "modify order",
+			r:      &request{method: http.MethodPut, endpoint: "/fapi/v1/order"},
+			orders: 1,
+		},
+		{
+			name:   "modify batch orders",
+			r:      (&request{method: http.MethodPut, endpoint: "/fapi/v1/batchOrders"}).setFormParam("batchOrders", `[{"orderId":1},{"orderId":2}]`),
+			orders: 2,
+		},
+		{
+			name:   "cancel batch orders",
+			r:      &request{method: http.MethodDelete, endpoint: "/fapi/v1/batchOrders"},
+			orders: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.orders, tt.r.orderCount())
+		})
+	}
+}
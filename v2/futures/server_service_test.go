@@ -88,6 +88,11 @@ func (s *serverServiceTestSuite) TestInvalidResponseBody() {
 	_, err := s.client.NewServerTimeService().Do(newContext())
 	s.r().Error(err)
 	s.r().False(common.IsAPIError(err))
+	var decodeErr *common.DecodeError
+	s.r().ErrorAs(err, &decodeErr)
+	s.r().Equal(http.MethodGet, decodeErr.Method)
+	s.r().Equal("/fapi/v1/time", decodeErr.Endpoint)
+	s.r().Equal(http.StatusOK, decodeErr.StatusCode)
 }
 
 func (s *serverServiceTestSuite) TestSetServerTime() {
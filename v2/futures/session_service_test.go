@@ -0,0 +1,102 @@
+package futures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type sessionServiceTestSuite struct {
+	suite.Suite
+	server *wsAPIServer
+	client *Client
+}
+
+func TestSessionService(t *testing.T) {
+	suite.Run(t, new(sessionServiceTestSuite))
+}
+
+func (s *sessionServiceTestSuite) SetupTest() {
+	s.server = newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		switch req.Method {
+		case "session.logon", "session.status":
+			return wsAPIResult(`{
+				"apiKey": "dummyAPIKey",
+				"authorizedSince": 1728980190000,
+				"connectedSince": 1728980180000,
+				"returnRateLimits": false,
+				"serverTime": 1728980199000
+			}`)
+		case "session.logout":
+			return wsAPIResult(`{
+				"apiKey": null,
+				"authorizedSince": null,
+				"connectedSince": 1728980180000,
+				"returnRateLimits": false,
+				"serverTime": 1728980199999
+			}`)
+		}
+		return wsAPIResult(`[]`)
+	})
+	s.client = newWsAPIClient(s.server)
+	s.Require().True(s.client.WsConnected())
+}
+
+func (s *sessionServiceTestSuite) TearDownTest() {
+	s.client.Close()
+	s.server.Close()
+}
+
+func (s *sessionServiceTestSuite) TestLogonAndLogout() {
+	r := s.Require()
+
+	res, err := s.client.NewSessionLogonService().Do(newContext())
+	r.NoError(err)
+	r.Equal(&SessionStatus{
+		APIKey:          "dummyAPIKey",
+		AuthorizedSince: 1728980190000,
+		ConnectedSince:  1728980180000,
+		ServerTime:      1728980199000,
+	}, res)
+	req := s.server.lastRequest()
+	r.Equal("session.logon", req.Method)
+	r.Equal("dummyAPIKey", req.Params[apiKey])
+	r.NotEmpty(req.Params[signatureKey])
+	r.True(s.client.WsConn.LoggedOn())
+
+	_, err = s.client.NewGetBalanceService().Do(newContext())
+	r.NoError(err)
+	req = s.server.lastRequest()
+	r.Equal("v2/account.balance", req.Method)
+	r.NotContains(req.Params, apiKey)
+	r.NotContains(req.Params, signatureKey)
+	r.NotEmpty(req.Params[timestampKey])
+
+	res, err = s.client.NewSessionLogoutService().Do(newContext())
+	r.NoError(err)
+	r.Empty(res.APIKey)
+	r.False(s.client.WsConn.LoggedOn())
+
+	_, err = s.client.NewGetBalanceService().Do(newContext())
+	r.NoError(err)
+	r.NotEmpty(s.server.lastRequest().Params[signatureKey])
+}
+
+func (s *sessionServiceTestSuite) TestSessionIntercepted() {
+	var methods []string
+	s.client.Interceptors = []common.Interceptor{func(ctx context.Context, req *common.APIRequest, next common.Invoker) (*common.APIResponse, error) {
+		s.Require().True(req.WebSocket)
+		methods = append(methods, req.WsMethod)
+		return next(ctx, req)
+	}}
+
+	_, err := s.client.NewSessionLogonService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewSessionStatusService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewSessionLogoutService().Do(newContext())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"session.logon", "session.status", "session.logout"}, methods)
+}
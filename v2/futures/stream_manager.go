@@ -0,0 +1,247 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// StreamManager subscribe the market streams of a client over a few
+// connections to its combined streams endpoints. The streams can be added
+// and removed without reconnecting, by their names, e.g. btcusdt@aggTrade.
+//
+// The depth and book ticker streams are served by the public endpoint, the
+// other ones by the market endpoint, each has its own connections.
+type StreamManager struct {
+	public *common.StreamManager
+	market *common.StreamManager
+}
+
+// NewStreamManager create a stream manager of the combined streams
+// endpoints of the client, the connections are dialed by the first
+// subscriptions
+func (c *Client) NewStreamManager() *StreamManager {
+	return &StreamManager{
+		public: newStreamManager(c.combinedEndpoint()),
+		market: newStreamManager(c.combinedMarketEndpoint()),
+	}
+}
+
+func newStreamManager(combinedEndpoint string) *common.StreamManager {
+	endpoint := strings.TrimSuffix(combinedEndpoint, "?streams=")
+	return &common.StreamManager{
+		Dial: func(ctx context.Context) (common.StreamConn, error) {
+			return dialStream(ctx, endpoint)
+		},
+		ErrHandler: func(err error) {
+			websocketLogger().Error("stream manager error", "endpoint", endpoint, "error", err)
+		},
+	}
+}
+
+// isPublicStream return true for the streams of the public endpoint
+func isPublicStream(stream string) bool {
+	return strings.Contains(stream, "@depth") || strings.HasSuffix(stream, "bookTicker")
+}
+
+// split the streams by endpoint
+func (m *StreamManager) split(streams []string) (public, market []string) {
+	for _, stream := range streams {
+		if isPublicStream(stream) {
+			public = append(public, stream)
+		} else {
+			market = append(market, stream)
+		}
+	}
+	return public, market
+}
+
+// Subscribe route the data of the streams to the handler, the streams which
+// are not subscribed yet are subscribed. The handler must not call the
+// manager, see common.StreamManager.Subscribe.
+func (m *StreamManager) Subscribe(ctx context.Context, handler common.StreamHandler, streams ...string) error {
+	public, market := m.split(streams)
+	if len(public) > 0 {
+		if err := m.public.Subscribe(ctx, handler, public...); err != nil {
+			return err
+		}
+	}
+	if len(market) > 0 {
+		return m.market.Subscribe(ctx, handler, market...)
+	}
+	return nil
+}
+
+// Unsubscribe the streams and remove their handlers
+func (m *StreamManager) Unsubscribe(ctx context.Context, streams ...string) error {
+	public, market := m.split(streams)
+	if len(public) > 0 {
+		if err := m.public.Unsubscribe(ctx, public...); err != nil {
+			return err
+		}
+	}
+	if len(market) > 0 {
+		return m.market.Unsubscribe(ctx, market...)
+	}
+	return nil
+}
+
+// ListSubscriptions ask the connections for their subscribed streams
+func (m *StreamManager) ListSubscriptions(ctx context.Context) ([]string, error) {
+	public, err := m.public.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := m.market.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(public, market...), nil
+}
+
+// Streams return the subscribed streams
+func (m *StreamManager) Streams() []string {
+	return append(m.public.Streams(), m.market.Streams()...)
+}
+
+// SetErrHandler set the handler of the errors of the connections, a
+// *common.StreamsDroppedError when one drops
+func (m *StreamManager) SetErrHandler(errHandler ErrHandler) *StreamManager {
+	m.public.ErrHandler = errHandler
+	m.market.ErrHandler = errHandler
+	return m
+}
+
+// Close close the connections, the manager can't be used after
+func (m *StreamManager) Close() {
+	m.public.Close()
+	m.market.Close()
+}
+
+// symbolStreams return the names of the stream of the symbols
+func symbolStreams(symbols []string, format string, args ...interface{}) []string {
+	suffix := fmt.Sprintf(format, args...)
+	streams := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		streams[i] = strings.ToLower(symbol) + suffix
+	}
+	return streams
+}
+
+// streamErrHandler log the errors of the streams without error handler
+func streamErrHandler(errHandler ErrHandler) ErrHandler {
+	if errHandler != nil {
+		return errHandler
+	}
+	return func(err error) {
+		websocketLogger().Error("stream error", "error", err)
+	}
+}
+
+// SubscribeAggTrade subscribe the <symbol>@aggTrade streams
+func (m *StreamManager) SubscribeAggTrade(ctx context.Context, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsAggTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@aggTrade")...)
+}
+
+// SubscribeMarkPrice subscribe the <symbol>@markPrice streams
+func (m *StreamManager) SubscribeMarkPrice(ctx context.Context, symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMarkPriceEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@markPrice")...)
+}
+
+// SubscribeKline subscribe the <symbol>@kline_<interval> streams
+func (m *StreamManager) SubscribeKline(ctx context.Context, symbols []string, interval string, handler WsKlineHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsKlineEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@kline_%s", interval)...)
+}
+
+// SubscribeMiniMarketTicker subscribe the <symbol>@miniTicker streams
+func (m *StreamManager) SubscribeMiniMarketTicker(ctx context.Context, symbols []string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMiniMarketTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@miniTicker")...)
+}
+
+// SubscribeMarketTicker subscribe the <symbol>@ticker streams
+func (m *StreamManager) SubscribeMarketTicker(ctx context.Context, symbols []string, handler WsMarketTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMarketTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@ticker")...)
+}
+
+// SubscribeBookTicker subscribe the <symbol>@bookTicker streams
+func (m *StreamManager) SubscribeBookTicker(ctx context.Context, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsBookTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@bookTicker")...)
+}
+
+// SubscribeDiffDepth subscribe the <symbol>@depth streams
+func (m *StreamManager) SubscribeDiffDepth(ctx context.Context, symbols []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth"), handler, errHandler)
+}
+
+// SubscribePartialDepth subscribe the <symbol>@depth<levels> streams, the
+// levels are 5, 10 or 20
+func (m *StreamManager) SubscribePartialDepth(ctx context.Context, symbols []string, levels int, handler WsDepthHandler, errHandler ErrHandler) error {
+	if levels != 5 && levels != 10 && levels != 20 {
+		return errors.New("Invalid levels")
+	}
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth%d", levels), handler, errHandler)
+}
+
+func (m *StreamManager) subscribeDepth(ctx context.Context, streams []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		j, err := newJSON(data)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(newWsDepthEvent(j))
+	}, streams...)
+}
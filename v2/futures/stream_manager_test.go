@@ -0,0 +1,199 @@
+package futures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamConn acknowledge the methods, the streams are pushed with push
+type fakeStreamConn struct {
+	readC  chan []byte
+	closeC chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+	params [][]string
+}
+
+func newFakeStreamConn() *fakeStreamConn {
+	return &fakeStreamConn{readC: make(chan []byte, 10), closeC: make(chan struct{})}
+}
+
+func (c *fakeStreamConn) ReadMessage() (int, []byte, error) {
+	select {
+	case message := <-c.readC:
+		return 1, message, nil
+	case <-c.closeC:
+		return 0, nil, errors.New("connection closed")
+	}
+}
+
+func (c *fakeStreamConn) WriteMessage(messageType int, data []byte) error {
+	var req struct {
+		Params []string `json:"params"`
+		ID     int64    `json:"id"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.params = append(c.params, req.Params)
+	c.mu.Unlock()
+	c.readC <- []byte(fmt.Sprintf(`{"result":null,"id":%d}`, req.ID))
+	return nil
+}
+
+func (c *fakeStreamConn) Close() error {
+	c.once.Do(func() { close(c.closeC) })
+	return nil
+}
+
+func (c *fakeStreamConn) push(stream, data string) {
+	c.readC <- []byte(fmt.Sprintf(`{"stream":%q,"data":%s}`, stream, data))
+}
+
+func (c *fakeStreamConn) subscribed() [][]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]string(nil), c.params...)
+}
+
+// newTestStreamManager return a stream manager whose public and market
+// endpoints are the connections
+func newTestStreamManager(public, market *fakeStreamConn) *StreamManager {
+	m := NewClient("apiKey", "secretKey").NewStreamManager()
+	m.public.Dial = func(ctx context.Context) (common.StreamConn, error) {
+		return public, nil
+	}
+	m.market.Dial = func(ctx context.Context) (common.StreamConn, error) {
+		return market, nil
+	}
+	return m
+}
+
+func TestStreamManagerRouting(t *testing.T) {
+	public, market := newFakeStreamConn(), newFakeStreamConn()
+	m := newTestStreamManager(public, market)
+	defer m.Close()
+
+	handler := func(stream string, data []byte) {}
+	err := m.Subscribe(context.Background(), handler, "btcusdt@depth", "btcusdt@aggTrade", "ethusdt@bookTicker", "btcusdt@markPrice")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@depth", "ethusdt@bookTicker"}}, public.subscribed())
+	assert.Equal(t, [][]string{{"btcusdt@aggTrade", "btcusdt@markPrice"}}, market.subscribed())
+	assert.ElementsMatch(t, []string{"btcusdt@depth", "ethusdt@bookTicker", "btcusdt@aggTrade", "btcusdt@markPrice"}, m.Streams())
+
+	require.NoError(t, m.Unsubscribe(context.Background(), "btcusdt@depth", "btcusdt@markPrice"))
+	assert.Equal(t, [][]string{{"btcusdt@depth", "ethusdt@bookTicker"}, {"btcusdt@depth"}}, public.subscribed())
+	assert.Equal(t, [][]string{{"btcusdt@aggTrade", "btcusdt@markPrice"}, {"btcusdt@markPrice"}}, market.subscribed())
+	assert.ElementsMatch(t, []string{"ethusdt@bookTicker", "btcusdt@aggTrade"}, m.Streams())
+}
+
+func TestStreamManagerSubscribeMarkPrice(t *testing.T) {
+	public, market := newFakeStreamConn(), newFakeStreamConn()
+	m := newTestStreamManager(public, market)
+	defer m.Close()
+
+	eventC := make(chan *WsMarkPriceEvent, 1)
+	err := m.SubscribeMarkPrice(context.Background(), []string{"BTCUSDT"}, func(event *WsMarkPriceEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@markPrice"}}, market.subscribed())
+	assert.Empty(t, public.subscribed())
+
+	market.push("btcusdt@markPrice", `{"e":"markPriceUpdate","E":1562305380000,"s":"BTCUSDT","p":"11794.15000000","i":"11784.62659091","P":"11784.25641265","r":"0.00038167","T":1562306400000}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSDT", event.Symbol)
+		assert.Equal(t, "11794.15000000", event.MarkPrice)
+		assert.Equal(t, "0.00038167", event.FundingRate)
+		assert.Equal(t, int64(1562306400000), event.NextFundingTime)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerSubscribeBookTicker(t *testing.T) {
+	public, market := newFakeStreamConn(), newFakeStreamConn()
+	m := newTestStreamManager(public, market)
+	defer m.Close()
+
+	eventC := make(chan *WsBookTickerEvent, 1)
+	err := m.SubscribeBookTicker(context.Background(), []string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@bookTicker"}}, public.subscribed())
+	assert.Empty(t, market.subscribed())
+
+	public.push("btcusdt@bookTicker", `{"e":"bookTicker","u":400900217,"E":1568014460893,"T":1568014460891,"s":"BTCUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSDT", event.Symbol)
+		assert.Equal(t, int64(400900217), event.UpdateID)
+		assert.Equal(t, "25.35190000", event.BestBidPrice)
+		assert.Equal(t, "40.66000000", event.BestAskQty)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerSubscribePartialDepth(t *testing.T) {
+	public, market := newFakeStreamConn(), newFakeStreamConn()
+	m := newTestStreamManager(public, market)
+	defer m.Close()
+
+	err := m.SubscribePartialDepth(context.Background(), []string{"BTCUSDT"}, 7, func(event *WsDepthEvent) {}, nil)
+	assert.EqualError(t, err, "Invalid levels")
+
+	eventC := make(chan *WsDepthEvent, 1)
+	err = m.SubscribePartialDepth(context.Background(), []string{"BTCUSDT"}, 5, func(event *WsDepthEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@depth5"}}, public.subscribed())
+
+	public.push("btcusdt@depth5", `{"e":"depthUpdate","E":1571889248277,"T":1571889248276,"s":"BTCUSDT","U":390497796,"u":390497878,"pu":390497794,"b":[["7403.89","0.002"]],"a":[["7405.96","3.340"]]}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSDT", event.Symbol)
+		assert.Equal(t, int64(390497878), event.LastUpdateID)
+		require.Len(t, event.Bids, 1)
+		assert.Equal(t, "7403.89", event.Bids[0].Price)
+		require.Len(t, event.Asks, 1)
+		assert.Equal(t, "3.340", event.Asks[0].Quantity)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerDecodeError(t *testing.T) {
+	public, market := newFakeStreamConn(), newFakeStreamConn()
+	m := newTestStreamManager(public, market)
+	defer m.Close()
+
+	errC := make(chan error, 1)
+	err := m.SubscribeAggTrade(context.Background(), []string{"BTCUSDT"}, func(event *WsAggTradeEvent) {
+		t.Error("an invalid event is not handled")
+	}, func(err error) {
+		errC <- err
+	})
+	require.NoError(t, err)
+
+	market.push("btcusdt@aggTrade", `{"a":"invalid"}`)
+	select {
+	case err := <-errC:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the error was not received")
+	}
+}
@@ -2,7 +2,6 @@ package futures
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 
 	"github.com/adshao/go-binance/v2/common"
@@ -36,7 +35,7 @@ func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption)
 		return []*BookTicker{}, err
 	}
 	res = make([]*BookTicker, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*BookTicker{}, err
 	}
@@ -80,7 +79,7 @@ func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res
 	}
 	data = common.ToJSONList(data)
 	res = make([]*SymbolPrice, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*SymbolPrice{}, err
 	}
@@ -120,7 +119,7 @@ func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOpt
 	}
 	data = common.ToJSONList(data)
 	res = make([]*PriceChangeStats, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,43 @@
+package futures
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// UserDataStream is a managed user data stream. It owns the listen key, keeps
+// it alive, reconnects when the connection drops and renews the listen key
+// on listenKeyExpired events. All events are delivered to the handler.
+//
+// KeepaliveInterval, MaxConnectionAge and ReconnectPolicy of the embedded
+// ListenKeyStream may be changed before Start. The ReconnectPolicy is the
+// stream's own, its callbacks are not shared with the WebSocket API one.
+type UserDataStream struct {
+	*common.ListenKeyStream
+}
+
+// NewUserDataStream create a managed USD-M futures user data stream
+func (c *Client) NewUserDataStream(handler WsUserDataHandler, errHandler ErrHandler) *UserDataStream {
+	return &UserDataStream{&common.ListenKeyStream{
+		StartListenKey: func(ctx context.Context) (string, error) {
+			return c.NewStartUserStreamService().Do(ctx)
+		},
+		KeepaliveListenKey: func(ctx context.Context, listenKey string) error {
+			return c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		CloseListenKey: func(ctx context.Context, listenKey string) error {
+			return c.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		Serve: func(listenKey string, expired func()) (doneC, stopC chan struct{}, err error) {
+			return c.WsUserDataServe(listenKey, func(event *WsUserDataEvent) {
+				if event.Event == UserDataEventTypeListenKeyExpired {
+					expired()
+				}
+				handler(event)
+			}, errHandler)
+		},
+		ReconnectPolicy: common.DefaultReconnectPolicy(),
+		ErrHandler:      errHandler,
+	}}
+}
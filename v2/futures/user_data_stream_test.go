@@ -0,0 +1,79 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	baseTestSuite
+	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.origWsServe = wsServe
+}
+
+func (s *userDataStreamTestSuite) TearDownTest() {
+	wsServe = s.origWsServe
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStream() {
+	listenKey := "pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"
+	s.mockDo([]byte(`{"listenKey": "`+listenKey+`"}`), nil)
+
+	var endpoints []string
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		doneC = make(chan struct{})
+		stopC = make(chan struct{})
+		go func() {
+			<-stopC
+			close(doneC)
+		}()
+		handler([]byte(`{"e":"ACCOUNT_CONFIG_UPDATE","E":1611646737479,"T":1611646737476,"ac":{"s":"BTCUSDT","l":25}}`))
+		return doneC, stopC, nil
+	}
+
+	eventC := make(chan *WsUserDataEvent, 1)
+	stream := s.client.NewUserDataStream(func(event *WsUserDataEvent) {
+		eventC <- event
+	}, func(err error) {
+		s.r().NoError(err)
+	})
+	s.r().NoError(stream.Start(newContext()))
+	s.r().Equal(listenKey, stream.ListenKey())
+
+	select {
+	case event := <-eventC:
+		s.r().Equal(UserDataEventTypeAccountConfigUpdate, event.Event)
+		s.r().Equal(int64(25), event.AccountConfigUpdate.Leverage)
+	case <-time.After(time.Second):
+		s.T().Fatal("timeout waiting for event")
+	}
+
+	stream.Stop()
+	s.r().Equal([]string{getWsPrivateEndpoint() + "/" + listenKey}, endpoints)
+	calls := s.client.Calls
+	s.r().Len(calls, 2)
+	s.r().Equal(http.MethodPost, calls[0].Arguments.Get(0).(*http.Request).Method)
+	s.r().Equal(http.MethodDelete, calls[1].Arguments.Get(0).(*http.Request).Method)
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStreamOwnReconnectPolicy() {
+	policy := common.DefaultReconnectPolicy()
+	policy.OnDisconnect = func(err error) {}
+	s.client.SetReconnectPolicy(policy)
+	stream := s.client.NewUserDataStream(func(event *WsUserDataEvent) {}, func(err error) {})
+	s.r().NotSame(policy, stream.ReconnectPolicy)
+	s.r().Nil(stream.ReconnectPolicy.OnDisconnect)
+}
@@ -21,7 +21,7 @@ func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return "", err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return "", err
 	}
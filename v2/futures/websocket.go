@@ -1,9 +1,13 @@
 package futures
 
 import (
+	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -26,6 +30,22 @@ func newWsConfig(endpoint string) *WsConfig {
 	}
 }
 
+// WebsocketInstrumentation receives the connections of the websocket
+// streams, nil to ignore them
+var WebsocketInstrumentation common.Instrumentation
+
+// WebsocketLogger logs the errors of the websocket streams served without
+// error handler, with the secrets redacted. They are printed to stderr if it
+// is nil.
+var WebsocketLogger common.Logger
+
+func websocketLogger() common.Logger {
+	if WebsocketLogger != nil {
+		return common.NewRedactingLogger(WebsocketLogger)
+	}
+	return common.NewRedactingLogger(common.DefaultLogger(common.LogLevelInfo))
+}
+
 var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	Dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
@@ -33,7 +53,14 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		EnableCompression: false,
 	}
 
+	if errHandler == nil {
+		errHandler = func(err error) {
+			websocketLogger().Error("websocket error", "endpoint", cfg.Endpoint, "error", err)
+		}
+	}
+	instrumentation := common.InstrumentationOrNoop(WebsocketInstrumentation)
 	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
+	instrumentation.WsConnect(cfg.Endpoint, err)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -64,7 +91,10 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 			_, message, err := c.ReadMessage()
 			if err != nil {
 				if !silent {
+					instrumentation.WsDisconnect(cfg.Endpoint, err)
 					errHandler(err)
+				} else {
+					instrumentation.WsDisconnect(cfg.Endpoint, nil)
 				}
 				return
 			}
@@ -74,6 +104,76 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 	return
 }
 
+// wsServeMarket serve a market data stream, it is reconnected when the
+// client has ResilientStreams
+func (c *Client) wsServeMarket(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	if c.ResilientStreams == nil {
+		return wsServe(cfg, handler, errHandler)
+	}
+	if errHandler == nil {
+		errHandler = func(err error) {
+			websocketLogger().Error("websocket error", "endpoint", cfg.Endpoint, "error", err)
+		}
+	}
+	// the old and the new connections overlap while renewing, the handler is
+	// still called by one of them at a time
+	var lock sync.Mutex
+	serialHandler := func(message []byte) {
+		lock.Lock()
+		defer lock.Unlock()
+		handler(message)
+	}
+	instrumentation := common.InstrumentationOrNoop(WebsocketInstrumentation)
+	return c.ResilientStreams.Serve(cfg.Endpoint, instrumentation, func() (doneC, stopC chan struct{}, err error) {
+		return wsServe(cfg, serialHandler, errHandler)
+	}, errHandler)
+}
+
+// streamConn report the disconnection of a connection of a stream manager
+type streamConn struct {
+	*websocket.Conn
+	endpoint        string
+	instrumentation common.Instrumentation
+	closed          int32
+}
+
+func (c *streamConn) ReadMessage() (messageType int, p []byte, err error) {
+	messageType, p, err = c.Conn.ReadMessage()
+	if err != nil {
+		if atomic.LoadInt32(&c.closed) == 1 {
+			c.instrumentation.WsDisconnect(c.endpoint, nil)
+		} else {
+			c.instrumentation.WsDisconnect(c.endpoint, err)
+		}
+	}
+	return
+}
+
+func (c *streamConn) Close() error {
+	atomic.StoreInt32(&c.closed, 1)
+	return c.Conn.Close()
+}
+
+// dialStream connect a stream manager to the combined streams endpoint
+func dialStream(ctx context.Context, endpoint string) (common.StreamConn, error) {
+	Dialer := websocket.Dialer{
+		Proxy:             http.ProxyFromEnvironment,
+		HandshakeTimeout:  45 * time.Second,
+		EnableCompression: false,
+	}
+	instrumentation := common.InstrumentationOrNoop(WebsocketInstrumentation)
+	c, _, err := Dialer.DialContext(ctx, endpoint, nil)
+	instrumentation.WsConnect(endpoint, err)
+	if err != nil {
+		return nil, err
+	}
+	c.SetReadLimit(wsReadLimit)
+	if WebsocketKeepalive {
+		keepAlive(c, WebsocketTimeout)
+	}
+	return &streamConn{Conn: c, endpoint: endpoint, instrumentation: instrumentation}, nil
+}
+
 func keepAlive(c *websocket.Conn, timeout time.Duration) {
 	ticker := time.NewTicker(timeout)
 
@@ -2,8 +2,6 @@ package futures
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"github.com/adshao/go-binance/v2/common"
@@ -13,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,9 +30,15 @@ var (
 	WsAPITestnetURL = "wss://testnet.binancefuture.com/ws-fapi/v1"
 )
 
+// _ResponseMap holds the pending requests of a WebSocket API connection
 type _ResponseMap struct {
-	lock sync.Mutex
-	d    map[string]chan *WsApiResponse
+	lock   sync.Mutex
+	d      map[string]chan *WsApiResponse
+	closed bool
+}
+
+func newResponseMap() *_ResponseMap {
+	return &_ResponseMap{d: make(map[string]chan *WsApiResponse)}
 }
 
 func (m *_ResponseMap) LoadAndDelete(id string) chan *WsApiResponse {
@@ -46,13 +51,27 @@ func (m *_ResponseMap) LoadAndDelete(id string) chan *WsApiResponse {
 	return nil
 }
 
-func (m *_ResponseMap) Set(id string, ch chan *WsApiResponse) {
+// Set register a pending request, return false if the connection is closed already
+func (m *_ResponseMap) Set(id string, ch chan *WsApiResponse) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	if m.closed {
+		return false
+	}
 	m.d[id] = ch
+	return true
 }
 
-var apiResponses = _ResponseMap{d: make(map[string]chan *WsApiResponse)}
+// Close fail all the pending requests by closing their channels
+func (m *_ResponseMap) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.closed = true
+	for id, ch := range m.d {
+		close(ch)
+		delete(m.d, id)
+	}
+}
 
 type WsApiResponse struct {
 	Id     string `json:"id"`
@@ -60,6 +79,10 @@ type WsApiResponse struct {
 	Error  struct {
 		Code int64  `json:"code"`
 		Msg  string `json:"msg"`
+		Data struct {
+			// RetryAfter is the time in ms when a 429 or 418 backoff ends
+			RetryAfter int64 `json:"retryAfter"`
+		} `json:"data"`
 	} `json:"error"`
 	Result     ObjectType `json:"result"`
 	RateLimits []struct {
@@ -81,38 +104,69 @@ func (o *ObjectType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// getWsAPIEndpoint return the base endpoint of the WebSocket API according the UseTestnet flag
-func getWsAPIEndpoint() string {
-	if UseTestnet {
-		return WsAPITestnetURL
-	}
-	return WsAPIMainURL
-}
-
 type WsConnection struct {
 	*websocket.Conn
 	Done chan struct{}
 	Stop chan struct{}
+	// responses of the pending requests, by request id
+	responses *_ResponseMap
+	// err is the read error which closed the connection
+	err error
+	// loggedOn is set to 1 once session.logon succeeded on the connection
+	loggedOn int32
 }
 
-func makeConn() *WsConnection {
+// LoggedOn return true if the connection is authenticated by session.logon
+func (c *WsConnection) LoggedOn() bool {
+	return atomic.LoadInt32(&c.loggedOn) == 1
+}
+
+// Err return the error which closed the connection after Done is closed,
+// nil if it is closed by Stop
+func (c *WsConnection) Err() error {
+	select {
+	case <-c.Done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *WsConnection) setLoggedOn(loggedOn bool) {
+	var v int32
+	if loggedOn {
+		v = 1
+	}
+	atomic.StoreInt32(&c.loggedOn, v)
+}
+
+func makeConn(endpoint string, logger func() common.Logger) (*WsConnection, error) {
 	Dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  45 * time.Second,
 		EnableCompression: true, // important for huge size message
 	}
 
-	c, _, err := Dialer.Dial(getWsAPIEndpoint(), nil)
+	c, _, err := Dialer.Dial(endpoint, nil)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	c.SetReadLimit(wsReadLimit)
 	doneC := make(chan struct{})
 	stopC := make(chan struct{})
+	conn := &WsConnection{
+		Conn:      c,
+		Done:      doneC,
+		Stop:      stopC,
+		responses: newResponseMap(),
+	}
+
 	go func() {
 		// This function will exit either on error from
 		// websocket.Conn.ReadMessage or when the stopC channel is
 		// closed by the client.
+		// The pending requests are failed once doneC is closed.
+		defer conn.responses.Close()
 		defer close(doneC)
 		if WebsocketKeepalive {
 			keepAlive(c, WebsocketTimeout)
@@ -134,7 +188,8 @@ func makeConn() *WsConnection {
 			_, message, err := c.ReadMessage()
 			if err != nil {
 				if !adminForced {
-					fmt.Printf("error websocket %s, %v\n", getWsAPIEndpoint(), err)
+					conn.err = err
+					logger().Error("websocket error", "endpoint", endpoint, "error", err)
 				}
 				return
 			}
@@ -144,62 +199,167 @@ func makeConn() *WsConnection {
 				//fmt.Println("unmarshal error:", err)
 				return
 			}
-			if a := apiResponses.LoadAndDelete(res.Id); a != nil {
+			if a := conn.responses.LoadAndDelete(res.Id); a != nil {
 				a <- res
 				close(a)
+			} else {
+				logger().Warn("unexpected websocket api response, the request may be timed out", "endpoint", endpoint, "id", res.Id)
 			}
 		}
 	}()
 
-	return &WsConnection{
-		c, doneC, stopC,
+	return conn, nil
+}
+
+// SetReconnectPolicy set how the WebSocket API connection is re-established after it drops
+func (c *Client) SetReconnectPolicy(policy *common.ReconnectPolicy) *Client {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	c.reconnectPolicy = policy
+	return c
+}
+
+func (c *Client) getReconnectPolicy() *common.ReconnectPolicy {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.reconnectPolicy == nil {
+		return common.DefaultReconnectPolicy()
 	}
+	return c.reconnectPolicy
 }
 
-func (c *Client) handleDisconnected(ch chan struct{}) {
+// wsConn return the WebSocket API connection, nil if it is not connected
+func (c *Client) wsConn() *WsConnection {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.wsState != WsConnected {
+		return nil
+	}
+	return c.WsConn
+}
+
+func (c *Client) handleDisconnected(conn *WsConnection) {
 	go func() {
-		select {
-		case <-ch:
-		}
+		<-conn.Done
+
+		c.wsLock.Lock()
 		// if it is triggered by AdminClose, just ignore
-		if c.wsState == WsAdminClosing {
+		if c.wsState != WsConnected || c.WsConn != conn {
+			c.wsLock.Unlock()
 			return
 		}
 		c.wsState = WsConnecting
-		c.debug("disconnected, try reconnecting later...")
-
-		ticker := time.NewTicker(10 * time.Second)
-		defer ticker.Stop()
+		stopC := c.wsStopC
+		c.wsLock.Unlock()
+
+		policy := c.getReconnectPolicy()
+		c.logger().Warn("websocket api disconnected, try reconnecting later", "endpoint", c.WsURL, "error", conn.Err())
+		c.instrumentation().WsDisconnect(c.WsURL, conn.Err())
+		if policy.OnDisconnect != nil {
+			policy.OnDisconnect(conn.Err())
+		}
 
-		for {
-			// 使用 select 语句同时等待超时和上下文完成
-			select {
-			//case <-sm.ctx.Done():
-			//	// 上下文已完成，可能是超时或取消
-			//	log.Info("%s stream, context terminated...", sm.name)
-			//	return
-			case <-ticker.C:
-				conn := makeConn()
-				if conn != nil {
-					c.WsConn = conn
-					c.wsState = WsConnected
-					// well Done, break the loop
-					c.handleDisconnected(conn.Done)
-
-					c.debug("reconnected with %s", c.BaseURL)
-					return
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = policy.Wait(attempt, stopC)
+			if err != nil {
+				break
+			}
+			err = c.reconnect()
+			if err != common.ErrReconnectStopped {
+				c.instrumentation().WsReconnect(c.WsURL, attempt, err)
+			}
+			if err == nil {
+				c.logger().Info("websocket api reconnected", "endpoint", c.WsURL, "attempt", attempt)
+				if policy.OnReconnect != nil {
+					policy.OnReconnect(attempt)
 				}
-				c.debug("failed to connect to %s, retrying later...", c.BaseURL)
+				return
+			}
+			if err == common.ErrReconnectStopped {
+				break
 			}
+			c.logger().Warn("failed to reconnect websocket api", "endpoint", c.WsURL, "attempt", attempt, "error", err)
+			if !policy.ShouldRetry(attempt) {
+				break
+			}
+		}
+
+		if policy.Stopped(err) {
+			c.logger().Info("stop reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+			return
+		}
+		c.logger().Error("give up reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+		if policy.OnGiveUp != nil {
+			policy.OnGiveUp(err)
 		}
 	}()
 }
 
+// reconnect dial a new connection and restore the session of the dropped one
+// before using it
+func (c *Client) reconnect() error {
+	conn, err := makeConn(c.WsURL, c.logger)
+	if err != nil {
+		return err
+	}
+	c.wsLock.RLock()
+	wsSession := c.wsSession
+	c.wsLock.RUnlock()
+	if wsSession {
+		_, err = c.logonSession(context.Background(), conn)
+		if err != nil {
+			close(conn.Stop)
+			return err
+		}
+	}
+
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.wsState != WsConnecting {
+		close(conn.Stop)
+		return common.ErrReconnectStopped
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn)
+	return nil
+}
+
+// Connect dial the WebSocket API connection of the client, if it has an
+// endpoint and is not connected yet
+func (c *Client) Connect() error {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.WsURL == "" || c.wsState != WsInit {
+		return nil
+	}
+	conn, err := makeConn(c.WsURL, c.logger)
+	if err != nil {
+		return err
+	}
+	if c.wsStopC == nil {
+		c.wsStopC = make(chan struct{})
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn)
+	return nil
+}
+
+// Close close the WebSocket API connection and stop reconnecting
 func (c *Client) Close() {
-	if c.wsState == WsConnected {
-		c.wsState = WsAdminClosing
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	switch c.wsState {
+	case WsConnected:
 		close(c.WsConn.Stop)
+	case WsConnecting:
+	default:
+		return
 	}
+	c.wsState = WsAdminClosing
+	close(c.wsStopC)
 }
 
 // Encode encodes the values into “URL encoded” form
@@ -226,7 +386,7 @@ func (v params) Encode() string {
 	return buf.String()
 }
 
-func (c *Client) parseWsRequest(r *request, opts ...RequestOption) (err error) {
+func (c *Client) parseWsRequest(conn *WsConnection, r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
 		opt(r)
@@ -246,25 +406,39 @@ func (c *Client) parseWsRequest(r *request, opts ...RequestOption) (err error) {
 	}
 
 	if r.secType == secTypeSigned {
-		r.wsParams[timestampKey] = currentTimestamp() - c.TimeOffset
+		r.wsParams[timestampKey] = currentTimestamp() - c.timeOffset()
+	}
+	// requests on an authenticated session are signed by session.logon already
+	if r.secType == secTypeSigned && (r.wsMethod == sessionLogonMethod || !conn.LoggedOn()) {
 		r.wsParams[apiKey] = c.APIKey
 		raw := r.wsParams.Encode()
 
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
-		r.wsParams[signatureKey] = fmt.Sprintf("%x", mac.Sum(nil))
+		r.wsParams[signatureKey] = sig
 	}
 
-	c.debug("ws-method: %s, params: %v", r.wsMethod, r.wsParams)
+	c.logger().Debug("ws request params", "method", r.wsMethod, "params", r.wsParams)
 
 	return nil
 }
 
 func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
-	err := c.parseWsRequest(r, opts...)
+	conn := r.wsConn
+	if conn == nil {
+		conn = c.wsConn()
+	}
+	if conn == nil {
+		return nil, nil, ErrWsAPINotConnected
+	}
+	return c.callWsConnAPI(ctx, conn, r, opts...)
+}
+
+// callWsConnAPI send the request over the given WebSocket API connection
+func (c *Client) callWsConnAPI(ctx context.Context, conn *WsConnection, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	err := c.parseWsRequest(conn, r, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -280,11 +454,13 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 		req["params"] = r.wsParams
 	}
 
-	c.debug("request: %#v", req)
+	c.logger().Debug("ws request", "request", req)
 
-	apiResponses.Set(id, ch)
+	if !conn.responses.Set(id, ch) {
+		return nil, nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+	}
 	c.Lock()
-	err = c.WsConn.WriteJSON(req)
+	err = conn.WriteJSON(req)
 	c.Unlock()
 
 	//f := c.do
@@ -293,7 +469,8 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 	//}
 	//res, err := f(req)
 	if err != nil {
-		return nil, nil, err
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: err}
 	}
 
 	// timeout context
@@ -302,22 +479,68 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 
 	select {
 	case <-ctx.Done():
-		return nil, nil, ctx.Err()
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx.Err()}
 
 	case <-ctx2.Done():
-		return nil, nil, ctx2.Err()
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx2.Err()}
 
-	case res := <-ch:
-		c.debug("response status code: %d", res.Status)
-		c.debug("response raw: %s", string(res.Result))
-		c.debug("response: %#v", res.Error)
+	case res, ok := <-ch:
+		if !ok {
+			return nil, nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+		}
+		c.logger().Debug("ws response", "status", res.Status, "result", string(res.Result), "error", res.Error)
 
+		r.statusCode = res.Status
+		r.rateLimits = res.rateLimits()
+		if c.RateLimiter != nil {
+			c.updateWsRateLimits(res)
+		}
 		if res.Status >= http.StatusBadRequest {
 			apiErr := new(common.APIError)
 			apiErr.Code = res.Error.Code
 			apiErr.Message = res.Error.Msg
+			apiErr.StatusCode = res.Status
+			apiErr.Method = r.wsMethod
+			apiErr.Endpoint = r.endpoint
+			apiErr.RateLimits = r.rateLimits
+			if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+				apiErr.RetryAfter = res.retryAfter()
+			}
 			return nil, nil, apiErr
 		}
 		return []byte(res.Result), RateLimitsFromWsResponse(res), nil
 	}
 }
+
+// updateWsRateLimits update the rate limiter with the usage and the backoff
+// of a WebSocket API response
+func (c *Client) updateWsRateLimits(res *WsApiResponse) {
+	c.RateLimiter.Update(res.rateLimits()...)
+	if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+		c.RateLimiter.Backoff(res.retryAfter())
+	}
+}
+
+// rateLimits return the usage reported by the response
+func (res *WsApiResponse) rateLimits() []common.RateLimit {
+	limits := make([]common.RateLimit, 0, len(res.RateLimits))
+	for _, l := range res.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, l.IntervalNum),
+			Limit:    l.Limit,
+			Count:    l.Count,
+		})
+	}
+	return limits
+}
+
+// retryAfter return the backoff required by a 429 or 418 response
+func (res *WsApiResponse) retryAfter() time.Duration {
+	if res.Error.Data.RetryAfter > 0 {
+		return time.Until(time.UnixMilli(res.Error.Data.RetryAfter))
+	}
+	return common.DefaultRateLimitBackoff
+}
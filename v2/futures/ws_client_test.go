@@ -0,0 +1,250 @@
+package futures
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPIReply struct {
+	Id     string           `json:"id"`
+	Status int              `json:"status"`
+	Result json.RawMessage  `json:"result,omitempty"`
+	Error  *common.APIError `json:"error,omitempty"`
+}
+
+type wsAPIRequest struct {
+	Id     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// wsAPIServer is a fake WebSocket API server, the response of each request is
+// generated by handle, nil for no response at all.
+type wsAPIServer struct {
+	*httptest.Server
+	sync.Mutex
+	requests []*wsAPIRequest
+	conns    []*websocket.Conn
+	handle   func(req *wsAPIRequest) *wsAPIReply
+}
+
+func newWsAPIServer(handle func(req *wsAPIRequest) *wsAPIReply) *wsAPIServer {
+	s := &wsAPIServer{handle: handle}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.Lock()
+		s.conns = append(s.conns, conn)
+		s.Unlock()
+		defer conn.Close()
+		for {
+			req := new(wsAPIRequest)
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			s.Lock()
+			s.requests = append(s.requests, req)
+			handle := s.handle
+			s.Unlock()
+			if res := handle(req); res != nil {
+				res.Id = req.Id
+				data, _ := json.Marshal(res)
+				s.Lock()
+				err := conn.WriteMessage(websocket.TextMessage, data)
+				s.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}))
+	return s
+}
+
+func (s *wsAPIServer) setHandle(handle func(req *wsAPIRequest) *wsAPIReply) {
+	s.Lock()
+	defer s.Unlock()
+	s.handle = handle
+}
+
+// wsURL return the websocket url of the server
+func (s *wsAPIServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+func (s *wsAPIServer) lastRequest() *wsAPIRequest {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// push send the message to all the connections
+func (s *wsAPIServer) push(message string) {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+}
+
+// dropConnections close all the connections accepted so far
+func (s *wsAPIServer) dropConnections() {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func wsAPIResult(result string) *wsAPIReply {
+	return &wsAPIReply{Status: http.StatusOK, Result: json.RawMessage(result)}
+}
+
+func wsAPIError(status int, code int64, msg string) *wsAPIReply {
+	return &wsAPIReply{Status: status, Error: &common.APIError{Code: code, Message: msg}}
+}
+
+// newWsAPIClient create a client connected to the fake WebSocket API server
+func newWsAPIClient(s *wsAPIServer) *Client {
+	url := WsAPIMainURL
+	defer func() { WsAPIMainURL = url }()
+	WsAPIMainURL = s.wsURL()
+	return NewClient("dummyAPIKey", "dummySecretKey")
+}
+
+type wsClientTestSuite struct {
+	suite.Suite
+}
+
+func TestWsClient(t *testing.T) {
+	suite.Run(t, new(wsClientTestSuite))
+}
+
+func (s *wsClientTestSuite) TestPendingRequestFailedOnDisconnect() {
+	r := s.Require()
+	received := make(chan struct{})
+	var once sync.Once
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		once.Do(func() { close(received) })
+		return nil
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+
+	go func() {
+		<-received
+		server.dropConnections()
+	}()
+	start := time.Now()
+	_, _, err := client.callWsAPI(newContext(), &request{wsMethod: "time"})
+	r.Less(time.Since(start), 5*time.Second)
+	var closedErr *common.WsConnectionClosedError
+	r.True(errors.As(err, &closedErr))
+	r.Equal("time", closedErr.Method)
+	r.Error(closedErr.Err)
+}
+
+func (s *wsClientTestSuite) TestReconnect() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"apiKey": "dummyAPIKey"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	disconnected, reconnected, gaveUp := make(chan error, 1), make(chan int, 1), make(chan error, 1)
+	client.SetReconnectPolicy(&common.ReconnectPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxAttempts:    3,
+		OnDisconnect:   func(err error) { disconnected <- err },
+		OnReconnect:    func(attempts int) { reconnected <- attempts },
+		OnGiveUp:       func(err error) { gaveUp <- err },
+	})
+	_, err := client.NewSessionLogonService().Do(newContext())
+	r.NoError(err)
+
+	server.dropConnections()
+	r.Error(<-disconnected)
+	select {
+	case attempts := <-reconnected:
+		r.Equal(1, attempts)
+	case <-time.After(time.Second):
+		r.FailNow("OnReconnect not called")
+	}
+	r.True(client.WsConnected())
+	r.Equal(sessionLogonMethod, server.lastRequest().Method)
+
+	server.dropConnections()
+	server.Close()
+	select {
+	case err := <-gaveUp:
+		r.Error(err)
+	case <-time.After(time.Second):
+		r.FailNow("OnGiveUp not called")
+	}
+	r.False(client.WsConnected())
+}
+
+func (s *wsClientTestSuite) TestCloseStopsReconnecting() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+
+	disconnected, gaveUp := make(chan error, 1), make(chan error, 1)
+	client.SetReconnectPolicy(&common.ReconnectPolicy{
+		InitialBackoff: time.Hour,
+		OnDisconnect:   func(err error) { disconnected <- err },
+		OnGiveUp:       func(err error) { gaveUp <- err },
+	})
+	server.dropConnections()
+	r.Error(<-disconnected)
+	client.Close()
+	select {
+	case err := <-gaveUp:
+		r.FailNow("OnGiveUp called after Close", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	r.False(client.WsConnected())
+}
+
+func (s *wsClientTestSuite) TestModifyOrder() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"orderId": 328971409, "symbol": "BTCUSDT", "status": "NEW", "price": "43187.00", "origQty": "0.100"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	res, err := client.NewModifyOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		OrderID(328971409).Quantity("0.100").Price("43187.00").Do(newContext())
+	r.NoError(err)
+	r.Equal(int64(328971409), res.OrderID)
+	r.Equal("43187.00", res.Price)
+
+	req := server.lastRequest()
+	r.Equal("order.modify", req.Method)
+	r.Equal("BTCUSDT", req.Params["symbol"])
+	r.Equal("43187.00", req.Params["price"])
+}
@@ -49,7 +49,7 @@ func (s *FuturesTransferService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +121,7 @@ func (s *ListFuturesTransferService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res = new(FuturesTransferHistory)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +82,7 @@ func (s *InterestHistoryService) Do(ctx context.Context) (*InterestHistory, erro
 		return nil, err
 	}
 	res := new(InterestHistory)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (s *InternalUniversalTransferService) Do(ctx context.Context, opts ...Reque
 	}
 
 	res := &InternalUniversalTransferResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -180,7 +180,7 @@ func (s *InternalUniversalTransferHistoryService) Do(ctx context.Context, opts .
 		return
 	}
 	res.Result = make([]*InternalUniversalTransfer, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -22,7 +22,7 @@ func (s *GetAllLiquidityPoolService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res := []*LiquidityPool{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -75,7 +75,7 @@ func (s *GetLiquidityPoolDetailService) Do(ctx context.Context) ([]*LiquidityPoo
 		return nil, err
 	}
 	res := []*LiquidityPoolDetail{}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +144,7 @@ func (s *AddLiquidityPreviewService) Do(ctx context.Context) (*AddLiquidityPrevi
 		return nil, err
 	}
 	res := &AddLiquidityPreviewResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +204,7 @@ func (s *GetSwapQuoteService) Do(ctx context.Context) (*GetSwapQuoteResponse, er
 		return nil, err
 	}
 	res := &GetSwapQuoteResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +258,7 @@ func (s *SwapService) Do(ctx context.Context) (*SwapResponse, error) {
 		return nil, err
 	}
 	res := &SwapResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -365,7 +365,7 @@ func (s *GetUserSwapRecordsService) Do(ctx context.Context) ([]*SwapRecord, erro
 		return nil, err
 	}
 	res := []*SwapRecord{}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -427,7 +427,7 @@ func (s *AddLiquidityService) Do(ctx context.Context) (*AddLiquidityResponse, er
 		return nil, err
 	}
 	res := &AddLiquidityResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -491,7 +491,7 @@ func (s *RemoveLiquidityService) Do(ctx context.Context) (*RemoveLiquidityRespon
 		return nil, err
 	}
 	res := &RemoveLiquidityResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -530,7 +530,7 @@ func (s *ClaimRewardService) Do(ctx context.Context) (*ClaimRewardResponse, erro
 		return nil, err
 	}
 	res := &ClaimRewardResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -624,7 +624,7 @@ func (s *QueryClaimedRewardHistoryService) Do(ctx context.Context) ([]*ClaimedRe
 		return nil, err
 	}
 	res := []*ClaimedRewardHistory{}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,8 @@ package binance
 import (
 	"context"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // CreateMarginOrderService create order
@@ -103,6 +105,21 @@ func (s *CreateMarginOrderService) SideEffectType(sideEffectType SideEffectType)
 
 // Do send request
 func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:        s.symbol,
+			Side:          string(s.side),
+			Market:        s.price == nil,
+			Algo:          s.orderType.isAlgo(),
+			Price:         s.price,
+			StopPrice:     s.stopPrice,
+			Quantity:      s.quantity,
+			QuoteQuantity: s.quoteOrderQty,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/sapi/v1/margin/order",
@@ -153,7 +170,7 @@ func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +247,7 @@ func (s *CancelMarginOrderService) Do(ctx context.Context, opts ...RequestOption
 		return nil, err
 	}
 	res = new(CancelMarginOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +310,7 @@ func (s *GetMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +355,7 @@ func (s *ListMarginOpenOrdersService) Do(ctx context.Context, opts ...RequestOpt
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -421,7 +438,7 @@ func (s *ListMarginOrdersService) Do(ctx context.Context, opts ...RequestOption)
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -555,8 +572,8 @@ func (s *CreateMarginOCOService) SideEffectType(sideEffectType SideEffectType) *
 	return s
 }
 
-func (s *CreateMarginOCOService) createOrder(ctx context.Context, opts ...RequestOption) (data []byte, err error) {
-	r := &request{
+func (s *CreateMarginOCOService) createOrder(ctx context.Context, opts ...RequestOption) (data []byte, r *request, err error) {
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: "/sapi/v1/margin/order/oco",
 		secType:  secTypeSigned,
@@ -606,19 +623,19 @@ func (s *CreateMarginOCOService) createOrder(ctx context.Context, opts ...Reques
 	r.setFormParams(m)
 	data, _, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *CreateMarginOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateMarginOCOResponse, err error) {
-	data, err := s.createOrder(ctx, opts...)
+	data, r, err := s.createOrder(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateMarginOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -731,7 +748,7 @@ func (s *CancelMarginOCOService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(CancelMarginOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,7 @@ func (s *MarginTransferService) Do(ctx context.Context, opts ...RequestOption) (
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (s *MarginLoanService) Do(ctx context.Context, opts ...RequestOption) (res
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +183,7 @@ func (s *MarginRepayService) Do(ctx context.Context, opts ...RequestOption) (res
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -265,7 +265,7 @@ func (s *ListMarginLoansService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(MarginLoanResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +361,7 @@ func (s *ListMarginRepaysService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(MarginRepayResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -415,7 +415,7 @@ func (s *GetIsolatedMarginAccountService) Do(ctx context.Context, opts ...Reques
 		return nil, err
 	}
 	res = new(IsolatedMarginAccount)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -489,7 +489,7 @@ func (s *GetMarginAccountService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(MarginAccount)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -543,7 +543,7 @@ func (s *GetMarginAssetService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = make([]*MarginAsset, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -585,7 +585,7 @@ func (s *GetMarginPairService) Do(ctx context.Context, opts ...RequestOption) (r
 		return nil, err
 	}
 	res = new(MarginPair)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -620,7 +620,7 @@ func (s *GetMarginAllPairsService) Do(ctx context.Context, opts ...RequestOption
 		return []*MarginAllPair{}, err
 	}
 	res = make([]*MarginAllPair, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*MarginAllPair{}, err
 	}
@@ -663,7 +663,7 @@ func (s *GetMarginPriceIndexService) Do(ctx context.Context, opts ...RequestOpti
 		return nil, err
 	}
 	res = new(MarginPriceIndex)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -752,7 +752,7 @@ func (s *ListMarginTradesService) Do(ctx context.Context, opts ...RequestOption)
 		return []*TradeV3{}, err
 	}
 	res = make([]*TradeV3, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*TradeV3{}, err
 	}
@@ -794,7 +794,7 @@ func (s *GetMaxBorrowableService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = new(MaxBorrowable)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -831,7 +831,7 @@ func (s *GetMaxTransferableService) Do(ctx context.Context, opts ...RequestOptio
 		return nil, err
 	}
 	res = new(MaxTransferable)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -869,7 +869,7 @@ func (s *StartIsolatedMarginUserStreamService) Do(ctx context.Context, opts ...R
 	if err != nil {
 		return "", err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return "", err
 	}
@@ -962,7 +962,7 @@ func (s *StartMarginUserStreamService) Do(ctx context.Context, opts ...RequestOp
 	if err != nil {
 		return "", err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return "", err
 	}
@@ -1037,7 +1037,7 @@ func (s *GetAllMarginAssetsService) Do(ctx context.Context, opts ...RequestOptio
 		return []*MarginAsset{}, err
 	}
 	res = make([]*MarginAsset, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*MarginAsset{}, err
 	}
@@ -1061,7 +1061,7 @@ func (s *GetIsolatedMarginAllPairsService) Do(ctx context.Context, opts ...Reque
 		return []*IsolatedMarginAllPair{}, err
 	}
 	res = make([]*IsolatedMarginAllPair, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*IsolatedMarginAllPair{}, err
 	}
@@ -1133,7 +1133,7 @@ func (s *IsolatedMarginTransferService) Do(ctx context.Context, opts ...RequestO
 		return nil, err
 	}
 	res = new(TransactionResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1183,7 +1183,7 @@ func (s *ListMarginLiquidationRecords) Do(ctx context.Context, opts ...RequestOp
 		return nil, err
 	}
 	res = new(MarginLiquidationRecords)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
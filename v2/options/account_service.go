@@ -0,0 +1,238 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetAccountService get account info
+type GetAccountService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res *Account, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/account",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Account)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Account define account info
+type Account struct {
+	Assets    []*AccountAsset `json:"asset"`
+	Greeks    []*AccountGreek `json:"greek"`
+	Time      int64           `json:"time"`
+	RiskLevel string          `json:"riskLevel"`
+}
+
+// AccountAsset define account asset
+type AccountAsset struct {
+	Asset         string `json:"asset"`
+	MarginBalance string `json:"marginBalance"`
+	Equity        string `json:"equity"`
+	Available     string `json:"available"`
+	Locked        string `json:"locked"`
+	UnrealizedPNL string `json:"unrealizedPNL"`
+}
+
+// AccountGreek define the greeks of the positions of an underlying
+type AccountGreek struct {
+	Underlying string `json:"underlying"`
+	Delta      string `json:"delta"`
+	Gamma      string `json:"gamma"`
+	Theta      string `json:"theta"`
+	Vega       string `json:"vega"`
+}
+
+// GetMarginAccountService get margin account info
+type GetMarginAccountService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetMarginAccountService) Do(ctx context.Context, opts ...RequestOption) (res *MarginAccount, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/marginAccount",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginAccount)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginAccount define margin account info
+type MarginAccount struct {
+	Assets      []*MarginAccountAsset `json:"asset"`
+	Greeks      []*AccountGreek       `json:"greek"`
+	Time        int64                 `json:"time"`
+	CanTrade    bool                  `json:"canTrade"`
+	CanDeposit  bool                  `json:"canDeposit"`
+	CanWithdraw bool                  `json:"canWithdraw"`
+	ReduceOnly  bool                  `json:"reduceOnly"`
+}
+
+// MarginAccountAsset define margin account asset
+type MarginAccountAsset struct {
+	Asset         string `json:"asset"`
+	MarginBalance string `json:"marginBalance"`
+	Equity        string `json:"equity"`
+	Available     string `json:"available"`
+	InitialMargin string `json:"initialMargin"`
+	MaintMargin   string `json:"maintMargin"`
+	UnrealizedPNL string `json:"unrealizedPNL"`
+	LpProfit      string `json:"lpProfit"`
+}
+
+// FundsTransferService transfer funds between the spot and the options account
+type FundsTransferService struct {
+	c            *Client
+	currency     string
+	transferType TransferType
+	amount       string
+}
+
+// Currency set currency
+func (s *FundsTransferService) Currency(currency string) *FundsTransferService {
+	s.currency = currency
+	return s
+}
+
+// Type set type, IN to the options account or OUT to the spot account
+func (s *FundsTransferService) Type(transferType TransferType) *FundsTransferService {
+	s.transferType = transferType
+	return s
+}
+
+// Amount set amount
+func (s *FundsTransferService) Amount(amount string) *FundsTransferService {
+	s.amount = amount
+	return s
+}
+
+// Do send request, return the id of the transfer
+func (s *FundsTransferService) Do(ctx context.Context, opts ...RequestOption) (tranID int64, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/eapi/v1/transfer",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"currency": s.currency,
+		"type":     s.transferType,
+		"amount":   s.amount,
+	})
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return 0, err
+	}
+	j, err := r.decodeJSON(data)
+	if err != nil {
+		return 0, err
+	}
+	// the id is returned either alone or wrapped in an object
+	if id, ok := j.CheckGet("tranId"); ok {
+		j = id
+	}
+	return j.Int64()
+}
+
+// ListBillsService list the account funding flows
+type ListBillsService struct {
+	c         *Client
+	currency  string
+	recordID  *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Currency set currency
+func (s *ListBillsService) Currency(currency string) *ListBillsService {
+	s.currency = currency
+	return s
+}
+
+// RecordID set recordId, the bills from this id are returned
+func (s *ListBillsService) RecordID(recordID int64) *ListBillsService {
+	s.recordID = &recordID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListBillsService) StartTime(startTime int64) *ListBillsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListBillsService) EndTime(endTime int64) *ListBillsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListBillsService) Limit(limit int) *ListBillsService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListBillsService) Do(ctx context.Context, opts ...RequestOption) (res []*Bill, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/bill",
+		secType:  secTypeSigned,
+	}
+	r.setParam("currency", s.currency)
+	if s.recordID != nil {
+		r.setParam("recordId", *s.recordID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Bill{}, err
+	}
+	res = make([]*Bill, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*Bill{}, err
+	}
+	return res, nil
+}
+
+// Bill define a funding flow of the account
+type Bill struct {
+	ID         int64  `json:"id"`
+	Asset      string `json:"asset"`
+	Amount     string `json:"amount"`
+	Type       string `json:"type"`
+	CreateDate int64  `json:"createDate"`
+}
@@ -0,0 +1,220 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type accountServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestAccountService(t *testing.T) {
+	suite.Run(t, new(accountServiceTestSuite))
+}
+
+func (s *accountServiceTestSuite) TestGetAccount() {
+	data := []byte(`{
+		"asset": [
+			{
+				"asset": "USDT",
+				"marginBalance": "1877.52214415",
+				"equity": "617.77711415",
+				"available": "0",
+				"locked": "2898.92389933",
+				"unrealizedPNL": "222.23697000"
+			}
+		],
+		"greek": [
+			{
+				"underlying": "BTCUSDT",
+				"delta": "-0.05",
+				"gamma": "-0.002",
+				"theta": "-0.05",
+				"vega": "-0.002"
+			}
+		],
+		"time": 1592449455993,
+		"riskLevel": "NORMAL"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetAccountService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&Account{
+		Assets: []*AccountAsset{
+			{
+				Asset:         "USDT",
+				MarginBalance: "1877.52214415",
+				Equity:        "617.77711415",
+				Available:     "0",
+				Locked:        "2898.92389933",
+				UnrealizedPNL: "222.23697000",
+			},
+		},
+		Greeks: []*AccountGreek{
+			{
+				Underlying: "BTCUSDT",
+				Delta:      "-0.05",
+				Gamma:      "-0.002",
+				Theta:      "-0.05",
+				Vega:       "-0.002",
+			},
+		},
+		Time:      1592449455993,
+		RiskLevel: "NORMAL",
+	}, res)
+}
+
+func (s *accountServiceTestSuite) TestGetMarginAccount() {
+	data := []byte(`{
+		"asset": [
+			{
+				"asset": "USDT",
+				"marginBalance": "10099.448",
+				"equity": "10094.44662",
+				"available": "8725.92524",
+				"initialMargin": "1084.52138",
+				"maintMargin": "151.00138",
+				"unrealizedPNL": "-5.00138",
+				"lpProfit": "-5.00138"
+			}
+		],
+		"greek": [
+			{
+				"underlying": "BTCUSDT",
+				"delta": "-0.05",
+				"gamma": "-0.002",
+				"theta": "-0.05",
+				"vega": "-0.002"
+			}
+		],
+		"time": 1592449455993,
+		"canTrade": true,
+		"canDeposit": true,
+		"canWithdraw": true,
+		"reduceOnly": false
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetMarginAccountService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Assets, 1)
+	r.Equal(&MarginAccountAsset{
+		Asset:         "USDT",
+		MarginBalance: "10099.448",
+		Equity:        "10094.44662",
+		Available:     "8725.92524",
+		InitialMargin: "1084.52138",
+		MaintMargin:   "151.00138",
+		UnrealizedPNL: "-5.00138",
+		LpProfit:      "-5.00138",
+	}, res.Assets[0])
+	r.Len(res.Greeks, 1)
+	r.Equal("BTCUSDT", res.Greeks[0].Underlying)
+	r.Equal(int64(1592449455993), res.Time)
+	r.True(res.CanTrade)
+	r.True(res.CanDeposit)
+	r.True(res.CanWithdraw)
+	r.False(res.ReduceOnly)
+}
+
+func (s *accountServiceTestSuite) TestFundsTransfer() {
+	data := []byte(`{"tranId": 7620120}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"currency": "USDT",
+			"type":     TransferTypeIn,
+			"amount":   "100",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	tranID, err := s.client.NewFundsTransferService().Currency("USDT").
+		Type(TransferTypeIn).Amount("100").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(7620120), tranID)
+}
+
+func (s *accountServiceTestSuite) TestFundsTransferBareID() {
+	data := []byte(`7620121`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"currency": "USDT",
+			"type":     TransferTypeOut,
+			"amount":   "50",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	tranID, err := s.client.NewFundsTransferService().Currency("USDT").
+		Type(TransferTypeOut).Amount("50").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(7620121), tranID)
+}
+
+func (s *accountServiceTestSuite) TestListBills() {
+	data := []byte(`[
+		{
+			"id": 1125899906842624000,
+			"asset": "USDT",
+			"amount": "-0.552",
+			"type": "FEE",
+			"createDate": 1592449456000
+		},
+		{
+			"id": 1125899906842624001,
+			"asset": "USDT",
+			"amount": "100",
+			"type": "CONTRACT",
+			"createDate": 1592449457000
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"currency":  "USDT",
+			"recordId":  1125899906842624000,
+			"startTime": 1592449455000,
+			"endTime":   1592449458000,
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListBillsService().Currency("USDT").RecordID(1125899906842624000).
+		StartTime(1592449455000).EndTime(1592449458000).Limit(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*Bill{
+		{
+			ID:         1125899906842624000,
+			Asset:      "USDT",
+			Amount:     "-0.552",
+			Type:       "FEE",
+			CreateDate: 1592449456000,
+		},
+		{
+			ID:         1125899906842624001,
+			Asset:      "USDT",
+			Amount:     "100",
+			Type:       "CONTRACT",
+			CreateDate: 1592449457000,
+		},
+	}, res)
+}
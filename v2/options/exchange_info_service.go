@@ -2,8 +2,9 @@ package options
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // ExchangeInfoService exchange info service
@@ -23,7 +24,7 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(ExchangeInfo)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -141,3 +142,44 @@ func (s *OptionSymbol) PriceFilter() *PriceFilter {
 	}
 	return nil
 }
+
+// OrderFilters return the filters of symbol checked by common.OrderValidator
+func (s *OptionSymbol) OrderFilters() *common.SymbolFilters {
+	f := &common.SymbolFilters{Symbol: s.Symbol}
+	if pf := s.PriceFilter(); pf != nil {
+		f.MinPrice = common.DecimalFromString(pf.MinPrice)
+		f.MaxPrice = common.DecimalFromString(pf.MaxPrice)
+		f.TickSize = common.DecimalFromString(pf.TickSize)
+	}
+	if lf := s.LotSizeFilter(); lf != nil {
+		f.MinQuantity = common.DecimalFromString(lf.MinQuantity)
+		f.MaxQuantity = common.DecimalFromString(lf.MaxQuantity)
+		f.StepSize = common.DecimalFromString(lf.StepSize)
+	}
+	return f
+}
+
+// NewOrderValidator create an order validator with the filters of the option
+// symbols of the exchange info, set it as Client.OrderValidator to check the
+// orders before they are sent
+func NewOrderValidator(info *ExchangeInfo) *common.OrderValidator {
+	v := common.NewOrderValidator()
+	for i := range info.OptionSymbols {
+		v.SetFilters(info.OptionSymbols[i].OrderFilters())
+	}
+	return v
+}
+
+// NewRateLimitGovernor create a rate limit governor with the limits of the
+// exchange info, set it as Client.RateLimiter to keep the calls under them
+func NewRateLimitGovernor(info *ExchangeInfo) *common.RateLimitGovernor {
+	limits := make([]common.RateLimit, 0, len(info.RateLimits))
+	for _, l := range info.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, int(l.IntervalNum)),
+			Limit:    int(l.Limit),
+		})
+	}
+	return common.NewRateLimitGovernor(limits...)
+}
@@ -0,0 +1,79 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListExerciseHistoryService list the historical exercise results of the
+// expired symbols
+type ListExerciseHistoryService struct {
+	c          *Client
+	underlying *string
+	startTime  *int64
+	endTime    *int64
+	limit      *int
+}
+
+// Underlying set underlying, e.g. BTCUSDT
+func (s *ListExerciseHistoryService) Underlying(underlying string) *ListExerciseHistoryService {
+	s.underlying = &underlying
+	return s
+}
+
+// StartTime set startTime
+func (s *ListExerciseHistoryService) StartTime(startTime int64) *ListExerciseHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListExerciseHistoryService) EndTime(endTime int64) *ListExerciseHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListExerciseHistoryService) Limit(limit int) *ListExerciseHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListExerciseHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*ExerciseHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/exerciseHistory",
+	}
+	if s.underlying != nil {
+		r.setParam("underlying", *s.underlying)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ExerciseHistory{}, err
+	}
+	res = make([]*ExerciseHistory, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*ExerciseHistory{}, err
+	}
+	return res, nil
+}
+
+// ExerciseHistory define the exercise result of an expired symbol
+type ExerciseHistory struct {
+	Symbol          string `json:"symbol"`
+	StrikePrice     string `json:"strikePrice"`
+	RealStrikePrice string `json:"realStrikePrice"`
+	ExpiryDate      int64  `json:"expiryDate"`
+	StrikeResult    string `json:"strikeResult"`
+}
@@ -0,0 +1,51 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type exerciseHistoryServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestExerciseHistoryService(t *testing.T) {
+	suite.Run(t, new(exerciseHistoryServiceTestSuite))
+}
+
+func (s *exerciseHistoryServiceTestSuite) TestListExerciseHistory() {
+	data := []byte(`[
+		{
+			"symbol": "BTC-220121-60000-P",
+			"strikePrice": "60000",
+			"realStrikePrice": "38844.69652571",
+			"expiryDate": 1642752000000,
+			"strikeResult": "REALISTIC_VALUE_STRICKEN"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"underlying": "BTCUSDT",
+			"startTime":  1642700000000,
+			"endTime":    1642800000000,
+			"limit":      100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListExerciseHistoryService().Underlying("BTCUSDT").
+		StartTime(1642700000000).EndTime(1642800000000).Limit(100).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*ExerciseHistory{
+		{
+			Symbol:          "BTC-220121-60000-P",
+			StrikePrice:     "60000",
+			RealStrikePrice: "38844.69652571",
+			ExpiryDate:      1642752000000,
+			StrikeResult:    "REALISTIC_VALUE_STRICKEN",
+		},
+	}, res)
+}
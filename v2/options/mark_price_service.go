@@ -0,0 +1,92 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// MarkPriceService get the mark price and the greeks of the symbols
+type MarkPriceService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *MarkPriceService) Symbol(symbol string) *MarkPriceService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *MarkPriceService) Do(ctx context.Context, opts ...RequestOption) (res []*MarkPrice, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/mark",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarkPrice{}, err
+	}
+	res = make([]*MarkPrice, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*MarkPrice{}, err
+	}
+	return res, nil
+}
+
+// MarkPrice define the mark price, implied volatility and greeks of a symbol
+type MarkPrice struct {
+	Symbol           string `json:"symbol"`
+	MarkPrice        string `json:"markPrice"`
+	BidIV            string `json:"bidIV"`
+	AskIV            string `json:"askIV"`
+	MarkIV           string `json:"markIV"`
+	Delta            string `json:"delta"`
+	Theta            string `json:"theta"`
+	Gamma            string `json:"gamma"`
+	Vega             string `json:"vega"`
+	HighPriceLimit   string `json:"highPriceLimit"`
+	LowPriceLimit    string `json:"lowPriceLimit"`
+	RiskFreeInterest string `json:"riskFreeInterest"`
+}
+
+// IndexPriceService get the spot index price of an underlying
+type IndexPriceService struct {
+	c          *Client
+	underlying string
+}
+
+// Underlying set underlying, e.g. BTCUSDT
+func (s *IndexPriceService) Underlying(underlying string) *IndexPriceService {
+	s.underlying = underlying
+	return s
+}
+
+// Do send request
+func (s *IndexPriceService) Do(ctx context.Context, opts ...RequestOption) (res *IndexPrice, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/index",
+	}
+	r.setParam("underlying", s.underlying)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(IndexPrice)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IndexPrice define index price info
+type IndexPrice struct {
+	Time       int64  `json:"time"`
+	IndexPrice string `json:"indexPrice"`
+}
@@ -0,0 +1,54 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// OpenInterestService get the open interest of the symbols of an underlying
+// asset expiring at the same date
+type OpenInterestService struct {
+	c               *Client
+	underlyingAsset string
+	expiration      string
+}
+
+// UnderlyingAsset set underlyingAsset, e.g. ETH
+func (s *OpenInterestService) UnderlyingAsset(underlyingAsset string) *OpenInterestService {
+	s.underlyingAsset = underlyingAsset
+	return s
+}
+
+// Expiration set expiration, e.g. 221225
+func (s *OpenInterestService) Expiration(expiration string) *OpenInterestService {
+	s.expiration = expiration
+	return s
+}
+
+// Do send request
+func (s *OpenInterestService) Do(ctx context.Context, opts ...RequestOption) (res []*OpenInterest, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/openInterest",
+	}
+	r.setParam("underlyingAsset", s.underlyingAsset)
+	r.setParam("expiration", s.expiration)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OpenInterest{}, err
+	}
+	res = make([]*OpenInterest, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*OpenInterest{}, err
+	}
+	return res, nil
+}
+
+// OpenInterest define open interest info
+type OpenInterest struct {
+	Symbol             string `json:"symbol"`
+	SumOpenInterest    string `json:"sumOpenInterest"`
+	SumOpenInterestUsd string `json:"sumOpenInterestUsd"`
+	Timestamp          string `json:"timestamp"`
+}
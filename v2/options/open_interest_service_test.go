@@ -0,0 +1,47 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type openInterestServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestOpenInterestService(t *testing.T) {
+	suite.Run(t, new(openInterestServiceTestSuite))
+}
+
+func (s *openInterestServiceTestSuite) TestOpenInterest() {
+	data := []byte(`[
+		{
+			"symbol": "ETH-221119-1175-P",
+			"sumOpenInterest": "4.01",
+			"sumOpenInterestUsd": "4880.2985615624",
+			"timestamp": "1668754020000"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"underlyingAsset": "ETH",
+			"expiration":      "221119",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewOpenInterestService().UnderlyingAsset("ETH").
+		Expiration("221119").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*OpenInterest{
+		{
+			Symbol:             "ETH-221119-1175-P",
+			SumOpenInterest:    "4.01",
+			SumOpenInterestUsd: "4880.2985615624",
+			Timestamp:          "1668754020000",
+		},
+	}, res)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // CreateOrderService create order
@@ -90,9 +92,21 @@ func (s *CreateOrderService) IsMmp(isMmp bool) *CreateOrderService {
 	return s
 }
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, r *request, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:   s.symbol,
+			Side:     string(s.side),
+			Market:   s.price == nil,
+			Price:    s.price,
+			Quantity: &s.quantity,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 
-	r := &request{
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
@@ -125,19 +139,19 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	r.setFormParams(m)
 	data, header, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, r, err
 	}
-	return data, header, nil
+	return data, header, r, nil
 }
 
 // Do send request
 func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
-	data, header, err := s.createOrder(ctx, "/eapi/v1/order", opts...)
+	data, header, r, err := s.createOrder(ctx, "/eapi/v1/order", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	res.RateLimitOrder10s = header.Get("X-Mbx-Order-Count-10s")
 	res.RateLimitOrder1m = header.Get("X-Mbx-Order-Count-1m")
 
@@ -241,7 +255,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -293,7 +307,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +385,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -497,7 +511,7 @@ func (s *CancelMultiplesOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return nil, err
 	}
 	res = make([]*CancelSingleOrderResponse, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*CancelSingleOrderResponse{}, err
 	}
@@ -587,7 +601,7 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 
 	rawMessages := make([]*json.RawMessage, 0)
 
-	err = json.Unmarshal(data, &rawMessages)
+	err = r.decode(data, &rawMessages)
 
 	if err != nil {
 		return &CreateBatchOrdersResponse{}, err
@@ -0,0 +1,142 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListPositionsService list the positions of the account
+type ListPositionsService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *ListPositionsService) Symbol(symbol string) *ListPositionsService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *ListPositionsService) Do(ctx context.Context, opts ...RequestOption) (res []*Position, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/position",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Position{}, err
+	}
+	res = make([]*Position, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*Position{}, err
+	}
+	return res, nil
+}
+
+// Position define position info
+type Position struct {
+	EntryPrice        string           `json:"entryPrice"`
+	Symbol            string           `json:"symbol"`
+	Side              PositionSideType `json:"side"`
+	Quantity          string           `json:"quantity"`
+	ReducibleQuantity string           `json:"reducibleQty"`
+	MarkValue         string           `json:"markValue"`
+	Ror               string           `json:"ror"`
+	UnrealizedPNL     string           `json:"unrealizedPNL"`
+	MarkPrice         string           `json:"markPrice"`
+	StrikePrice       string           `json:"strikePrice"`
+	PositionCost      string           `json:"positionCost"`
+	ExpiryDate        int64            `json:"expiryDate"`
+	PriceScale        int              `json:"priceScale"`
+	QuantityScale     int              `json:"quantityScale"`
+	OptionSide        OptionSideType   `json:"optionSide"`
+	QuoteAsset        string           `json:"quoteAsset"`
+}
+
+// ListExerciseRecordsService list the exercise records of the account
+type ListExerciseRecordsService struct {
+	c         *Client
+	symbol    *string
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *ListExerciseRecordsService) Symbol(symbol string) *ListExerciseRecordsService {
+	s.symbol = &symbol
+	return s
+}
+
+// StartTime set startTime
+func (s *ListExerciseRecordsService) StartTime(startTime int64) *ListExerciseRecordsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListExerciseRecordsService) EndTime(endTime int64) *ListExerciseRecordsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListExerciseRecordsService) Limit(limit int) *ListExerciseRecordsService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListExerciseRecordsService) Do(ctx context.Context, opts ...RequestOption) (res []*ExerciseRecord, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/exerciseRecord",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ExerciseRecord{}, err
+	}
+	res = make([]*ExerciseRecord, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*ExerciseRecord{}, err
+	}
+	return res, nil
+}
+
+// ExerciseRecord define an exercise record of the account
+type ExerciseRecord struct {
+	ID            string           `json:"id"`
+	Currency      string           `json:"currency"`
+	Symbol        string           `json:"symbol"`
+	ExercisePrice string           `json:"exercisePrice"`
+	MarkPrice     string           `json:"markPrice"`
+	Quantity      string           `json:"quantity"`
+	Amount        string           `json:"amount"`
+	Fee           string           `json:"fee"`
+	CreateDate    int64            `json:"createDate"`
+	PriceScale    int              `json:"priceScale"`
+	QuantityScale int              `json:"quantityScale"`
+	OptionSide    OptionSideType   `json:"optionSide"`
+	PositionSide  PositionSideType `json:"positionSide"`
+	QuoteAsset    string           `json:"quoteAsset"`
+}
@@ -0,0 +1,122 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type positionServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestPositionService(t *testing.T) {
+	suite.Run(t, new(positionServiceTestSuite))
+}
+
+func (s *positionServiceTestSuite) TestListPositions() {
+	data := []byte(`[
+		{
+			"entryPrice": "1000",
+			"symbol": "BTC-200730-9000-C",
+			"side": "SHORT",
+			"quantity": "-0.1",
+			"reducibleQty": "0",
+			"markValue": "105.00138",
+			"ror": "-0.05",
+			"unrealizedPNL": "-5.00138",
+			"markPrice": "1050.0138",
+			"strikePrice": "9000",
+			"positionCost": "1000.0000",
+			"expiryDate": 1593511200000,
+			"priceScale": 2,
+			"quantityScale": 2,
+			"optionSide": "CALL",
+			"quoteAsset": "USDT"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	symbol := "BTC-200730-9000-C"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", symbol)
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListPositionsService().Symbol(symbol).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*Position{
+		{
+			EntryPrice:        "1000",
+			Symbol:            symbol,
+			Side:              PositionSideTypeShort,
+			Quantity:          "-0.1",
+			ReducibleQuantity: "0",
+			MarkValue:         "105.00138",
+			Ror:               "-0.05",
+			UnrealizedPNL:     "-5.00138",
+			MarkPrice:         "1050.0138",
+			StrikePrice:       "9000",
+			PositionCost:      "1000.0000",
+			ExpiryDate:        1593511200000,
+			PriceScale:        2,
+			QuantityScale:     2,
+			OptionSide:        OptionSideTypeCall,
+			QuoteAsset:        "USDT",
+		},
+	}, res)
+}
+
+func (s *positionServiceTestSuite) TestListExerciseRecords() {
+	data := []byte(`[
+		{
+			"id": "1125899906842624000",
+			"currency": "USDT",
+			"symbol": "BTC-220721-25000-C",
+			"exercisePrice": "25000.00000000",
+			"markPrice": "25000.00000000",
+			"quantity": "1.00000000",
+			"amount": "0.00000000",
+			"fee": "0.00000000",
+			"createDate": 1658361600000,
+			"priceScale": 2,
+			"quantityScale": 2,
+			"optionSide": "CALL",
+			"positionSide": "LONG",
+			"quoteAsset": "USDT"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":    "BTC-220721-25000-C",
+			"startTime": 1658361500000,
+			"endTime":   1658361700000,
+			"limit":     100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListExerciseRecordsService().Symbol("BTC-220721-25000-C").
+		StartTime(1658361500000).EndTime(1658361700000).Limit(100).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*ExerciseRecord{
+		{
+			ID:            "1125899906842624000",
+			Currency:      "USDT",
+			Symbol:        "BTC-220721-25000-C",
+			ExercisePrice: "25000.00000000",
+			MarkPrice:     "25000.00000000",
+			Quantity:      "1.00000000",
+			Amount:        "0.00000000",
+			Fee:           "0.00000000",
+			CreateDate:    1658361600000,
+			PriceScale:    2,
+			QuantityScale: 2,
+			OptionSide:    OptionSideTypeCall,
+			PositionSide:  PositionSideTypeLong,
+			QuoteAsset:    "USDT",
+		},
+	}, res)
+}
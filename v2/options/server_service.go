@@ -0,0 +1,72 @@
+package options
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// PingService ping server
+type PingService struct {
+	c *Client
+}
+
+// Do send request
+func (s *PingService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/ping",
+	}
+	_, _, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// ServerTimeService get server time
+type ServerTimeService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ServerTimeService) Do(ctx context.Context, opts ...RequestOption) (serverTime int64, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/time",
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return 0, err
+	}
+	j, err := r.decodeJSON(data)
+	if err != nil {
+		return 0, err
+	}
+	serverTime = j.Get("serverTime").MustInt64()
+	return serverTime, nil
+}
+
+// SetServerTimeService set server time
+type SetServerTimeService struct {
+	c *Client
+}
+
+// Do send request
+func (s *SetServerTimeService) Do(ctx context.Context, opts ...RequestOption) (timeOffset int64, err error) {
+	serverTime, err := s.c.NewServerTimeService().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	timeOffset = currentTimestamp() - serverTime
+	s.c.setTimeOffset(timeOffset)
+	return timeOffset, nil
+}
+
+// NewTimeSync create a TimeSync which samples the server time of the client,
+// to be set as its TimeSync
+func (c *Client) NewTimeSync() *common.TimeSync {
+	return &common.TimeSync{
+		ServerTime: func(ctx context.Context) (int64, error) {
+			return c.NewServerTimeService().Do(ctx)
+		},
+	}
+}
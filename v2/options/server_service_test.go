@@ -0,0 +1,61 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type serverServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestServerService(t *testing.T) {
+	suite.Run(t, new(serverServiceTestSuite))
+}
+
+func (s *serverServiceTestSuite) TestPing() {
+	data := []byte(`{}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	err := s.client.NewPingService().Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *serverServiceTestSuite) TestServerTime() {
+	data := []byte(`{
+        "serverTime": 1499827319559
+    }`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	serverTime, err := s.client.NewServerTimeService().Do(newContext())
+	s.r().NoError(err)
+	s.r().EqualValues(1499827319559, serverTime)
+}
+
+func (s *serverServiceTestSuite) TestTimeSync() {
+	data := []byte(`{
+        "serverTime": 1499827319559
+    }`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	timeSync := s.client.NewTimeSync()
+	timeSync.Samples = 1
+	err := timeSync.Sync(newContext())
+	s.r().NoError(err)
+	s.r().True(timeSync.Synced())
+	s.r().NotZero(timeSync.Offset())
+}
@@ -0,0 +1,58 @@
+package options
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// SymbolRegistry caches the option symbols of the exchange info. See
+// common.SymbolRegistry.
+type SymbolRegistry struct {
+	*common.SymbolRegistry
+}
+
+// NewSymbolRegistry create a registry of the option symbols, they are loaded
+// by Start or Refresh. The filters are set on Client.OrderValidator, the one
+// set at the time of each refresh, if any.
+//
+// The exchange info has no status for the option symbols, expired options
+// are emitted as removed.
+func (c *Client) NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{&common.SymbolRegistry{
+		Fetch: func(ctx context.Context) ([]*common.RegistrySymbol, error) {
+			res, err := c.NewExchangeInfoService().Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			baseAssets := make(map[int64]string, len(res.OptionContracts))
+			for _, contract := range res.OptionContracts {
+				baseAssets[contract.Id] = contract.BaseAsset
+			}
+			symbols := make([]*common.RegistrySymbol, 0, len(res.OptionSymbols))
+			for i := range res.OptionSymbols {
+				s := &res.OptionSymbols[i]
+				symbols = append(symbols, &common.RegistrySymbol{
+					Symbol:     s.Symbol,
+					BaseAsset:  baseAssets[s.ContractId],
+					QuoteAsset: s.QuoteAsset,
+					Filters:    s.OrderFilters(),
+					Info:       s,
+				})
+			}
+			return symbols, nil
+		},
+		OrderValidator: func() *common.OrderValidator {
+			return c.OrderValidator
+		},
+	}}
+}
+
+// ExchangeSymbol return the symbol as returned by the exchange info service,
+// nil if unknown
+func (r *SymbolRegistry) ExchangeSymbol(symbol string) *OptionSymbol {
+	if s := r.Symbol(symbol); s != nil {
+		return s.Info.(*OptionSymbol)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+type symbolRegistryTestSuite struct {
+	baseTestSuite
+}
+
+func TestSymbolRegistry(t *testing.T) {
+	suite.Run(t, new(symbolRegistryTestSuite))
+}
+
+func (s *symbolRegistryTestSuite) TestRefresh() {
+	data := []byte(`{
+		"timezone": "UTC",
+		"serverTime": 1592387337630,
+		"optionContracts": [
+			{"id": 2, "baseAsset": "BTC", "quoteAsset": "USDT", "underlying": "BTCUSDT", "settleAsset": "USDT"}
+		],
+		"optionSymbols": [
+			{
+				"contractId": 2,
+				"filters": [
+					{"filterType": "PRICE_FILTER", "minPrice": "0.02", "maxPrice": "80000.01", "tickSize": "0.01"},
+					{"filterType": "LOT_SIZE", "minQty": "0.01", "maxQty": "100", "stepSize": "0.01"}
+				],
+				"symbol": "BTC-220815-50000-C",
+				"side": "CALL",
+				"quoteAsset": "USDT"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	registry := s.client.NewSymbolRegistry()
+	// the validator set after the registry is built receives the filters
+	s.client.OrderValidator = common.NewOrderValidator()
+	s.r().NoError(registry.Refresh(newContext()))
+
+	symbol := registry.Symbol("BTC-220815-50000-C")
+	s.r().NotNil(symbol)
+	s.r().Equal("BTC", symbol.BaseAsset)
+	s.r().Equal("USDT", symbol.QuoteAsset)
+	s.r().Equal("0.01", symbol.Filters.TickSize.String())
+	s.r().Equal("CALL", registry.ExchangeSymbol("BTC-220815-50000-C").Side)
+	s.r().Nil(registry.ExchangeSymbol("BTC-220815-60000-C"))
+	s.r().Len(registry.SymbolsByBaseAsset("BTC"), 1)
+	s.r().NotNil(s.client.OrderValidator.Filters("BTC-220815-50000-C"))
+}
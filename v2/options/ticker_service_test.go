@@ -0,0 +1,104 @@
+package options
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type tickerServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestTickerService(t *testing.T) {
+	suite.Run(t, new(tickerServiceTestSuite))
+}
+
+func (s *tickerServiceTestSuite) TestTicker() {
+	data := []byte(`[
+		{
+			"symbol": "BTC-200730-9000-C",
+			"priceChange": "-16.2038",
+			"priceChangePercent": "-0.0162",
+			"lastPrice": "1000",
+			"lastQty": "1000",
+			"open": "1016.2038",
+			"high": "1016.2038",
+			"low": "0",
+			"volume": "5",
+			"amount": "1",
+			"bidPrice": "999.34",
+			"askPrice": "1000.23",
+			"openTime": 1592317127349,
+			"closeTime": 1592380593516,
+			"firstTradeId": 1,
+			"tradeCount": 5,
+			"strikePrice": "9000",
+			"exercisePrice": "3000.3356"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParam("symbol", "BTC-200730-9000-C")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewTickerService().Symbol("BTC-200730-9000-C").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*Ticker{
+		{
+			Symbol:             "BTC-200730-9000-C",
+			PriceChange:        "-16.2038",
+			PriceChangePercent: "-0.0162",
+			LastPrice:          "1000",
+			LastQuantity:       "1000",
+			Open:               "1016.2038",
+			High:               "1016.2038",
+			Low:                "0",
+			Volume:             "5",
+			Amount:             "1",
+			BidPrice:           "999.34",
+			AskPrice:           "1000.23",
+			OpenTime:           1592317127349,
+			CloseTime:          1592380593516,
+			FirstTradeID:       1,
+			TradeCount:         5,
+			StrikePrice:        "9000",
+			ExercisePrice:      "3000.3356",
+		},
+	}, res)
+}
+
+func (s *tickerServiceTestSuite) TestTickerAllSymbols() {
+	data := []byte(`[{"symbol": "BTC-200730-9000-C"}, {"symbol": "BTC-200730-9000-P"}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewTickerService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal("BTC-200730-9000-P", res[1].Symbol)
+}
+
+func (s *tickerServiceTestSuite) TestTickerInvalidResponseBody() {
+	s.mockDo([]byte(`{"symbol": "BTC-200730-9000-C"}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest()
+		s.assertRequestEqual(e, r)
+	})
+	_, err := s.client.NewTickerService().Do(newContext())
+	r := s.r()
+	var decodeErr *common.DecodeError
+	r.ErrorAs(err, &decodeErr)
+	r.Equal(http.MethodGet, decodeErr.Method)
+	r.Equal("/eapi/v1/ticker", decodeErr.Endpoint)
+	r.Equal(http.StatusOK, decodeErr.StatusCode)
+}
@@ -0,0 +1,211 @@
+package options
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListUserTradesService list the trades of the account
+type ListUserTradesService struct {
+	c         *Client
+	symbol    *string
+	fromID    *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Symbol set symbol
+func (s *ListUserTradesService) Symbol(symbol string) *ListUserTradesService {
+	s.symbol = &symbol
+	return s
+}
+
+// FromID set fromId, the trades from this id are returned
+func (s *ListUserTradesService) FromID(fromID int64) *ListUserTradesService {
+	s.fromID = &fromID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListUserTradesService) StartTime(startTime int64) *ListUserTradesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListUserTradesService) EndTime(endTime int64) *ListUserTradesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit
+func (s *ListUserTradesService) Limit(limit int) *ListUserTradesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListUserTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*UserTrade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/userTrades",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*UserTrade{}, err
+	}
+	res = make([]*UserTrade, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*UserTrade{}, err
+	}
+	return res, nil
+}
+
+// UserTrade define a trade of the account
+type UserTrade struct {
+	ID             int64          `json:"id"`
+	TradeID        int64          `json:"tradeId"`
+	OrderID        int64          `json:"orderId"`
+	Symbol         string         `json:"symbol"`
+	Price          string         `json:"price"`
+	Quantity       string         `json:"quantity"`
+	Fee            string         `json:"fee"`
+	RealizedProfit string         `json:"realizedProfit"`
+	Side           SideType       `json:"side"`
+	Type           OrderType      `json:"type"`
+	Volatility     string         `json:"volatility"`
+	Liquidity      string         `json:"liquidity"`
+	QuoteAsset     string         `json:"quoteAsset"`
+	Time           int64          `json:"time"`
+	PriceScale     int            `json:"priceScale"`
+	QuantityScale  int            `json:"quantityScale"`
+	OptionSide     OptionSideType `json:"optionSide"`
+}
+
+// RecentTradesService list the recent market trades of a symbol
+type RecentTradesService struct {
+	c      *Client
+	symbol string
+	limit  *int
+}
+
+// Symbol set symbol
+func (s *RecentTradesService) Symbol(symbol string) *RecentTradesService {
+	s.symbol = symbol
+	return s
+}
+
+// Limit set limit
+func (s *RecentTradesService) Limit(limit int) *RecentTradesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*Trade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/trades",
+	}
+	r.setParam("symbol", s.symbol)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Trade{}, err
+	}
+	res = make([]*Trade, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*Trade{}, err
+	}
+	return res, nil
+}
+
+// Trade define a market trade, side is 1 when the taker bought and -1 when
+// the taker sold
+type Trade struct {
+	ID            string `json:"id"`
+	TradeID       string `json:"tradeId"`
+	Symbol        string `json:"symbol"`
+	Price         string `json:"price"`
+	Quantity      string `json:"qty"`
+	QuoteQuantity string `json:"quoteQty"`
+	Side          int    `json:"side"`
+	Time          int64  `json:"time"`
+}
+
+// BlockTradesService list the recent block trades
+type BlockTradesService struct {
+	c      *Client
+	symbol *string
+	limit  *int
+}
+
+// Symbol set symbol
+func (s *BlockTradesService) Symbol(symbol string) *BlockTradesService {
+	s.symbol = &symbol
+	return s
+}
+
+// Limit set limit
+func (s *BlockTradesService) Limit(limit int) *BlockTradesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *BlockTradesService) Do(ctx context.Context, opts ...RequestOption) (res []*BlockTrade, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/eapi/v1/blockTrades",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*BlockTrade{}, err
+	}
+	res = make([]*BlockTrade, 0)
+	err = r.decode(data, &res)
+	if err != nil {
+		return []*BlockTrade{}, err
+	}
+	return res, nil
+}
+
+// BlockTrade define a block trade, side is 1 when the taker bought and -1
+// when the taker sold
+type BlockTrade struct {
+	ID            int64  `json:"id"`
+	TradeID       int64  `json:"tradeId"`
+	Symbol        string `json:"symbol"`
+	Price         string `json:"price"`
+	Quantity      string `json:"quantity"`
+	QuoteQuantity string `json:"quoteQty"`
+	Side          int    `json:"side"`
+	Time          int64  `json:"time"`
+}
@@ -0,0 +1,154 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type tradeServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestTradeService(t *testing.T) {
+	suite.Run(t, new(tradeServiceTestSuite))
+}
+
+func (s *tradeServiceTestSuite) TestListUserTrades() {
+	data := []byte(`[
+		{
+			"id": 4611875134427365376,
+			"tradeId": 239,
+			"orderId": 4611875134427365376,
+			"symbol": "BTC-200730-9000-C",
+			"price": "100",
+			"quantity": "1",
+			"fee": "0",
+			"realizedProfit": "0.00000000",
+			"side": "BUY",
+			"type": "LIMIT",
+			"volatility": "0.9",
+			"liquidity": "TAKER",
+			"quoteAsset": "USDT",
+			"time": 1592465880683,
+			"priceScale": 2,
+			"quantityScale": 2,
+			"optionSide": "CALL"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":    "BTC-200730-9000-C",
+			"fromId":    238,
+			"startTime": 1592465880000,
+			"endTime":   1592465890000,
+			"limit":     100,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListUserTradesService().Symbol("BTC-200730-9000-C").FromID(238).
+		StartTime(1592465880000).EndTime(1592465890000).Limit(100).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*UserTrade{
+		{
+			ID:             4611875134427365376,
+			TradeID:        239,
+			OrderID:        4611875134427365376,
+			Symbol:         "BTC-200730-9000-C",
+			Price:          "100",
+			Quantity:       "1",
+			Fee:            "0",
+			RealizedProfit: "0.00000000",
+			Side:           SideTypeBuy,
+			Type:           OrderTypeLimit,
+			Volatility:     "0.9",
+			Liquidity:      "TAKER",
+			QuoteAsset:     "USDT",
+			Time:           1592465880683,
+			PriceScale:     2,
+			QuantityScale:  2,
+			OptionSide:     OptionSideTypeCall,
+		},
+	}, res)
+}
+
+func (s *tradeServiceTestSuite) TestRecentTrades() {
+	data := []byte(`[
+		{
+			"id": "1",
+			"tradeId": "159244329455993",
+			"symbol": "BTC-220722-19000-C",
+			"price": "1000",
+			"qty": "-0.1",
+			"quoteQty": "-100",
+			"side": -1,
+			"time": 1592449455993
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": "BTC-220722-19000-C",
+			"limit":  10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewRecentTradesService().Symbol("BTC-220722-19000-C").Limit(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*Trade{
+		{
+			ID:            "1",
+			TradeID:       "159244329455993",
+			Symbol:        "BTC-220722-19000-C",
+			Price:         "1000",
+			Quantity:      "-0.1",
+			QuoteQuantity: "-100",
+			Side:          -1,
+			Time:          1592449455993,
+		},
+	}, res)
+}
+
+func (s *tradeServiceTestSuite) TestBlockTrades() {
+	data := []byte(`[
+		{
+			"id": 1125899906901081078,
+			"tradeId": 389,
+			"symbol": "ETH-232412-3700-P",
+			"price": "156.3",
+			"quantity": "1",
+			"quoteQty": "156.3",
+			"side": 1,
+			"time": 1677407407540
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newRequest().setParams(params{
+			"symbol": "ETH-232412-3700-P",
+			"limit":  50,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewBlockTradesService().Symbol("ETH-232412-3700-P").Limit(50).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*BlockTrade{
+		{
+			ID:            1125899906901081078,
+			TradeID:       389,
+			Symbol:        "ETH-232412-3700-P",
+			Price:         "156.3",
+			Quantity:      "1",
+			QuoteQuantity: "156.3",
+			Side:          1,
+			Time:          1677407407540,
+		},
+	}, res)
+}
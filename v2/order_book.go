@@ -0,0 +1,47 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// OrderBook is a local order book of a symbol, synchronized from the depth
+// snapshot and the diff depth stream. See common.OrderBook.
+//
+// The ReconnectPolicy of the embedded common.OrderBook is the book's own,
+// its callbacks are not shared with the WebSocket API one.
+type OrderBook struct {
+	*common.OrderBook
+}
+
+// NewOrderBook create a local spot order book of the symbol, it is
+// synchronized after Start
+func (c *Client) NewOrderBook(symbol string) *OrderBook {
+	return &OrderBook{&common.OrderBook{
+		Symbol: symbol,
+		Snapshot: func(ctx context.Context, limit int) (*common.DepthSnapshot, error) {
+			res, err := c.NewDepthService().Symbol(symbol).Limit(limit).Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &common.DepthSnapshot{
+				LastUpdateID: res.LastUpdateID,
+				Bids:         res.Bids,
+				Asks:         res.Asks,
+			}, nil
+		},
+		Serve: func(handler func(update *common.DepthUpdate), errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+			return c.WsDepthServe100Ms(symbol, func(event *WsDepthEvent) {
+				handler(&common.DepthUpdate{
+					Time:          event.Time,
+					FirstUpdateID: event.FirstUpdateID,
+					LastUpdateID:  event.LastUpdateID,
+					Bids:          event.Bids,
+					Asks:          event.Asks,
+				})
+			}, errHandler)
+		},
+		ReconnectPolicy: common.DefaultReconnectPolicy(),
+	}}
+}
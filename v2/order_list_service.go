@@ -0,0 +1,748 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// orderListLeg is an order of an order list, its params are prefixed by its
+// place in the list, e.g. aboveType
+type orderListLeg struct {
+	orderType       *OrderType
+	side            *SideType
+	clientOrderID   *string
+	price           *string
+	stopPrice       *string
+	trailingDelta   *string
+	quantity        *string
+	icebergQuantity *string
+	timeInForce     *TimeInForceType
+	strategyID      *int64
+	strategyType    *int
+}
+
+func (l *orderListLeg) setParams(m params, prefix string) {
+	if l.orderType != nil {
+		m[prefix+"Type"] = *l.orderType
+	}
+	if l.side != nil {
+		m[prefix+"Side"] = *l.side
+	}
+	if l.clientOrderID != nil {
+		m[prefix+"ClientOrderId"] = *l.clientOrderID
+	}
+	if l.price != nil {
+		m[prefix+"Price"] = *l.price
+	}
+	if l.stopPrice != nil {
+		m[prefix+"StopPrice"] = *l.stopPrice
+	}
+	if l.trailingDelta != nil {
+		m[prefix+"TrailingDelta"] = *l.trailingDelta
+	}
+	if l.quantity != nil {
+		m[prefix+"Quantity"] = *l.quantity
+	}
+	if l.icebergQuantity != nil {
+		m[prefix+"IcebergQty"] = *l.icebergQuantity
+	}
+	if l.timeInForce != nil {
+		m[prefix+"TimeInForce"] = *l.timeInForce
+	}
+	if l.strategyID != nil {
+		m[prefix+"StrategyId"] = *l.strategyID
+	}
+	if l.strategyType != nil {
+		m[prefix+"StrategyType"] = *l.strategyType
+	}
+}
+
+// validate check the order with the validator, side and quantity are the
+// ones of the list when the order has none
+func (l *orderListLeg) validate(validator *common.OrderValidator, symbol string, side *SideType, quantity *string) error {
+	if l.side != nil {
+		side = l.side
+	}
+	if l.quantity != nil {
+		quantity = l.quantity
+	}
+	p := &common.OrderParams{
+		Symbol:    symbol,
+		Market:    l.price == nil,
+		Price:     l.price,
+		StopPrice: l.stopPrice,
+		Quantity:  quantity,
+	}
+	if side != nil {
+		p.Side = string(*side)
+	}
+	if l.orderType != nil {
+		p.Algo = l.orderType.isAlgo()
+	}
+	return validator.Validate(p)
+}
+
+// createOrderList send the request of an order list
+func (c *Client) createOrderList(ctx context.Context, r *request, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	data, _, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOrderListResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateOrderListResponse define create order list response
+type CreateOrderListResponse struct {
+	OrderListID       int64             `json:"orderListId"`
+	ContingencyType   string            `json:"contingencyType"`
+	ListStatusType    string            `json:"listStatusType"`
+	ListOrderStatus   string            `json:"listOrderStatus"`
+	ListClientOrderID string            `json:"listClientOrderId"`
+	TransactionTime   int64             `json:"transactionTime"`
+	Symbol            string            `json:"symbol"`
+	Orders            []*OCOOrder       `json:"orders"`
+	OrderReports      []*OCOOrderReport `json:"orderReports"`
+}
+
+// CreateOrderListOCOService create a one-cancels-the-other order list, an
+// above order and a below order of the same side and quantity
+type CreateOrderListOCOService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	quantity                string
+	listClientOrderID       *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+	above                   orderListLeg
+	below                   orderListLeg
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOCOService) Symbol(symbol string) *CreateOrderListOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOCOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderListOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// Side set side
+func (s *CreateOrderListOCOService) Side(side SideType) *CreateOrderListOCOService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateOrderListOCOService) Quantity(quantity string) *CreateOrderListOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// AboveType set aboveType
+func (s *CreateOrderListOCOService) AboveType(aboveType OrderType) *CreateOrderListOCOService {
+	s.above.orderType = &aboveType
+	return s
+}
+
+// AboveClientOrderID set aboveClientOrderId
+func (s *CreateOrderListOCOService) AboveClientOrderID(aboveClientOrderID string) *CreateOrderListOCOService {
+	s.above.clientOrderID = &aboveClientOrderID
+	return s
+}
+
+// AbovePrice set abovePrice
+func (s *CreateOrderListOCOService) AbovePrice(abovePrice string) *CreateOrderListOCOService {
+	s.above.price = &abovePrice
+	return s
+}
+
+// AboveStopPrice set aboveStopPrice
+func (s *CreateOrderListOCOService) AboveStopPrice(aboveStopPrice string) *CreateOrderListOCOService {
+	s.above.stopPrice = &aboveStopPrice
+	return s
+}
+
+// AboveTrailingDelta set aboveTrailingDelta
+func (s *CreateOrderListOCOService) AboveTrailingDelta(aboveTrailingDelta string) *CreateOrderListOCOService {
+	s.above.trailingDelta = &aboveTrailingDelta
+	return s
+}
+
+// AboveIcebergQuantity set aboveIcebergQty
+func (s *CreateOrderListOCOService) AboveIcebergQuantity(aboveIcebergQuantity string) *CreateOrderListOCOService {
+	s.above.icebergQuantity = &aboveIcebergQuantity
+	return s
+}
+
+// AboveTimeInForce set aboveTimeInForce
+func (s *CreateOrderListOCOService) AboveTimeInForce(aboveTimeInForce TimeInForceType) *CreateOrderListOCOService {
+	s.above.timeInForce = &aboveTimeInForce
+	return s
+}
+
+// AboveStrategyID set aboveStrategyId
+func (s *CreateOrderListOCOService) AboveStrategyID(aboveStrategyID int64) *CreateOrderListOCOService {
+	s.above.strategyID = &aboveStrategyID
+	return s
+}
+
+// AboveStrategyType set aboveStrategyType
+func (s *CreateOrderListOCOService) AboveStrategyType(aboveStrategyType int) *CreateOrderListOCOService {
+	s.above.strategyType = &aboveStrategyType
+	return s
+}
+
+// BelowType set belowType
+func (s *CreateOrderListOCOService) BelowType(belowType OrderType) *CreateOrderListOCOService {
+	s.below.orderType = &belowType
+	return s
+}
+
+// BelowClientOrderID set belowClientOrderId
+func (s *CreateOrderListOCOService) BelowClientOrderID(belowClientOrderID string) *CreateOrderListOCOService {
+	s.below.clientOrderID = &belowClientOrderID
+	return s
+}
+
+// BelowPrice set belowPrice
+func (s *CreateOrderListOCOService) BelowPrice(belowPrice string) *CreateOrderListOCOService {
+	s.below.price = &belowPrice
+	return s
+}
+
+// BelowStopPrice set belowStopPrice
+func (s *CreateOrderListOCOService) BelowStopPrice(belowStopPrice string) *CreateOrderListOCOService {
+	s.below.stopPrice = &belowStopPrice
+	return s
+}
+
+// BelowTrailingDelta set belowTrailingDelta
+func (s *CreateOrderListOCOService) BelowTrailingDelta(belowTrailingDelta string) *CreateOrderListOCOService {
+	s.below.trailingDelta = &belowTrailingDelta
+	return s
+}
+
+// BelowIcebergQuantity set belowIcebergQty
+func (s *CreateOrderListOCOService) BelowIcebergQuantity(belowIcebergQuantity string) *CreateOrderListOCOService {
+	s.below.icebergQuantity = &belowIcebergQuantity
+	return s
+}
+
+// BelowTimeInForce set belowTimeInForce
+func (s *CreateOrderListOCOService) BelowTimeInForce(belowTimeInForce TimeInForceType) *CreateOrderListOCOService {
+	s.below.timeInForce = &belowTimeInForce
+	return s
+}
+
+// BelowStrategyID set belowStrategyId
+func (s *CreateOrderListOCOService) BelowStrategyID(belowStrategyID int64) *CreateOrderListOCOService {
+	s.below.strategyID = &belowStrategyID
+	return s
+}
+
+// BelowStrategyType set belowStrategyType
+func (s *CreateOrderListOCOService) BelowStrategyType(belowStrategyType int) *CreateOrderListOCOService {
+	s.below.strategyType = &belowStrategyType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	if s.c.OrderValidator != nil {
+		if err = s.above.validate(s.c.OrderValidator, s.symbol, &s.side, &s.quantity); err != nil {
+			return nil, err
+		}
+		if err = s.below.validate(s.c.OrderValidator, s.symbol, &s.side, &s.quantity); err != nil {
+			return nil, err
+		}
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/orderList/oco",
+		secType:  secTypeSigned,
+		wsMethod: "orderList.place.oco",
+	}
+	m := params{
+		"symbol": s.symbol,
+	}
+	m["side"] = s.side
+	m["quantity"] = s.quantity
+	s.above.setParams(m, "above")
+	s.below.setParams(m, "below")
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	return s.c.createOrderList(ctx, r, opts...)
+}
+
+// CreateOrderListOTOService create a one-triggers-the-other order list, the
+// pending order is placed once the working order is filled
+type CreateOrderListOTOService struct {
+	c                       *Client
+	symbol                  string
+	listClientOrderID       *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+	working                 orderListLeg
+	pending                 orderListLeg
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOTOService) Symbol(symbol string) *CreateOrderListOTOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOTOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderListOTOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOTOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// WorkingType set workingType
+func (s *CreateOrderListOTOService) WorkingType(workingType OrderType) *CreateOrderListOTOService {
+	s.working.orderType = &workingType
+	return s
+}
+
+// WorkingSide set workingSide
+func (s *CreateOrderListOTOService) WorkingSide(workingSide SideType) *CreateOrderListOTOService {
+	s.working.side = &workingSide
+	return s
+}
+
+// WorkingClientOrderID set workingClientOrderId
+func (s *CreateOrderListOTOService) WorkingClientOrderID(workingClientOrderID string) *CreateOrderListOTOService {
+	s.working.clientOrderID = &workingClientOrderID
+	return s
+}
+
+// WorkingPrice set workingPrice
+func (s *CreateOrderListOTOService) WorkingPrice(workingPrice string) *CreateOrderListOTOService {
+	s.working.price = &workingPrice
+	return s
+}
+
+// WorkingQuantity set workingQuantity
+func (s *CreateOrderListOTOService) WorkingQuantity(workingQuantity string) *CreateOrderListOTOService {
+	s.working.quantity = &workingQuantity
+	return s
+}
+
+// WorkingIcebergQuantity set workingIcebergQty
+func (s *CreateOrderListOTOService) WorkingIcebergQuantity(workingIcebergQuantity string) *CreateOrderListOTOService {
+	s.working.icebergQuantity = &workingIcebergQuantity
+	return s
+}
+
+// WorkingTimeInForce set workingTimeInForce
+func (s *CreateOrderListOTOService) WorkingTimeInForce(workingTimeInForce TimeInForceType) *CreateOrderListOTOService {
+	s.working.timeInForce = &workingTimeInForce
+	return s
+}
+
+// WorkingStrategyID set workingStrategyId
+func (s *CreateOrderListOTOService) WorkingStrategyID(workingStrategyID int64) *CreateOrderListOTOService {
+	s.working.strategyID = &workingStrategyID
+	return s
+}
+
+// WorkingStrategyType set workingStrategyType
+func (s *CreateOrderListOTOService) WorkingStrategyType(workingStrategyType int) *CreateOrderListOTOService {
+	s.working.strategyType = &workingStrategyType
+	return s
+}
+
+// PendingType set pendingType
+func (s *CreateOrderListOTOService) PendingType(pendingType OrderType) *CreateOrderListOTOService {
+	s.pending.orderType = &pendingType
+	return s
+}
+
+// PendingSide set pendingSide
+func (s *CreateOrderListOTOService) PendingSide(pendingSide SideType) *CreateOrderListOTOService {
+	s.pending.side = &pendingSide
+	return s
+}
+
+// PendingClientOrderID set pendingClientOrderId
+func (s *CreateOrderListOTOService) PendingClientOrderID(pendingClientOrderID string) *CreateOrderListOTOService {
+	s.pending.clientOrderID = &pendingClientOrderID
+	return s
+}
+
+// PendingPrice set pendingPrice
+func (s *CreateOrderListOTOService) PendingPrice(pendingPrice string) *CreateOrderListOTOService {
+	s.pending.price = &pendingPrice
+	return s
+}
+
+// PendingStopPrice set pendingStopPrice
+func (s *CreateOrderListOTOService) PendingStopPrice(pendingStopPrice string) *CreateOrderListOTOService {
+	s.pending.stopPrice = &pendingStopPrice
+	return s
+}
+
+// PendingTrailingDelta set pendingTrailingDelta
+func (s *CreateOrderListOTOService) PendingTrailingDelta(pendingTrailingDelta string) *CreateOrderListOTOService {
+	s.pending.trailingDelta = &pendingTrailingDelta
+	return s
+}
+
+// PendingQuantity set pendingQuantity
+func (s *CreateOrderListOTOService) PendingQuantity(pendingQuantity string) *CreateOrderListOTOService {
+	s.pending.quantity = &pendingQuantity
+	return s
+}
+
+// PendingIcebergQuantity set pendingIcebergQty
+func (s *CreateOrderListOTOService) PendingIcebergQuantity(pendingIcebergQuantity string) *CreateOrderListOTOService {
+	s.pending.icebergQuantity = &pendingIcebergQuantity
+	return s
+}
+
+// PendingTimeInForce set pendingTimeInForce
+func (s *CreateOrderListOTOService) PendingTimeInForce(pendingTimeInForce TimeInForceType) *CreateOrderListOTOService {
+	s.pending.timeInForce = &pendingTimeInForce
+	return s
+}
+
+// PendingStrategyID set pendingStrategyId
+func (s *CreateOrderListOTOService) PendingStrategyID(pendingStrategyID int64) *CreateOrderListOTOService {
+	s.pending.strategyID = &pendingStrategyID
+	return s
+}
+
+// PendingStrategyType set pendingStrategyType
+func (s *CreateOrderListOTOService) PendingStrategyType(pendingStrategyType int) *CreateOrderListOTOService {
+	s.pending.strategyType = &pendingStrategyType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOTOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	if s.c.OrderValidator != nil {
+		if err = s.working.validate(s.c.OrderValidator, s.symbol, nil, nil); err != nil {
+			return nil, err
+		}
+		if err = s.pending.validate(s.c.OrderValidator, s.symbol, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/orderList/oto",
+		secType:  secTypeSigned,
+		wsMethod: "orderList.place.oto",
+	}
+	m := params{
+		"symbol": s.symbol,
+	}
+	s.working.setParams(m, "working")
+	s.pending.setParams(m, "pending")
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	return s.c.createOrderList(ctx, r, opts...)
+}
+
+// CreateOrderListOTOCOService create a one-triggers-a-one-cancels-the-other
+// order list, the pending above and below orders are placed as an OCO once
+// the working order is filled
+type CreateOrderListOTOCOService struct {
+	c                       *Client
+	symbol                  string
+	listClientOrderID       *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+	working                 orderListLeg
+	pending                 orderListLeg
+	pendingAbove            orderListLeg
+	pendingBelow            orderListLeg
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOTOCOService) Symbol(symbol string) *CreateOrderListOTOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOCOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOTOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderListOTOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOTOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// WorkingType set workingType
+func (s *CreateOrderListOTOCOService) WorkingType(workingType OrderType) *CreateOrderListOTOCOService {
+	s.working.orderType = &workingType
+	return s
+}
+
+// WorkingSide set workingSide
+func (s *CreateOrderListOTOCOService) WorkingSide(workingSide SideType) *CreateOrderListOTOCOService {
+	s.working.side = &workingSide
+	return s
+}
+
+// WorkingClientOrderID set workingClientOrderId
+func (s *CreateOrderListOTOCOService) WorkingClientOrderID(workingClientOrderID string) *CreateOrderListOTOCOService {
+	s.working.clientOrderID = &workingClientOrderID
+	return s
+}
+
+// WorkingPrice set workingPrice
+func (s *CreateOrderListOTOCOService) WorkingPrice(workingPrice string) *CreateOrderListOTOCOService {
+	s.working.price = &workingPrice
+	return s
+}
+
+// WorkingQuantity set workingQuantity
+func (s *CreateOrderListOTOCOService) WorkingQuantity(workingQuantity string) *CreateOrderListOTOCOService {
+	s.working.quantity = &workingQuantity
+	return s
+}
+
+// WorkingIcebergQuantity set workingIcebergQty
+func (s *CreateOrderListOTOCOService) WorkingIcebergQuantity(workingIcebergQuantity string) *CreateOrderListOTOCOService {
+	s.working.icebergQuantity = &workingIcebergQuantity
+	return s
+}
+
+// WorkingTimeInForce set workingTimeInForce
+func (s *CreateOrderListOTOCOService) WorkingTimeInForce(workingTimeInForce TimeInForceType) *CreateOrderListOTOCOService {
+	s.working.timeInForce = &workingTimeInForce
+	return s
+}
+
+// WorkingStrategyID set workingStrategyId
+func (s *CreateOrderListOTOCOService) WorkingStrategyID(workingStrategyID int64) *CreateOrderListOTOCOService {
+	s.working.strategyID = &workingStrategyID
+	return s
+}
+
+// WorkingStrategyType set workingStrategyType
+func (s *CreateOrderListOTOCOService) WorkingStrategyType(workingStrategyType int) *CreateOrderListOTOCOService {
+	s.working.strategyType = &workingStrategyType
+	return s
+}
+
+// PendingSide set pendingSide
+func (s *CreateOrderListOTOCOService) PendingSide(pendingSide SideType) *CreateOrderListOTOCOService {
+	s.pending.side = &pendingSide
+	return s
+}
+
+// PendingQuantity set pendingQuantity
+func (s *CreateOrderListOTOCOService) PendingQuantity(pendingQuantity string) *CreateOrderListOTOCOService {
+	s.pending.quantity = &pendingQuantity
+	return s
+}
+
+// PendingAboveType set pendingAboveType
+func (s *CreateOrderListOTOCOService) PendingAboveType(pendingAboveType OrderType) *CreateOrderListOTOCOService {
+	s.pendingAbove.orderType = &pendingAboveType
+	return s
+}
+
+// PendingAboveClientOrderID set pendingAboveClientOrderId
+func (s *CreateOrderListOTOCOService) PendingAboveClientOrderID(pendingAboveClientOrderID string) *CreateOrderListOTOCOService {
+	s.pendingAbove.clientOrderID = &pendingAboveClientOrderID
+	return s
+}
+
+// PendingAbovePrice set pendingAbovePrice
+func (s *CreateOrderListOTOCOService) PendingAbovePrice(pendingAbovePrice string) *CreateOrderListOTOCOService {
+	s.pendingAbove.price = &pendingAbovePrice
+	return s
+}
+
+// PendingAboveStopPrice set pendingAboveStopPrice
+func (s *CreateOrderListOTOCOService) PendingAboveStopPrice(pendingAboveStopPrice string) *CreateOrderListOTOCOService {
+	s.pendingAbove.stopPrice = &pendingAboveStopPrice
+	return s
+}
+
+// PendingAboveTrailingDelta set pendingAboveTrailingDelta
+func (s *CreateOrderListOTOCOService) PendingAboveTrailingDelta(pendingAboveTrailingDelta string) *CreateOrderListOTOCOService {
+	s.pendingAbove.trailingDelta = &pendingAboveTrailingDelta
+	return s
+}
+
+// PendingAboveIcebergQuantity set pendingAboveIcebergQty
+func (s *CreateOrderListOTOCOService) PendingAboveIcebergQuantity(pendingAboveIcebergQuantity string) *CreateOrderListOTOCOService {
+	s.pendingAbove.icebergQuantity = &pendingAboveIcebergQuantity
+	return s
+}
+
+// PendingAboveTimeInForce set pendingAboveTimeInForce
+func (s *CreateOrderListOTOCOService) PendingAboveTimeInForce(pendingAboveTimeInForce TimeInForceType) *CreateOrderListOTOCOService {
+	s.pendingAbove.timeInForce = &pendingAboveTimeInForce
+	return s
+}
+
+// PendingAboveStrategyID set pendingAboveStrategyId
+func (s *CreateOrderListOTOCOService) PendingAboveStrategyID(pendingAboveStrategyID int64) *CreateOrderListOTOCOService {
+	s.pendingAbove.strategyID = &pendingAboveStrategyID
+	return s
+}
+
+// PendingAboveStrategyType set pendingAboveStrategyType
+func (s *CreateOrderListOTOCOService) PendingAboveStrategyType(pendingAboveStrategyType int) *CreateOrderListOTOCOService {
+	s.pendingAbove.strategyType = &pendingAboveStrategyType
+	return s
+}
+
+// PendingBelowType set pendingBelowType
+func (s *CreateOrderListOTOCOService) PendingBelowType(pendingBelowType OrderType) *CreateOrderListOTOCOService {
+	s.pendingBelow.orderType = &pendingBelowType
+	return s
+}
+
+// PendingBelowClientOrderID set pendingBelowClientOrderId
+func (s *CreateOrderListOTOCOService) PendingBelowClientOrderID(pendingBelowClientOrderID string) *CreateOrderListOTOCOService {
+	s.pendingBelow.clientOrderID = &pendingBelowClientOrderID
+	return s
+}
+
+// PendingBelowPrice set pendingBelowPrice
+func (s *CreateOrderListOTOCOService) PendingBelowPrice(pendingBelowPrice string) *CreateOrderListOTOCOService {
+	s.pendingBelow.price = &pendingBelowPrice
+	return s
+}
+
+// PendingBelowStopPrice set pendingBelowStopPrice
+func (s *CreateOrderListOTOCOService) PendingBelowStopPrice(pendingBelowStopPrice string) *CreateOrderListOTOCOService {
+	s.pendingBelow.stopPrice = &pendingBelowStopPrice
+	return s
+}
+
+// PendingBelowTrailingDelta set pendingBelowTrailingDelta
+func (s *CreateOrderListOTOCOService) PendingBelowTrailingDelta(pendingBelowTrailingDelta string) *CreateOrderListOTOCOService {
+	s.pendingBelow.trailingDelta = &pendingBelowTrailingDelta
+	return s
+}
+
+// PendingBelowIcebergQuantity set pendingBelowIcebergQty
+func (s *CreateOrderListOTOCOService) PendingBelowIcebergQuantity(pendingBelowIcebergQuantity string) *CreateOrderListOTOCOService {
+	s.pendingBelow.icebergQuantity = &pendingBelowIcebergQuantity
+	return s
+}
+
+// PendingBelowTimeInForce set pendingBelowTimeInForce
+func (s *CreateOrderListOTOCOService) PendingBelowTimeInForce(pendingBelowTimeInForce TimeInForceType) *CreateOrderListOTOCOService {
+	s.pendingBelow.timeInForce = &pendingBelowTimeInForce
+	return s
+}
+
+// PendingBelowStrategyID set pendingBelowStrategyId
+func (s *CreateOrderListOTOCOService) PendingBelowStrategyID(pendingBelowStrategyID int64) *CreateOrderListOTOCOService {
+	s.pendingBelow.strategyID = &pendingBelowStrategyID
+	return s
+}
+
+// PendingBelowStrategyType set pendingBelowStrategyType
+func (s *CreateOrderListOTOCOService) PendingBelowStrategyType(pendingBelowStrategyType int) *CreateOrderListOTOCOService {
+	s.pendingBelow.strategyType = &pendingBelowStrategyType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOTOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderListResponse, err error) {
+	if s.c.OrderValidator != nil {
+		if err = s.working.validate(s.c.OrderValidator, s.symbol, nil, nil); err != nil {
+			return nil, err
+		}
+		if err = s.pendingAbove.validate(s.c.OrderValidator, s.symbol, s.pending.side, s.pending.quantity); err != nil {
+			return nil, err
+		}
+		if err = s.pendingBelow.validate(s.c.OrderValidator, s.symbol, s.pending.side, s.pending.quantity); err != nil {
+			return nil, err
+		}
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/orderList/otoco",
+		secType:  secTypeSigned,
+		wsMethod: "orderList.place.otoco",
+	}
+	m := params{
+		"symbol": s.symbol,
+	}
+	s.working.setParams(m, "working")
+	s.pending.setParams(m, "pending")
+	s.pendingAbove.setParams(m, "pendingAbove")
+	s.pendingBelow.setParams(m, "pendingBelow")
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	return s.c.createOrderList(ctx, r, opts...)
+}
@@ -0,0 +1,186 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type orderListServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderListService(t *testing.T) {
+	suite.Run(t, new(orderListServiceTestSuite))
+}
+
+func (s *orderListServiceTestSuite) TestCreateOrderListOCO() {
+	data := []byte(`{
+		"orderListId": 1,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+		"transactionTime": 1710485608839,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 10, "clientOrderId": "44nZvqpemY7sVYgPYbvPih"},
+			{"symbol": "LTCBTC", "orderId": 11, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"}
+		],
+		"orderReports": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 10,
+				"orderListId": 1,
+				"clientOrderId": "44nZvqpemY7sVYgPYbvPih",
+				"transactTime": 1710485608839,
+				"price": "1.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "STOP_LOSS_LIMIT",
+				"side": "SELL",
+				"stopPrice": "1.00000000"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 11,
+				"orderListId": 1,
+				"clientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+				"transactTime": 1710485608839,
+				"price": "3.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":           "LTCBTC",
+			"side":             SideTypeSell,
+			"quantity":         "5",
+			"aboveType":        OrderTypeLimitMaker,
+			"abovePrice":       "3",
+			"belowType":        OrderTypeStopLossLimit,
+			"belowPrice":       "1",
+			"belowStopPrice":   "1",
+			"belowTimeInForce": TimeInForceTypeGTC,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateOrderListOCOService().Symbol("LTCBTC").Side(SideTypeSell).Quantity("5").
+		AboveType(OrderTypeLimitMaker).AbovePrice("3").
+		BelowType(OrderTypeStopLossLimit).BelowPrice("1").BelowStopPrice("1").BelowTimeInForce(TimeInForceTypeGTC).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.OrderListID)
+	r.Equal("OCO", res.ContingencyType)
+	r.Len(res.Orders, 2)
+	r.Len(res.OrderReports, 2)
+	r.Equal(OrderTypeStopLossLimit, res.OrderReports[0].Type)
+	r.Equal("1.00000000", res.OrderReports[0].StopPrice)
+	r.Equal(OrderTypeLimitMaker, res.OrderReports[1].Type)
+}
+
+func (s *orderListServiceTestSuite) TestCreateOrderListOTO() {
+	data := []byte(`{
+		"orderListId": 0,
+		"contingencyType": "OTO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "yl2ERtcar1o25zcWtqVBTC",
+		"transactionTime": 1712289389158,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "Bq17mn9fP6vyCn75Jw1xya"},
+			{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "arLFo0zGJVDE69cvGBaU0d"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":             "LTCBTC",
+			"listClientOrderId":  "yl2ERtcar1o25zcWtqVBTC",
+			"workingType":        OrderTypeLimit,
+			"workingSide":        SideTypeSell,
+			"workingPrice":       "1",
+			"workingQuantity":    "1",
+			"workingTimeInForce": TimeInForceTypeGTC,
+			"pendingType":        OrderTypeMarket,
+			"pendingSide":        SideTypeBuy,
+			"pendingQuantity":    "1",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateOrderListOTOService().Symbol("LTCBTC").ListClientOrderID("yl2ERtcar1o25zcWtqVBTC").
+		WorkingType(OrderTypeLimit).WorkingSide(SideTypeSell).WorkingPrice("1").WorkingQuantity("1").
+		WorkingTimeInForce(TimeInForceTypeGTC).
+		PendingType(OrderTypeMarket).PendingSide(SideTypeBuy).PendingQuantity("1").
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal("OTO", res.ContingencyType)
+	r.Equal(&OCOOrder{Symbol: "LTCBTC", OrderID: 5, ClientOrderID: "arLFo0zGJVDE69cvGBaU0d"}, res.Orders[1])
+}
+
+func (s *orderListServiceTestSuite) TestCreateOrderListOTOCO() {
+	data := []byte(`{
+		"orderListId": 1,
+		"contingencyType": "OTO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "RumwQpBaDctlUu5jyG5rs0",
+		"transactionTime": 1712291372842,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 6, "clientOrderId": "fM9Y4m23IFJVCQmIrlUmMK"},
+			{"symbol": "LTCBTC", "orderId": 7, "clientOrderId": "6pcQbFIzTXGZQ1e2MkGDq4"},
+			{"symbol": "LTCBTC", "orderId": 8, "clientOrderId": "r4JMv9cwAYYUwwBZfbussx"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                  "LTCBTC",
+			"workingType":             OrderTypeLimit,
+			"workingSide":             SideTypeSell,
+			"workingPrice":            "1.5",
+			"workingQuantity":         "1",
+			"workingTimeInForce":      TimeInForceTypeGTC,
+			"pendingSide":             SideTypeBuy,
+			"pendingQuantity":         "5",
+			"pendingAboveType":        OrderTypeStopLossLimit,
+			"pendingAbovePrice":       "0.5",
+			"pendingAboveStopPrice":   "0.5",
+			"pendingAboveTimeInForce": TimeInForceTypeGTC,
+			"pendingBelowType":        OrderTypeLimitMaker,
+			"pendingBelowPrice":       "0.3",
+			"newOrderRespType":        NewOrderRespTypeRESULT,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateOrderListOTOCOService().Symbol("LTCBTC").
+		WorkingType(OrderTypeLimit).WorkingSide(SideTypeSell).WorkingPrice("1.5").WorkingQuantity("1").
+		WorkingTimeInForce(TimeInForceTypeGTC).
+		PendingSide(SideTypeBuy).PendingQuantity("5").
+		PendingAboveType(OrderTypeStopLossLimit).PendingAbovePrice("0.5").PendingAboveStopPrice("0.5").
+		PendingAboveTimeInForce(TimeInForceTypeGTC).
+		PendingBelowType(OrderTypeLimitMaker).PendingBelowPrice("0.3").
+		NewOrderRespType(NewOrderRespTypeRESULT).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Orders, 3)
+	r.Equal(int64(8), res.Orders[2].OrderID)
+}
@@ -3,7 +3,10 @@ package binance
 import (
 	"context"
 	stdjson "encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // CreateOrderService create order
@@ -95,8 +98,23 @@ func (s *CreateOrderService) NewOrderRespType(newOrderRespType NewOrderRespType)
 	return s
 }
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, limits *RateLimits, err error) {
-	r := &request{
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, limits *RateLimits, r *request, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:        s.symbol,
+			Side:          string(s.side),
+			Market:        s.price == nil,
+			Algo:          s.orderType.isAlgo(),
+			Price:         s.price,
+			StopPrice:     s.stopPrice,
+			Quantity:      s.quantity,
+			QuoteQuantity: s.quoteOrderQty,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
@@ -135,29 +153,33 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint, wsMethod
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
 	r.setFormParams(m)
-	return s.c.callAPI(ctx, r, opts...)
+	data, limits, err = s.c.callAPI(ctx, r, opts...)
+	return data, limits, r, err
 }
 
 // Do send request
 func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
-	data, rateLimits, err := s.createOrder(ctx, "/api/v3/order", "order.place", opts...)
+	data, rateLimits, r, err := s.createOrder(ctx, "/api/v3/order", "order.place", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
-	res.RateLimitOrder10s = rateLimits.Order10s
-	res.RateLimitOrder1m = rateLimits.Order1m
+	// an order found by its client order id after a failure has no limits
+	if rateLimits != nil {
+		res.RateLimitOrder10s = rateLimits.Order10s
+		res.RateLimitOrder1m = rateLimits.Order1m
+	}
 
 	return res, nil
 }
 
 // Test send test api to check if the request is valid
 func (s *CreateOrderService) Test(ctx context.Context, opts ...RequestOption) (err error) {
-	_, _, err = s.createOrder(ctx, "/api/v3/order/test", "order.test", opts...)
+	_, _, _, err = s.createOrder(ctx, "/api/v3/order/test", "order.test", opts...)
 	return err
 }
 
@@ -292,8 +314,36 @@ func (s *CreateOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *
 	return s
 }
 
-func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
-	r := &request{
+// validate check the limit order and the stop order with Client.OrderValidator
+func (s *CreateOCOService) validate() error {
+	if s.c.OrderValidator == nil {
+		return nil
+	}
+	err := s.c.OrderValidator.Validate(&common.OrderParams{
+		Symbol:   s.symbol,
+		Side:     string(s.side),
+		Price:    s.price,
+		Quantity: s.quantity,
+	})
+	if err != nil {
+		return err
+	}
+	return s.c.OrderValidator.Validate(&common.OrderParams{
+		Symbol:    s.symbol,
+		Side:      string(s.side),
+		Market:    s.stopLimitPrice == nil,
+		Algo:      true,
+		Price:     s.stopLimitPrice,
+		StopPrice: s.stopPrice,
+		Quantity:  s.quantity,
+	})
+}
+
+func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, r *request, err error) {
+	if err = s.validate(); err != nil {
+		return nil, nil, err
+	}
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
@@ -333,19 +383,19 @@ func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opt
 	r.setFormParams(m)
 	data, _, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *CreateOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOCOResponse, err error) {
-	data, err := s.createOrder(ctx, "/api/v3/order/oco", opts...)
+	data, r, err := s.createOrder(ctx, "/api/v3/order/oco", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -422,7 +472,7 @@ func (s *ListOpenOrderService) Do(ctx context.Context, opts ...RequestOption) (r
 		return []*OpenOrderList{}, err
 	}
 	res = make([]*OpenOrderList, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*OpenOrderList{}, err
 	}
@@ -457,7 +507,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -510,7 +560,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -606,7 +656,7 @@ func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -669,7 +719,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -731,7 +781,7 @@ func (s *CancelOCOService) Do(ctx context.Context, opts ...RequestOption) (res *
 		return nil, err
 	}
 	res = new(CancelOCOResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -763,7 +813,7 @@ func (s *CancelOpenOrdersService) Do(ctx context.Context, opts ...RequestOption)
 		return &CancelOpenOrdersResponse{}, err
 	}
 	rawMessages := make([]*stdjson.RawMessage, 0)
-	err = json.Unmarshal(data, &rawMessages)
+	err = r.decode(data, &rawMessages)
 	if err != nil {
 		return &CancelOpenOrdersResponse{}, err
 	}
@@ -823,3 +873,287 @@ type CancelOCOResponse struct {
 	Orders            []*OCOOrder       `json:"orders"`
 	OrderReports      []*OCOOrderReport `json:"orderReports"`
 }
+
+// CancelReplaceOrderService cancel an order and place a new order on the same
+// symbol
+type CancelReplaceOrderService struct {
+	c                          *Client
+	symbol                     string
+	side                       SideType
+	orderType                  OrderType
+	cancelReplaceMode          CancelReplaceModeType
+	timeInForce                *TimeInForceType
+	quantity                   *string
+	quoteOrderQty              *string
+	price                      *string
+	cancelNewClientOrderID     *string
+	cancelOrigClientOrderID    *string
+	cancelOrderID              *int64
+	newClientOrderID           *string
+	strategyID                 *int64
+	strategyType               *int
+	stopPrice                  *string
+	trailingDelta              *string
+	icebergQuantity            *string
+	newOrderRespType           *NewOrderRespType
+	selfTradePreventionMode    *SelfTradePreventionModeType
+	cancelRestrictions         *CancelRestrictionsType
+	orderRateLimitExceededMode *OrderRateLimitExceededModeType
+}
+
+// Symbol set symbol
+func (s *CancelReplaceOrderService) Symbol(symbol string) *CancelReplaceOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CancelReplaceOrderService) Side(side SideType) *CancelReplaceOrderService {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *CancelReplaceOrderService) Type(orderType OrderType) *CancelReplaceOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// CancelReplaceMode set cancelReplaceMode
+func (s *CancelReplaceOrderService) CancelReplaceMode(cancelReplaceMode CancelReplaceModeType) *CancelReplaceOrderService {
+	s.cancelReplaceMode = cancelReplaceMode
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CancelReplaceOrderService) TimeInForce(timeInForce TimeInForceType) *CancelReplaceOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CancelReplaceOrderService) Quantity(quantity string) *CancelReplaceOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// QuoteOrderQty set quoteOrderQty
+func (s *CancelReplaceOrderService) QuoteOrderQty(quoteOrderQty string) *CancelReplaceOrderService {
+	s.quoteOrderQty = &quoteOrderQty
+	return s
+}
+
+// Price set price
+func (s *CancelReplaceOrderService) Price(price string) *CancelReplaceOrderService {
+	s.price = &price
+	return s
+}
+
+// CancelNewClientOrderID set cancelNewClientOrderId
+func (s *CancelReplaceOrderService) CancelNewClientOrderID(cancelNewClientOrderID string) *CancelReplaceOrderService {
+	s.cancelNewClientOrderID = &cancelNewClientOrderID
+	return s
+}
+
+// CancelOrigClientOrderID set cancelOrigClientOrderId
+func (s *CancelReplaceOrderService) CancelOrigClientOrderID(cancelOrigClientOrderID string) *CancelReplaceOrderService {
+	s.cancelOrigClientOrderID = &cancelOrigClientOrderID
+	return s
+}
+
+// CancelOrderID set cancelOrderId
+func (s *CancelReplaceOrderService) CancelOrderID(cancelOrderID int64) *CancelReplaceOrderService {
+	s.cancelOrderID = &cancelOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderId
+func (s *CancelReplaceOrderService) NewClientOrderID(newClientOrderID string) *CancelReplaceOrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StrategyID set strategyId
+func (s *CancelReplaceOrderService) StrategyID(strategyID int64) *CancelReplaceOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StrategyType set strategyType
+func (s *CancelReplaceOrderService) StrategyType(strategyType int) *CancelReplaceOrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *CancelReplaceOrderService) StopPrice(stopPrice string) *CancelReplaceOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// TrailingDelta set trailingDelta
+func (s *CancelReplaceOrderService) TrailingDelta(trailingDelta string) *CancelReplaceOrderService {
+	s.trailingDelta = &trailingDelta
+	return s
+}
+
+// IcebergQuantity set icebergQuantity
+func (s *CancelReplaceOrderService) IcebergQuantity(icebergQuantity string) *CancelReplaceOrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CancelReplaceOrderService) NewOrderRespType(newOrderRespType NewOrderRespType) *CancelReplaceOrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CancelReplaceOrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CancelReplaceOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// CancelRestrictions set cancelRestrictions
+func (s *CancelReplaceOrderService) CancelRestrictions(cancelRestrictions CancelRestrictionsType) *CancelReplaceOrderService {
+	s.cancelRestrictions = &cancelRestrictions
+	return s
+}
+
+// OrderRateLimitExceededMode set orderRateLimitExceededMode
+func (s *CancelReplaceOrderService) OrderRateLimitExceededMode(orderRateLimitExceededMode OrderRateLimitExceededModeType) *CancelReplaceOrderService {
+	s.orderRateLimitExceededMode = &orderRateLimitExceededMode
+	return s
+}
+
+// Do send request. When the cancel or the new order fails, the error is
+// returned with the response, which tells which one failed and why.
+func (s *CancelReplaceOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelReplaceOrderResponse, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:        s.symbol,
+			Side:          string(s.side),
+			Market:        s.price == nil,
+			Algo:          s.orderType.isAlgo(),
+			Replace:       true,
+			Price:         s.price,
+			StopPrice:     s.stopPrice,
+			Quantity:      s.quantity,
+			QuoteQuantity: s.quoteOrderQty,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/order/cancelReplace",
+		secType:  secTypeSigned,
+		wsMethod: "order.cancelReplace",
+	}
+	m := params{
+		"symbol":            s.symbol,
+		"side":              s.side,
+		"type":              s.orderType,
+		"cancelReplaceMode": s.cancelReplaceMode,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQty != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQty
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.cancelNewClientOrderID != nil {
+		m["cancelNewClientOrderId"] = *s.cancelNewClientOrderID
+	}
+	if s.cancelOrigClientOrderID != nil {
+		m["cancelOrigClientOrderId"] = *s.cancelOrigClientOrderID
+	}
+	if s.cancelOrderID != nil {
+		m["cancelOrderId"] = *s.cancelOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.trailingDelta != nil {
+		m["trailingDelta"] = *s.trailingDelta
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.cancelRestrictions != nil {
+		m["cancelRestrictions"] = *s.cancelRestrictions
+	}
+	if s.orderRateLimitExceededMode != nil {
+		m["orderRateLimitExceededMode"] = *s.orderRateLimitExceededMode
+	}
+	r.setFormParams(m)
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		// the results of a partial failure are the data of the error
+		var apiErr *common.APIError
+		if !errors.As(err, &apiErr) || len(apiErr.Data) == 0 {
+			return nil, err
+		}
+		res = new(CancelReplaceOrderResponse)
+		if r.decode(apiErr.Data, res) != nil {
+			return nil, err
+		}
+		return res, err
+	}
+	res = new(CancelReplaceOrderResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelReplaceOrderResponse define cancel replace order response
+type CancelReplaceOrderResponse struct {
+	CancelResult   CancelReplaceResultType `json:"cancelResult"`
+	NewOrderResult CancelReplaceResultType `json:"newOrderResult"`
+	// CancelResponse is the canceled order, or the error of the cancel
+	CancelResponse *CancelReplaceCancelResponse `json:"cancelResponse"`
+	// NewOrderResponse is the new order, or the error of the new order, nil
+	// if it was not attempted
+	NewOrderResponse *CancelReplaceNewOrderResponse `json:"newOrderResponse"`
+}
+
+// CancelReplaceCancelResponse is the cancel of a cancel-replace, Code and
+// Message are set if it failed
+type CancelReplaceCancelResponse struct {
+	CancelOrderResponse
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// CancelReplaceNewOrderResponse is the new order of a cancel-replace, Code
+// and Message are set if it failed
+type CancelReplaceNewOrderResponse struct {
+	CreateOrderResponse
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
@@ -1,8 +1,11 @@
 package binance
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -169,6 +172,64 @@ func (s *orderServiceTestSuite) TestCreateOrderFull() {
 	s.r().NoError(err)
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderRetryFound() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 28,
+		"orderListId": -1,
+		"clientOrderId": "testOrder",
+		"price": "1.00000000",
+		"origQty": "10.00000000",
+		"executedQty": "10.00000000",
+		"cummulativeQuoteQty": "10.00000000",
+		"status": "FILLED",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "SELL",
+		"stopPrice": "0.00000000",
+		"icebergQty": "0.00000000",
+		"time": 1507725176595,
+		"updateTime": 1507725176600,
+		"isWorking": true,
+		"origQuoteOrderQty": "0.000000"
+	}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").
+		Side(SideTypeSell).Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("10").Price("1").NewClientOrderID("testOrder").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	// the order found by its client order id has no fills
+	r.Equal(&CreateOrderResponse{
+		Symbol:                   "BTCUSDT",
+		OrderID:                  28,
+		ClientOrderID:            "testOrder",
+		TransactTime:             1507725176595,
+		Price:                    "1.00000000",
+		OrigQuantity:             "10.00000000",
+		ExecutedQuantity:         "10.00000000",
+		CummulativeQuoteQuantity: "10.00000000",
+		Status:                   OrderStatusTypeFilled,
+		TimeInForce:              TimeInForceTypeGTC,
+		Type:                     OrderTypeLimit,
+		Side:                     SideTypeSell,
+		Fills:                    []*Fill{},
+	}, res)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
+
+func (s *orderServiceTestSuite) TestCancelOrderNotRetried() {
+	s.mockDo([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), nil, http.StatusBadRequest)
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	_, err := s.client.NewCancelOrderService().Symbol("BTCUSDT").OrderID(28).Do(newContext())
+	s.r().ErrorIs(err, common.ErrUnknownExecutionStatus)
+	s.client.AssertNumberOfCalls(s.T(), "do", 1)
+}
+
 func (s *baseOrderTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderResponse) {
 	r := s.r()
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
@@ -389,7 +450,7 @@ func (s *baseOrderTestSuite) assertOCOOrderEqual(e, a *OCOOrder) {
 	r.Equal(e.OrderID, a.OrderID, "OrderID")
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
 }
-func (s *orderServiceTestSuite) TestListOpenOco() {
+func (s *orderServiceTestSuite) TestListOpenOrderLists() {
 	data := []byte(`[
 		{
 			"orderListId": 31,
@@ -422,12 +483,12 @@ func (s *orderServiceTestSuite) TestListOpenOco() {
 		})
 		s.assertRequestEqual(e, r)
 	})
-	ocos, err := s.client.NewListOpenOcoService().
+	orderLists, err := s.client.NewListOpenOrderService().
 		Do(newContext(), WithRecvWindow(recvWindow))
 	r := s.r()
 	r.NoError(err)
-	r.Len(ocos, 1)
-	e := &Oco{
+	r.Len(orderLists, 1)
+	e := &OpenOrderList{
 		Symbol:            "LTCBTC",
 		OrderListId:       31,
 		ContingencyType:   "OCO",
@@ -448,9 +509,9 @@ func (s *orderServiceTestSuite) TestListOpenOco() {
 			},
 		},
 	}
-	s.assertOcoEqual(e, ocos[0])
+	s.assertOpenOrderListEqual(e, orderLists[0])
 }
-func (s *baseOrderTestSuite) assertOcoEqual(e, a *Oco) {
+func (s *baseOrderTestSuite) assertOpenOrderListEqual(e, a *OpenOrderList) {
 	r := s.r()
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
 	r.Equal(e.ContingencyType, a.ContingencyType, "ContingencyType")
@@ -1043,3 +1104,111 @@ func (s *baseOrderTestSuite) assertCancelOCOResponseEqual(e, a *CancelOCORespons
 		s.assertOCOOrderEqual(order, a.Orders[idx])
 	}
 }
+
+func (s *orderServiceTestSuite) TestCancelReplaceOrder() {
+	data := []byte(`{
+		"cancelResult": "SUCCESS",
+		"newOrderResult": "SUCCESS",
+		"cancelResponse": {
+			"symbol": "BTCUSDT",
+			"origClientOrderId": "DnLo3vTAQcjha43lAZhZ0y",
+			"orderId": 9,
+			"orderListId": -1,
+			"clientOrderId": "osxN3JXAtJvKvCqGeMWMVR",
+			"transactTime": 1684804350068,
+			"price": "0.01000010",
+			"origQty": "0.000100",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "CANCELED",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "SELL"
+		},
+		"newOrderResponse": {
+			"symbol": "BTCUSDT",
+			"orderId": 10,
+			"orderListId": -1,
+			"clientOrderId": "wOceeeOzNORyLiQfw7jd8S",
+			"transactTime": 1652928801803,
+			"price": "0.02000000",
+			"origQty": "0.040000",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "NEW",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "BUY"
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":             "BTCUSDT",
+			"side":               SideTypeBuy,
+			"type":               OrderTypeLimit,
+			"cancelReplaceMode":  CancelReplaceModeTypeStopOnFailure,
+			"timeInForce":        TimeInForceTypeGTC,
+			"quantity":           "0.04",
+			"price":              "0.02",
+			"cancelOrderId":      int64(9),
+			"cancelRestrictions": CancelRestrictionsTypeOnlyNew,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).CancelReplaceMode(CancelReplaceModeTypeStopOnFailure).
+		TimeInForce(TimeInForceTypeGTC).Quantity("0.04").Price("0.02").CancelOrderID(9).
+		CancelRestrictions(CancelRestrictionsTypeOnlyNew).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeSuccess, res.NewOrderResult)
+	r.Equal(int64(9), res.CancelResponse.OrderID)
+	r.Equal(OrderStatusTypeCanceled, res.CancelResponse.Status)
+	r.Equal(int64(10), res.NewOrderResponse.OrderID)
+	r.Equal("0.040000", res.NewOrderResponse.OrigQuantity)
+	r.Zero(res.NewOrderResponse.Code)
+}
+
+func (s *orderServiceTestSuite) TestCancelReplaceOrderPartialFailure() {
+	data := []byte(`{
+		"code": -2021,
+		"msg": "Order cancel-replace partially failed.",
+		"data": {
+			"cancelResult": "SUCCESS",
+			"newOrderResult": "FAILURE",
+			"cancelResponse": {
+				"symbol": "BTCUSDT",
+				"origClientOrderId": "86M8erehfExV8z2RC8Zo8k",
+				"orderId": 3,
+				"orderListId": -1,
+				"clientOrderId": "G1kLo6aDv2KGNTFcjfTSFq",
+				"status": "CANCELED",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			},
+			"newOrderResponse": {
+				"code": -2010,
+				"msg": "Order would immediately match and take."
+			}
+		}
+	}`)
+	s.mockDo(data, nil, 409)
+	defer s.assertDo()
+	res, err := s.client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimitMaker).CancelReplaceMode(CancelReplaceModeTypeAllowFailure).
+		Quantity("1").Price("0.0003").CancelOrderID(3).Do(newContext())
+	r := s.r()
+	r.Error(err)
+	apiErr, ok := err.(*common.APIError)
+	r.True(ok)
+	r.Equal(int64(-2021), apiErr.Code)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeFailure, res.NewOrderResult)
+	r.Equal(int64(3), res.CancelResponse.OrderID)
+	r.Zero(res.CancelResponse.Code)
+	r.Equal(int64(-2010), res.NewOrderResponse.Code)
+	r.Equal("Order would immediately match and take.", res.NewOrderResponse.Message)
+}
@@ -52,7 +52,7 @@ func (s *PayTradeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res := PayTradeHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -24,7 +23,7 @@ func (s *GetBalanceService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Balance{}, err
 	}
 	res = make([]*Balance, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Balance{}, err
 	}
@@ -65,7 +64,7 @@ func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(Account)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +112,7 @@ func (s *GetAccountExtService) Do(ctx context.Context, opts ...RequestOption) (r
 		return nil, err
 	}
 	res = new(AccountExt)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -3,16 +3,17 @@ package portfolio
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bitly/go-simplejson"
@@ -208,13 +209,20 @@ func getApiEndpoint() string {
 // You should always call this function before using this SDK.
 // Services will be created by the form client.NewXXXService().
 func NewClient(apiKey, secretKey string) *Client {
+	return NewClientWithEnvironment(apiKey, secretKey, MainEnvironment())
+}
+
+// NewClientWithEnvironment initialize an API client instance with the
+// endpoints of the environment instead of the ones of the production
+func NewClientWithEnvironment(apiKey, secretKey string, env Environment) *Client {
 	return &Client{
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		BaseURL:    getApiEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		APIKey:      apiKey,
+		SecretKey:   secretKey,
+		BaseURL:     env.BaseURL,
+		UserAgent:   "Binance/golang",
+		HTTPClient:  http.DefaultClient,
+		Logger:      log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+		WsStreamURL: env.WsStreamURL,
 	}
 }
 
@@ -246,19 +254,77 @@ type doFunc func(req *http.Request) (*http.Response, error)
 type Client struct {
 	APIKey     string
 	SecretKey  string
+	Signer     common.Signer
 	BaseURL    string
 	UserAgent  string
 	HTTPClient *http.Client
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	timeLock   sync.RWMutex
 	do         doFunc
+	// WsStreamURL is the base endpoint of the user data streams of the
+	// client, the production one if it is empty
+	WsStreamURL string
+
+	// RateLimiter keeps the calls under the rate limits, nil to let the
+	// server enforce them
+	RateLimiter *common.RateLimitGovernor
+	// RetryPolicy sends the failed requests again when it is safe, nil to
+	// send them once
+	RetryPolicy *common.RetryPolicy
+	// Interceptors wrap every API call, the first one is
+	// the outermost
+	Interceptors []common.Interceptor
+	// Instrumentation receives the spans of the API calls, nil to ignore
+	// them
+	Instrumentation common.Instrumentation
+	// StructuredLogger receives the leveled logs of the client, with the
+	// secrets redacted. Logger is used if it is nil, from the debug level if
+	// Debug is set.
+	StructuredLogger common.Logger
+	// TimeSync, if set and synced, gives the time offset of the signed
+	// requests instead of TimeOffset. It is synced again when a request is
+	// rejected for its timestamp, and the request is sent once again.
+	TimeSync *common.TimeSync
+}
+
+// timeOffset return the offset of the local time to the server time in
+// milliseconds
+func (c *Client) timeOffset() int64 {
+	if c.TimeSync != nil && c.TimeSync.Synced() {
+		return c.TimeSync.Offset().Milliseconds()
+	}
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.TimeOffset
 }
 
-func (c *Client) debug(format string, v ...interface{}) {
+// setTimeOffset set TimeOffset, which the requests may be reading
+func (c *Client) setTimeOffset(offset int64) {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+	c.TimeOffset = offset
+}
+
+// logger return the logger of the client, which redacts the secrets
+func (c *Client) logger() common.Logger {
+	if c.StructuredLogger != nil {
+		return common.NewRedactingLogger(c.StructuredLogger)
+	}
+	level := common.LogLevelInfo
 	if c.Debug {
-		c.Logger.Printf(format, v...)
+		level = common.LogLevelDebug
 	}
+	return common.NewRedactingLogger(common.NewStdLogger(c.Logger, level))
+}
+
+// signer return the signer of SIGNED requests, HMAC with SecretKey by default
+func (c *Client) signer() common.Signer {
+	if c.Signer != nil {
+		return c.Signer
+	}
+	return common.NewHMACSigner(c.SecretKey)
 }
 
 func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
@@ -276,7 +342,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		r.setParam(recvWindowKey, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+		r.setParam(timestampKey, currentTimestamp()-c.timeOffset())
 	}
 	queryString := r.query.Encode()
 	body := &bytes.Buffer{}
@@ -295,13 +361,12 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 
 	if r.secType == secTypeSigned {
 		raw := fmt.Sprintf("%s%s", queryString, bodyString)
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
+		v.Set(signatureKey, sig)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -311,7 +376,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	c.debug("full url: %s, body: %s", fullURL, bodyString)
+	c.logger().Debug("request url", "url", fullURL, "body", bodyString)
 
 	r.fullURL = fullURL
 	r.header = header
@@ -319,7 +384,115 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 	return nil
 }
 
+// callAPI send the request, and send it once again after syncing TimeSync
+// if its timestamp is outside of the recvWindow
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	if c.TimeSync == nil || r.secType != secTypeSigned {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	rc := r.clone()
+	data, header, err = c.retryAPI(ctx, rc, opts...)
+	r.statusCode = rc.statusCode
+	if errors.Is(err, common.ErrTimestampOutsideRecvWindow) && c.TimeSync.Resync(ctx) == nil {
+		return c.retryAPI(ctx, r, opts...)
+	}
+	return data, header, err
+}
+
+// retryAPI send the request, and send it again according to RetryPolicy
+func (c *Client) retryAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	if c.RetryPolicy == nil {
+		return c.callAPIOnce(ctx, r, opts...)
+	}
+	err = c.RetryPolicy.Do(ctx, &common.RetryRequest{
+		Idempotent:    r.idempotent(),
+		PlacesOrder:   r.orderCount() > 0,
+		ClientOrderID: r.param("newClientOrderId"),
+		Send: func() (err error) {
+			rc := r.clone()
+			data, header, err = c.callAPIOnce(ctx, rc, opts...)
+			r.statusCode = rc.statusCode
+			return err
+		},
+		LookupOrder: c.lookupOrder(ctx, r, &data),
+	})
+	return data, header, err
+}
+
+// lookupOrder return a function which finds the order placed by the request
+// by its client order id, nil if the order cannot be queried
+func (c *Client) lookupOrder(ctx context.Context, r *request, data *[]byte) func() (bool, error) {
+	if r.endpoint != "/papi/v1/um/order" && r.endpoint != "/papi/v1/cm/order" {
+		return nil
+	}
+	return func() (bool, error) {
+		order, err := c.NewGetOrderService().Which(strings.Split(r.endpoint, "/")[3]).Symbol(r.param("symbol")).OrigClientOrderID(r.param("newClientOrderId")).Do(ctx)
+		if errors.Is(err, common.ErrUnknownOrder) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		*data, err = json.Marshal(order)
+		return err == nil, err
+	}
+}
+
+// callAPIOnce send the request without retrying it
+func (c *Client) callAPIOnce(ctx context.Context, r *request, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	if c.RateLimiter != nil {
+		if err = c.RateLimiter.Acquire(ctx, r.method, r.endpoint, r.weight(), r.orderCount()); err != nil {
+			return []byte{}, &http.Header{}, err
+		}
+	}
+	ctx, span := c.startSpan(ctx, r)
+	if len(c.Interceptors) > 0 {
+		data, header, err = c.intercept(ctx, r, opts...)
+	} else {
+		data, header, err = c.sendAPI(ctx, r, opts...)
+	}
+	span.Finish(common.NewSpanEnd(r.statusCode, r.rateLimits, err))
+	return data, header, err
+}
+
+func (c *Client) instrumentation() common.Instrumentation {
+	return common.InstrumentationOrNoop(c.Instrumentation)
+}
+
+// startSpan start the span of the request
+func (c *Client) startSpan(ctx context.Context, r *request) (context.Context, common.Span) {
+	return c.instrumentation().StartSpan(ctx, &common.SpanStart{BaseURL: c.BaseURL, Method: r.method, Endpoint: r.endpoint, Time: time.Now()})
+}
+
+// intercept send the request through the Interceptors
+func (c *Client) intercept(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *http.Header, error) {
+	// the interceptors see the request options
+	for _, opt := range opts {
+		opt(r)
+	}
+	header := &http.Header{}
+	invoker := common.ChainInterceptors(c.Interceptors, func(ctx context.Context, req *common.APIRequest) (*common.APIResponse, error) {
+		r.setAPIRequest(req)
+		data, h, err := c.sendAPI(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		header = h
+		return &common.APIResponse{Data: data, StatusCode: r.statusCode, RateLimits: r.rateLimits}, nil
+	})
+	res, err := invoker(ctx, r.apiRequest())
+	if err != nil {
+		return []byte{}, &http.Header{}, err
+	}
+	if res == nil {
+		return []byte{}, header, nil
+	}
+	r.statusCode, r.rateLimits = res.StatusCode, res.RateLimits
+	return res.Data, header, nil
+}
+
+// sendAPI send the request to the REST API
+func (c *Client) sendAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, header *http.Header, err error) {
 	err = c.parseRequest(r, opts...)
 	if err != nil {
 		return []byte{}, &http.Header{}, err
@@ -330,18 +503,18 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
-	c.debug("request: %#v", req)
+	c.logger().Debug("request", "method", req.Method, "url", req.URL.String(), "header", req.Header)
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
 	res, err := f(req)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	data, err = ioutil.ReadAll(res.Body)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, &common.RequestError{Method: r.method, Endpoint: r.endpoint, Err: err}
 	}
 	defer func() {
 		cerr := res.Body.Close()
@@ -351,17 +524,30 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 			err = cerr
 		}
 	}()
-	c.debug("response: %#v", res)
-	c.debug("response body: %s", string(data))
-	c.debug("response status code: %d", res.StatusCode)
-
+	c.logger().Debug("response", "status", res.StatusCode, "header", res.Header, "body", string(data))
+
+	r.statusCode = res.StatusCode
+	r.rateLimits = common.RateLimitsFromHeader(res.Header)
+	if c.RateLimiter != nil {
+		c.RateLimiter.Update(r.rateLimits...)
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			c.RateLimiter.Backoff(common.RetryAfterFromHeader(res.Header))
+		}
+	}
 	if res.StatusCode >= http.StatusBadRequest {
 		apiErr := new(common.APIError)
 		e := json.Unmarshal(data, apiErr)
 		if e != nil {
 			apiErr.Code = int64(res.StatusCode)
 			apiErr.Message = string(data)
-			c.debug("failed to unmarshal json: %s", e)
+			c.logger().Debug("failed to unmarshal json", "error", e)
+		}
+		apiErr.StatusCode = res.StatusCode
+		apiErr.Method = r.method
+		apiErr.Endpoint = r.endpoint
+		apiErr.RateLimits = r.rateLimits
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+			apiErr.RetryAfter = common.RetryAfterFromHeader(res.Header)
 		}
 		return nil, &http.Header{}, apiErr
 	}
@@ -33,7 +33,7 @@ func (s *AssetCollectionService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return
 	}
@@ -76,7 +76,7 @@ func (s *BnbTransferService) Do(ctx context.Context, opts ...RequestOption) (tra
 	if err != nil {
 		return
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return
 	}
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -43,7 +42,7 @@ func (s *CommissionRateService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(CommissionRate)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+package portfolio
+
+// Environment define the endpoints of a client, e.g. of a regional host
+type Environment struct {
+	// BaseURL of the REST API
+	BaseURL string
+	// WsStreamURL of the user data streams
+	WsStreamURL string
+}
+
+// MainEnvironment return the endpoints of the production
+func MainEnvironment() Environment {
+	return Environment{
+		BaseURL:     baseApiMainUrl,
+		WsStreamURL: baseWsMainUrl,
+	}
+}
+
+// Environment return the endpoints of the client
+func (c *Client) Environment() Environment {
+	return Environment{
+		BaseURL:     c.BaseURL,
+		WsStreamURL: c.wsEndpoint(),
+	}
+}
+
+// wsEndpoint return the base endpoint of the user data streams of the
+// client, the production one if it has none
+func (c *Client) wsEndpoint() string {
+	if c.WsStreamURL != "" {
+		return c.WsStreamURL
+	}
+	return getWsEndpoint()
+}
@@ -0,0 +1,39 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithEnvironment(t *testing.T) {
+	env := Environment{
+		BaseURL:     "https://papi.example.com",
+		WsStreamURL: "wss://fstream.example.com/pm/ws",
+	}
+	c := NewClientWithEnvironment("apiKey", "secretKey", env)
+	assert.Equal(t, env, c.Environment())
+
+	var endpoints []string
+	origWsServe := wsServe
+	defer func() { wsServe = origWsServe }()
+	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+		endpoints = append(endpoints, cfg.Endpoint)
+		return make(chan struct{}), make(chan struct{}), nil
+	}
+
+	_, _, err := c.WsUserDataServe("listenKey", func(event *WsUserDataEvent) {}, nil)
+	assert.NoError(t, err)
+	_, _, err = WsUserDataServe("listenKey", func(event *WsUserDataEvent) {}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"wss://fstream.example.com/pm/ws/listenKey",
+		"wss://fstream.binance.com/pm/ws/listenKey",
+	}, endpoints)
+}
+
+func TestClientEnvironmentDefault(t *testing.T) {
+	c := NewClient("apiKey", "secretKey")
+	assert.Equal(t, MainEnvironment(), c.Environment())
+	assert.Equal(t, MainEnvironment().WsStreamURL, new(Client).Environment().WsStreamURL)
+}
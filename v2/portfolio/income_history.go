@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -83,7 +82,7 @@ func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = make([]*IncomeHistory, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -64,7 +63,7 @@ func (s *GetInterestHistoryService) Do(ctx context.Context, opts ...RequestOptio
 		return nil, err
 	}
 	res = make([]*InterestHistory, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
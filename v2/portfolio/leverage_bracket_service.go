@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -41,7 +40,7 @@ func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOptio
 		return []*LeverageBracket{}, err
 	}
 	res = make([]*LeverageBracket, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*LeverageBracket{}, err
 	}
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -133,9 +132,9 @@ func (s *CreateOrderService) NewOrderResponseType(newOrderResponseType NewOrderR
 //	return s
 //}
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, r *request, err error) {
 
-	r := &request{
+	r = &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
 		secType:  secTypeSigned,
@@ -185,9 +184,9 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	r.setFormParams(m)
 	data, header, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, &http.Header{}, err
+		return []byte{}, &http.Header{}, r, err
 	}
-	return data, header, nil
+	return data, header, r, nil
 }
 
 // Do send request
@@ -196,12 +195,12 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, errWhichMissing
 	}
 	endpoint := fmt.Sprintf("/papi/v1/%s/order", s.which)
-	data, header, err := s.createOrder(ctx, endpoint, opts...)
+	data, header, r, err := s.createOrder(ctx, endpoint, opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = new(CreateOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	res.RateLimitOrder10s = header.Get("X-Mbx-Order-Count-10s")
 	res.RateLimitOrder1m = header.Get("X-Mbx-Order-Count-1m")
 
@@ -284,7 +283,7 @@ func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -345,7 +344,7 @@ func (s *GetOpenOrderService) Do(ctx context.Context, opts ...RequestOption) (re
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -407,7 +406,7 @@ func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *O
 		return nil, err
 	}
 	res = new(Order)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -520,7 +519,7 @@ func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*Order{}, err
 	}
 	res = make([]*Order, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Order{}, err
 	}
@@ -582,7 +581,7 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 		return nil, err
 	}
 	res = new(CancelOrderResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -727,7 +726,7 @@ func (s *ListUserLiquidationOrdersService) Do(ctx context.Context, opts ...Reque
 		return []*UserLiquidationOrder{}, err
 	}
 	res = make([]*UserLiquidationOrder, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*UserLiquidationOrder{}, err
 	}
@@ -796,7 +795,7 @@ func (s *ListMarginForceOrdersService) Do(ctx context.Context, opts ...RequestOp
 		return nil, err
 	}
 	res = new(MarginForceOrders)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,10 @@
 package portfolio
 
 import (
-	"context"
+	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
@@ -36,12 +38,9 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 		"timeInForce": "GTC",
 		"type": "LIMIT",
 		"updateTime": 1566818724722,
-		"workingType": "CONTRACT_PRICE",
-		"activatePrice": "1000",
-		"priceRate": "0.1",
 		"positionSide": "BOTH",
-		"closePosition": false,
-		"priceProtect": true
+		"selfTradePreventionMode": "NONE",
+		"goodTillDate": 0
 	}`)
 	s.mockDo(data, nil)
 	defer s.assertDo()
@@ -54,13 +53,7 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 	price := "10000"
 	newClientOrderID := "testOrder"
 	reduceOnly := false
-	stopPrice := "0"
-	activationPrice := "1000"
-	callbackRate := "0.1"
-	workingType := WorkingTypeContractPrice
-	priceProtect := true
 	newOrderResponseType := NewOrderRespTypeRESULT
-	closePosition := false
 	s.assertReq(func(r *request) {
 		e := newSignedRequest().setFormParams(params{
 			"symbol":           symbol,
@@ -72,57 +65,86 @@ func (s *orderServiceTestSuite) TestCreateOrder() {
 			"reduceOnly":       reduceOnly,
 			"price":            price,
 			"newClientOrderId": newClientOrderID,
-			"stopPrice":        stopPrice,
-			"workingType":      workingType,
-			"activationPrice":  activationPrice,
-			"callbackRate":     callbackRate,
-			"priceProtect":     priceProtect,
 			"newOrderRespType": newOrderResponseType,
-			"closePosition":    closePosition,
 		})
 		s.assertRequestEqual(e, r)
 	})
-	res, err := s.client.NewCreateOrderService().Symbol(symbol).Side(side).
-		Type(orderType).TimeInForce(timeInForce).Quantity(quantity).ClosePosition(closePosition).
+	res, err := s.client.NewCreateOrderService().Which("um").Symbol(symbol).Side(side).
+		Type(orderType).TimeInForce(timeInForce).Quantity(quantity).
 		ReduceOnly(reduceOnly).Price(price).NewClientOrderID(newClientOrderID).
-		StopPrice(stopPrice).WorkingType(workingType).ActivationPrice(activationPrice).
-		CallbackRate(callbackRate).PositionSide(positionSide).
-		PriceProtect(priceProtect).NewOrderResponseType(newOrderResponseType).
+		PositionSide(positionSide).NewOrderResponseType(newOrderResponseType).
 		Do(newContext())
 	s.r().NoError(err)
 	e := &CreateOrderResponse{
-		ClientOrderID:    newClientOrderID,
-		CumQuote:         "0",
-		ExecutedQuantity: "0",
-		OrderID:          22542179,
-		OrigQuantity:     "10",
-		PositionSide:     positionSide,
-		Price:            "10000",
-		ReduceOnly:       false,
-		Side:             SideTypeSell,
-		Status:           OrderStatusTypeNew,
-		StopPrice:        "0",
-		Symbol:           symbol,
-		TimeInForce:      TimeInForceTypeGTC,
-		Type:             OrderTypeLimit,
-		UpdateTime:       1566818724722,
-		WorkingType:      WorkingTypeContractPrice,
-		ActivatePrice:    activationPrice,
-		PriceRate:        callbackRate,
-		ClosePosition:    false,
-		PriceProtect:     priceProtect,
+		ClientOrderId:           newClientOrderID,
+		CumQuote:                "0",
+		ExecutedQty:             "0",
+		OrderId:                 22542179,
+		OrigQty:                 "10",
+		PositionSide:            positionSide,
+		Price:                   "10000",
+		ReduceOnly:              false,
+		Side:                    SideTypeSell,
+		Status:                  OrderStatusTypeNew,
+		StopPrice:               "0",
+		Symbol:                  symbol,
+		TimeInForce:             TimeInForceTypeGTC,
+		OrderType:               OrderTypeLimit,
+		SelfTradePreventionMode: "NONE",
+		UpdateTime:              1566818724722,
 	}
 	s.assertCreateOrderResponseEqual(e, res)
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderRetryFound() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"orderId": 22542179, "clientOrderId": "testOrder", "status": "FILLED"}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	var queries []url.Values
+	s.assertReq(func(r *request) {
+		queries = append(queries, r.query)
+	})
+	res, err := s.client.NewCreateOrderService().Which("um").Symbol("BTCUSDT").
+		Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1").
+		NewClientOrderID("testOrder").Do(newContext())
+	s.r().NoError(err)
+	s.r().EqualValues(22542179, res.OrderId)
+	s.r().Equal(OrderStatusTypeFilled, res.Status)
+	// the order is looked up by its client order id
+	s.r().Len(queries, 2)
+	s.r().Equal("BTCUSDT", queries[1].Get("symbol"))
+	s.r().Equal("testOrder", queries[1].Get("origClientOrderId"))
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
+
+func (s *orderServiceTestSuite) TestCreateOrderNotRetried() {
+	s.mockDo([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), nil, http.StatusBadRequest)
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	_, err := s.client.NewCreateOrderService().Which("um").Symbol("BTCUSDT").
+		Side(SideTypeBuy).Type(OrderTypeMarket).Quantity("1").Do(newContext())
+	s.r().ErrorIs(err, common.ErrUnknownExecutionStatus)
+	s.client.AssertNumberOfCalls(s.T(), "do", 1)
+}
+
+func (s *orderServiceTestSuite) TestCancelOrderNotRetried() {
+	s.mockDo([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), nil, http.StatusBadRequest)
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	_, err := s.client.NewCancelOrderService().Which("um").Symbol("BTCUSDT").OrderID(28).Do(newContext())
+	s.r().ErrorIs(err, common.ErrUnknownExecutionStatus)
+	s.client.AssertNumberOfCalls(s.T(), "do", 1)
+}
+
 func (s *baseOrderTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderResponse) {
 	r := s.r()
-	r.Equal(e.ClientOrderID, a.ClientOrderID, "ClientOrderID")
+	r.Equal(e.ClientOrderId, a.ClientOrderId, "ClientOrderId")
 	r.Equal(e.CumQuote, a.CumQuote, "CumQuote")
-	r.Equal(e.PriceProtect, a.PriceProtect, "PriceProtect")
-	r.Equal(e.ExecutedQuantity, a.ExecutedQuantity, "ExecutedQuantity")
-	r.Equal(e.OrderID, a.OrderID, "OrderID")
-	r.Equal(e.OrigQuantity, a.OrigQuantity, "OrigQuantity")
+	r.Equal(e.ExecutedQty, a.ExecutedQty, "ExecutedQty")
+	r.Equal(e.OrderId, a.OrderId, "OrderId")
+	r.Equal(e.OrigQty, a.OrigQty, "OrigQty")
 	r.Equal(e.PositionSide, a.PositionSide, "PositionSide")
 	r.Equal(e.Price, a.Price, "Price")
 	r.Equal(e.ReduceOnly, a.ReduceOnly, "ReduceOnly")
@@ -131,12 +153,9 @@ func (s *baseOrderTestSuite) assertCreateOrderResponseEqual(e, a *CreateOrderRes
 	r.Equal(e.StopPrice, a.StopPrice, "StopPrice")
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
 	r.Equal(e.TimeInForce, a.TimeInForce, "TimeInForce")
-	r.Equal(e.Type, a.Type, "Type")
+	r.Equal(e.OrderType, a.OrderType, "OrderType")
+	r.Equal(e.SelfTradePreventionMode, a.SelfTradePreventionMode, "SelfTradePreventionMode")
 	r.Equal(e.UpdateTime, a.UpdateTime, "UpdateTime")
-	r.Equal(e.WorkingType, a.WorkingType, "WorkingType")
-	r.Equal(e.ActivatePrice, a.ActivatePrice, "ActivatePrice")
-	r.Equal(e.PriceRate, a.PriceRate, "PriceRate")
-	r.Equal(e.ClosePosition, a.ClosePosition, "ClosePosition")
 }
 
 func (s *orderServiceTestSuite) TestListOpenOrders() {
@@ -176,7 +195,7 @@ func (s *orderServiceTestSuite) TestListOpenOrders() {
 		})
 		s.assertRequestEqual(e, r)
 	})
-	orders, err := s.client.NewListOpenOrdersService().Symbol(symbol).
+	orders, err := s.client.NewListOpenOrdersService().Which("um").Symbol(symbol).
 		Do(newContext(), WithRecvWindow(recvWindow))
 	r := s.r()
 	r.NoError(err)
@@ -267,7 +286,7 @@ func (s *orderServiceTestSuite) TestGetOpenOrder() {
 		})
 		s.assertRequestEqual(e, r)
 	})
-	order, err := s.client.NewGetOpenOrderService().Symbol(symbol).OrderID(orderId).
+	order, err := s.client.NewGetOpenOrderService().Which("um").Symbol(symbol).OrderID(orderId).
 		Do(newContext(), WithRecvWindow(recvWindow))
 	r := s.r()
 	r.NoError(err)
@@ -333,7 +352,7 @@ func (s *orderServiceTestSuite) TestGetOrder() {
 		})
 		s.assertRequestEqual(e, r)
 	})
-	order, err := s.client.NewGetOrderService().Symbol(symbol).
+	order, err := s.client.NewGetOrderService().Which("um").Symbol(symbol).
 		OrderID(orderID).OrigClientOrderID(origClientOrderID).Do(newContext())
 	r := s.r()
 	r.NoError(err)
@@ -405,7 +424,7 @@ func (s *orderServiceTestSuite) TestListOrders() {
 		s.assertRequestEqual(e, r)
 	})
 
-	orders, err := s.client.NewListOrdersService().Symbol(symbol).
+	orders, err := s.client.NewListOrdersService().Which("um").Symbol(symbol).
 		OrderID(orderID).StartTime(startTime).EndTime(endTime).
 		Limit(limit).Do(newContext())
 	r := s.r()
@@ -473,7 +492,7 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 		s.assertRequestEqual(e, r)
 	})
 
-	res, err := s.client.NewCancelOrderService().Symbol(symbol).
+	res, err := s.client.NewCancelOrderService().Which("um").Symbol(symbol).
 		OrderID(orderID).OrigClientOrderID(origClientOrderID).
 		Do(newContext())
 	r := s.r()
@@ -492,7 +511,7 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 		StopPrice:        "8300",
 		Symbol:           symbol,
 		TimeInForce:      TimeInForceTypeGTC,
-		Type:             OrderTypeTakeProfit,
+		Type:             OrderType("TAKE_PROFIT"),
 		UpdateTime:       1571110484038,
 		WorkingType:      WorkingTypeContractPrice,
 		ActivatePrice:    "10000",
@@ -543,168 +562,7 @@ func (s *orderServiceTestSuite) TestCancelAllOpenOrders() {
 		s.assertRequestEqual(e, r)
 	})
 
-	err := s.client.NewCancelAllOpenOrdersService().Symbol(symbol).
+	err := s.client.NewCancelAllOpenOrdersService().Which("um").Symbol(symbol).
 		Do(newContext())
 	s.r().NoError(err)
 }
-
-func (s *orderServiceTestSuite) TestListLiquidationOrders() {
-	data := []byte(`[
-		{
-			  "symbol": "BTCUSDT",
-			  "price": "7918.33",
-			  "origQty": "0.014",
-			  "executedQty": "0.014",
-			  "avragePrice": "7918.33",
-			  "status": "FILLED",
-			  "timeInForce": "IOC",
-			  "type": "LIMIT",
-			  "side": "SELL",
-			  "time": 1568014460893
-		}
-	]`)
-	s.mockDo(data, nil)
-	defer s.assertDo()
-
-	symbol := "BTCUSDT"
-	startTime := int64(1568014460893)
-	endTime := int64(1568014460894)
-	limit := 1
-	s.assertReq(func(r *request) {
-		e := newRequest().setParams(params{
-			"symbol":    symbol,
-			"startTime": startTime,
-			"endTime":   endTime,
-			"limit":     limit,
-		})
-		s.assertRequestEqual(e, r)
-	})
-
-	res, err := s.client.NewListLiquidationOrdersService().Symbol(symbol).
-		StartTime(startTime).EndTime(endTime).Limit(limit).Do(newContext())
-	r := s.r()
-	r.NoError(err)
-	e := []*LiquidationOrder{
-		{
-			Symbol:           symbol,
-			Price:            "7918.33",
-			OrigQuantity:     "0.014",
-			ExecutedQuantity: "0.014",
-			AveragePrice:     "7918.33",
-			Status:           OrderStatusTypeFilled,
-			TimeInForce:      TimeInForceTypeIOC,
-			Type:             OrderTypeLimit,
-			Side:             SideTypeSell,
-		},
-	}
-	s.r().Len(res, len(e))
-	for i := range res {
-		s.assertLiquidationEqual(e[i], res[i])
-	}
-}
-
-func (s *orderServiceTestSuite) assertLiquidationEqual(e, a *LiquidationOrder) {
-	r := s.r()
-	r.Equal(e.Symbol, a.Symbol, "Symbol")
-	r.Equal(e.Price, a.Price, "Price")
-	r.Equal(e.OrigQuantity, a.OrigQuantity, "OrigQuantity")
-	r.Equal(e.ExecutedQuantity, a.ExecutedQuantity, "ExecutedQuantity")
-	r.Equal(e.AveragePrice, a.AveragePrice, "AveragePrice")
-	r.Equal(e.Status, a.Status, "Status")
-	r.Equal(e.TimeInForce, a.TimeInForce, "TimeInForce")
-	r.Equal(e.Type, a.Type, "Type")
-	r.Equal(e.Side, a.Side, "Side")
-}
-
-func (s *orderServiceTestSuite) TestCreateBatchOrders() {
-	data := []byte(`[
-		{
-			"code": -2014, 
-			"msg": "API-key format invalid."
-		},
-		{
-			"clientOrderId": "testOrder",
-			"cumQty": "0",
-			"cumQuote": "0",
-			"executedQty": "0",
-			"orderId": 22542179,
-			"avgPrice": "0.00000",
-			"origQty": "10",
-			"price": "100",
-			"reduceOnly": false,
-			"side": "BUY",
-			"positionSide": "SHORT",
-			"status": "NEW",
-			"stopPrice": "9300",
-			"symbol": "BTCUSDT",
-			"timeInForce": "GTC",
-			"type": "TRAILING_STOP_MARKET",
-			"origType": "TRAILING_STOP_MARKET",
-			"activatePrice": "9020",
-			"priceRate": "0.3",
-			"updateTime": 1566818724722,
-			"workingType": "CONTRACT_PRICE",
-			"priceProtect": false,            
-			"priceMatch": "NONE",             
-			"selfTradePreventionMode": "NONE", 
-			"goodTillDate": 0
-		},
-		{
-			"code": -2022, 
-			"msg": "ReduceOnly Order is rejected."
-		}
-	]`)
-	s.mockDo(data, nil)
-	defer s.assertDo()
-
-	res, err := s.client.NewCreateBatchOrdersService().OrderList([]*CreateOrderService{{}, {}, {}}).Do(context.Background())
-	r := s.r()
-	r.NoError(err)
-
-	e := &CreateBatchOrdersResponse{
-		N: 3,
-		Orders: []*Order{
-			{
-				Symbol:                  "BTCUSDT",
-				OrderID:                 22542179,
-				ClientOrderID:           "testOrder",
-				Price:                   "100",
-				ReduceOnly:              false,
-				OrigQuantity:            "10",
-				ExecutedQuantity:        "0",
-				CumQuantity:             "0",
-				CumQuote:                "0",
-				Status:                  "NEW",
-				TimeInForce:             "GTC",
-				Type:                    "TRAILING_STOP_MARKET",
-				Side:                    "BUY",
-				StopPrice:               "9300",
-				Time:                    0,
-				UpdateTime:              1566818724722,
-				WorkingType:             "CONTRACT_PRICE",
-				ActivatePrice:           "9020",
-				PriceRate:               "0.3",
-				AvgPrice:                "0.00000",
-				OrigType:                "TRAILING_STOP_MARKET",
-				PositionSide:            "SHORT",
-				PriceProtect:            false,
-				ClosePosition:           false,
-				PriceMatch:              "NONE",
-				SelfTradePreventionMode: "NONE",
-				GoodTillDate:            0,
-			},
-		},
-		Errors: []error{
-			&common.APIError{
-				Code:    -2014,
-				Message: "API-key format invalid.",
-			},
-			nil,
-			&common.APIError{
-				Code:    -2022,
-				Message: "ReduceOnly Order is rejected.",
-			},
-		},
-	}
-	r.EqualValues(e, res)
-}
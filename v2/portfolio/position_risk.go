@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -44,7 +43,7 @@ func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption)
 		return []*PositionRisk{}, err
 	}
 	res = make([]*PositionRisk, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*PositionRisk{}, err
 	}
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -52,7 +51,7 @@ func (s *ChangeLeverageService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(SymbolLeverage)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +138,7 @@ func (s *GetPositionModeService) Do(ctx context.Context, opts ...RequestOption)
 		return nil, err
 	}
 	res = &PositionMode{}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
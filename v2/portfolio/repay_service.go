@@ -21,7 +21,7 @@ func (s *RepayService) Do(ctx context.Context, opts ...RequestOption) (ret bool,
 	if err != nil {
 		return
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return
 	}
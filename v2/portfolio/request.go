@@ -1,10 +1,16 @@
 package portfolio
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/bitly/go-simplejson"
 )
 
 type secType int
@@ -28,6 +34,9 @@ type request struct {
 	header     http.Header
 	body       io.Reader
 	fullURL    string
+	// set by the response, for the instrumentation
+	statusCode int
+	rateLimits []common.RateLimit
 }
 
 // setParam set param with key/value to query string
@@ -74,6 +83,186 @@ func (r *request) validate() (err error) {
 	return nil
 }
 
+// orderCount return the number of orders placed by the request, counted by
+// the ORDERS rate limits
+func (r *request) orderCount() int {
+	if r.method != http.MethodPost {
+		return 0
+	}
+	switch r.endpoint {
+	case "/papi/v1/um/order", "/papi/v1/cm/order", "/papi/v1/margin/order",
+		"/papi/v1/um/conditional/order", "/papi/v1/cm/conditional/order":
+		return 1
+	case "/papi/v1/margin/order/oco":
+		return 2
+	}
+	return 0
+}
+
+// weight return the request weight of the request, counted by the
+// REQUEST_WEIGHT rate limits. The endpoints which are not known weigh 1.
+func (r *request) weight() int {
+	hasSymbol := r.param("symbol") != ""
+	switch r.method + " " + r.endpoint {
+	case "GET /papi/v1/account", "GET /papi/v1/balance":
+		return 20
+	case "GET /papi/v1/um/openOrders", "GET /papi/v1/cm/openOrders":
+		if hasSymbol {
+			return 1
+		}
+		return 40
+	case "GET /papi/v1/um/allOrders", "GET /papi/v1/um/userTrades", "GET /papi/v1/um/positionRisk",
+		"GET /papi/v1/um/account":
+		return 5
+	case "GET /papi/v1/cm/allOrders", "GET /papi/v1/cm/userTrades":
+		if hasSymbol {
+			return 20
+		}
+		return 40
+	case "GET /papi/v1/um/income", "GET /papi/v1/cm/income":
+		return 30
+	}
+	return 1
+}
+
+// intParam return the value of an integer param, def if it is not set
+func (r *request) intParam(key string, def int) int {
+	if v, err := strconv.Atoi(r.param(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// paramLen return the number of items of a JSON array param, 0 if it is not
+// set
+func (r *request) paramLen(key string) int {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(r.param(key)), &items); err != nil {
+		return 0
+	}
+	return len(items)
+}
+
+// idempotent return true if the request can be sent again safely: the GET
+// requests and the keepalive of a listen key. The other PUT and DELETE
+// requests, e.g. modifying or canceling an order, are not retried.
+func (r *request) idempotent() bool {
+	if r.method == http.MethodGet {
+		return true
+	}
+	return r.method == http.MethodPut && isListenKeyEndpoint(r.endpoint)
+}
+
+// isListenKeyEndpoint return true for the endpoints of the user data stream
+func isListenKeyEndpoint(endpoint string) bool {
+	return strings.HasSuffix(endpoint, "/listenKey") || strings.Contains(endpoint, "/userDataStream")
+}
+
+// param return the value of a query or form param
+func (r *request) param(key string) string {
+	if v := r.query.Get(key); v != "" {
+		return v
+	}
+	return r.form.Get(key)
+}
+
+// clone return a copy of the request which can be sent again
+func (r *request) clone() *request {
+	c := *r
+	c.query = cloneValues(r.query)
+	c.form = cloneValues(r.form)
+	c.header = r.header.Clone()
+	return &c
+}
+
+// decode unmarshal the response data of the request into v, the error
+// carries the request
+func (r *request) decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return r.decodeError(err)
+	}
+	return nil
+}
+
+// decodeJSON parse the response data of the request, the error carries the
+// request
+func (r *request) decodeJSON(data []byte) (*simplejson.Json, error) {
+	j, err := newJSON(data)
+	if err != nil {
+		return nil, r.decodeError(err)
+	}
+	return j, nil
+}
+
+// decodeError wrap the error of decoding the response of the request
+func (r *request) decodeError(err error) error {
+	return &common.DecodeError{Method: r.method, Endpoint: r.endpoint, StatusCode: r.statusCode, Err: err}
+}
+
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+	c := make(url.Values, len(values))
+	for k, v := range values {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// apiRequest return the request seen by the interceptors
+func (r *request) apiRequest() *common.APIRequest {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	if r.form == nil {
+		r.form = url.Values{}
+	}
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	return &common.APIRequest{
+		Method:     r.method,
+		Endpoint:   r.endpoint,
+		SecType:    r.secType.apiSecType(),
+		Query:      r.query,
+		Form:       r.form,
+		Header:     r.header,
+		RecvWindow: r.recvWindow,
+	}
+}
+
+// setAPIRequest apply the changes of the interceptors to the request
+func (r *request) setAPIRequest(req *common.APIRequest) {
+	r.method = req.Method
+	r.endpoint = req.Endpoint
+	r.secType = newSecType(req.SecType)
+	r.query = req.Query
+	r.form = req.Form
+	r.header = req.Header
+	r.recvWindow = req.RecvWindow
+}
+
+func (t secType) apiSecType() common.SecType {
+	switch t {
+	case secTypeAPIKey:
+		return common.SecTypeAPIKey
+	case secTypeSigned:
+		return common.SecTypeSigned
+	}
+	return common.SecTypeNone
+}
+
+func newSecType(t common.SecType) secType {
+	switch t {
+	case common.SecTypeAPIKey:
+		return secTypeAPIKey
+	case common.SecTypeSigned:
+		return secTypeSigned
+	}
+	return secTypeNone
+}
+
 // RequestOption define option type for request
 type RequestOption func(*request)
 
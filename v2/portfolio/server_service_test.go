@@ -31,24 +31,7 @@ func (s *serverServiceTestSuite) TestPing() {
 	s.r().NoError(err)
 }
 
-func (s *serverServiceTestSuite) TestServerTime() {
-	data := []byte(`{
-        "serverTime": 1499827319559
-    }`)
-	s.mockDo(data, nil)
-	defer s.assertDo()
-
-	s.assertReq(func(r *request) {
-		e := newRequest()
-		s.assertRequestEqual(e, r)
-	})
-
-	serverTime, err := s.client.NewServerTimeService().Do(newContext())
-	s.r().NoError(err)
-	s.r().EqualValues(1499827319559, serverTime)
-}
-
-func (s *serverServiceTestSuite) TestServerTimeError() {
+func (s *serverServiceTestSuite) TestPingError() {
 	s.mockDo([]byte("{}"), fmt.Errorf("dummy error"), http.StatusInternalServerError)
 	defer s.assertDo()
 
@@ -56,12 +39,12 @@ func (s *serverServiceTestSuite) TestServerTimeError() {
 		e := newRequest()
 		s.assertRequestEqual(e, r)
 	})
-	_, err := s.client.NewServerTimeService().Do(newContext())
+	err := s.client.NewPingService().Do(newContext())
 	s.r().Error(err)
 	s.r().Contains(err.Error(), "dummy error")
 }
 
-func (s *serverServiceTestSuite) TestServerTimeBadRequest() {
+func (s *serverServiceTestSuite) TestPingBadRequest() {
 	s.mockDo([]byte(`{
         "code": -1121,
         "msg": "Invalid symbol."
@@ -72,7 +55,7 @@ func (s *serverServiceTestSuite) TestServerTimeBadRequest() {
 		e := newRequest()
 		s.assertRequestEqual(e, r)
 	})
-	_, err := s.client.NewServerTimeService().Do(newContext())
+	err := s.client.NewPingService().Do(newContext())
 	s.r().Error(err)
 	s.r().True(common.IsAPIError(err))
 }
@@ -82,26 +65,10 @@ func (s *serverServiceTestSuite) TestInvalidResponseBody() {
 	defer s.assertDo()
 
 	s.assertReq(func(r *request) {
-		e := newRequest()
+		e := newSignedRequest()
 		s.assertRequestEqual(e, r)
 	})
-	_, err := s.client.NewServerTimeService().Do(newContext())
+	_, err := s.client.NewGetBalanceService().Do(newContext())
 	s.r().Error(err)
 	s.r().False(common.IsAPIError(err))
 }
-
-func (s *serverServiceTestSuite) TestSetServerTime() {
-	data := []byte(`1399827319559`)
-	s.mockDo(data, nil)
-	defer s.assertDo()
-
-	s.assertReq(func(r *request) {
-		e := newRequest()
-		s.assertRequestEqual(e, r)
-	})
-
-	timeOffset, err := s.client.NewSetServerTimeService().Do(newContext())
-	s.r().NoError(err)
-	s.r().NotZero(s.client.TimeOffset)
-	s.r().EqualValues(timeOffset, s.client.TimeOffset)
-}
@@ -2,7 +2,6 @@ package portfolio
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -63,7 +62,7 @@ func (s *HistoricalTradesService) Do(ctx context.Context, opts ...RequestOption)
 		return
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -160,7 +159,7 @@ func (s *AggTradesService) Do(ctx context.Context, opts ...RequestOption) (res [
 		return []*AggTrade{}, err
 	}
 	res = make([]*AggTrade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*AggTrade{}, err
 	}
@@ -212,7 +211,7 @@ func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (re
 		return []*Trade{}, err
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Trade{}, err
 	}
@@ -294,7 +293,7 @@ func (s *ListAccountTradeService) Do(ctx context.Context, opts ...RequestOption)
 		return []*AccountTrade{}, err
 	}
 	res = make([]*AccountTrade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*AccountTrade{}, err
 	}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -26,6 +27,22 @@ func newWsConfig(endpoint string) *WsConfig {
 	}
 }
 
+// WebsocketInstrumentation receives the connections of the websocket
+// streams, nil to ignore them
+var WebsocketInstrumentation common.Instrumentation
+
+// WebsocketLogger logs the errors of the websocket streams served without
+// error handler, with the secrets redacted. They are printed to stderr if it
+// is nil.
+var WebsocketLogger common.Logger
+
+func websocketLogger() common.Logger {
+	if WebsocketLogger != nil {
+		return common.NewRedactingLogger(WebsocketLogger)
+	}
+	return common.NewRedactingLogger(common.DefaultLogger(common.LogLevelInfo))
+}
+
 var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	Dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
@@ -33,7 +50,14 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		EnableCompression: false,
 	}
 
+	if errHandler == nil {
+		errHandler = func(err error) {
+			websocketLogger().Error("websocket error", "endpoint", cfg.Endpoint, "error", err)
+		}
+	}
+	instrumentation := common.InstrumentationOrNoop(WebsocketInstrumentation)
 	c, _, err := Dialer.Dial(cfg.Endpoint, nil)
+	instrumentation.WsConnect(cfg.Endpoint, err)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -64,7 +88,10 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 			_, message, err := c.ReadMessage()
 			if err != nil {
 				if !silent {
+					instrumentation.WsDisconnect(cfg.Endpoint, err)
 					errHandler(err)
+				} else {
+					instrumentation.WsDisconnect(cfg.Endpoint, nil)
 				}
 				return
 			}
@@ -79,39 +79,25 @@ func (s *websocketServiceTestSuite) TestWsUserDataServeStreamExpired() {
 	s.testWsUserDataServe(data, expectedEvent)
 }
 
-func (s *websocketServiceTestSuite) TestWsUserDataServeMarginCall() {
+func (s *websocketServiceTestSuite) TestWsUserDataServeRiskLevelChange() {
 	data := []byte(`{
-		"e":"MARGIN_CALL",
+		"e":"riskLevelChange",
 		"E":1587727187525,
-		"cw":"3.16812045",
-		"p":[
-			{
-				"s":"ETHUSDT",
-				"ps":"LONG",
-				"pa":"1.327",
-				"mt":"CROSSED",
-				"iw":"0",
-				"mp":"187.17127",
-				"up":"-1.166074",
-				"mm":"1.614445"
-			}
-		]
+		"u":"1.99999999",
+		"s":"MARGIN_CALL",
+		"eq":"30.23416728",
+		"ae":"30.23416728",
+		"m":"15.11708371"
 	}`)
 	expectedEvent := &WsUserDataEvent{
-		Event:              "MARGIN_CALL",
-		Time:               1587727187525,
-		CrossWalletBalance: "3.16812045",
-		MarginCallPositions: []WsPosition{
-			{
-				Symbol:                    "ETHUSDT",
-				Side:                      "LONG",
-				Amount:                    "1.327",
-				MarginType:                "CROSSED",
-				IsolatedWallet:            "0",
-				MarkPrice:                 "187.17127",
-				UnrealizedPnL:             "-1.166074",
-				MaintenanceMarginRequired: "1.614445",
-			},
+		Event: "riskLevelChange",
+		Time:  1587727187525,
+		RiskLevelChange: WsRiskLevelChange{
+			UniMMR:       "1.99999999",
+			MarginEvent:  "MARGIN_CALL",
+			MarginUsd:    "30.23416728",
+			ActualEquity: "30.23416728",
+			MaintMargin:  "15.11708371",
 		},
 	}
 	s.testWsUserDataServe(data, expectedEvent)
@@ -330,11 +316,7 @@ func (s *websocketServiceTestSuite) assertUserDataEvent(e, a *WsUserDataEvent) {
 	r := s.r()
 	r.Equal(e.Event, a.Event, "Event")
 	r.Equal(e.Time, a.Time, "Time")
-	r.Equal(e.CrossWalletBalance, a.CrossWalletBalance, "CrossWalletBalance")
-	for i, e := range e.MarginCallPositions {
-		a := a.MarginCallPositions[i]
-		s.assertPosition(e, a)
-	}
+	r.Equal(e.RiskLevelChange, a.RiskLevelChange, "RiskLevelChange")
 	r.Equal(e.TransactionTime, a.TransactionTime, "TransactionTime")
 	s.assertAccountUpdate(e.AccountUpdate, a.AccountUpdate)
 	s.assertOrderTradeUpdate(e.OrderTradeUpdate, a.OrderTradeUpdate)
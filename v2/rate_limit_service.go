@@ -3,6 +3,8 @@ package binance
 import (
 	"context"
 	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
 )
 
 // RateLimitService get rate limits
@@ -22,7 +24,7 @@ func (s *RateLimitService) Do(ctx context.Context, opts ...RequestOption) (res [
 	if err != nil {
 		return res, err
 	}
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return res, err
 	}
@@ -36,3 +38,14 @@ type RateLimitFull struct {
 	Limit         int               `json:"limit"`
 	Count         int               `json:"count"`
 }
+
+// RateLimit return the limit with its current count, to update a
+// common.RateLimitGovernor
+func (l *RateLimitFull) RateLimit() common.RateLimit {
+	return common.RateLimit{
+		Type:     string(l.RateLimitType),
+		Interval: common.RateLimitInterval(string(l.Interval), l.IntervalNum),
+		Limit:    l.Limit,
+		Count:    l.Count,
+	}
+}
@@ -51,7 +51,7 @@ func (s *SpotRebateHistoryService) Do(ctx context.Context, opts ...RequestOption
 		return nil, err
 	}
 	res := SpotRebateHistory{}
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return &res, nil
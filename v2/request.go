@@ -6,6 +6,12 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/bitly/go-simplejson"
+	jsoniter "github.com/json-iterator/go"
 )
 
 type secType int
@@ -29,10 +35,17 @@ type request struct {
 	header     http.Header
 	body       io.Reader
 	fullURL    string
+	// set by the response, for the instrumentation
+	statusCode int
+	rateLimits []common.RateLimit
+	// set when the request was sent over the WS API
+	ws bool
 	// for WS API
 	wsMethod string
 	wsParams params
-	ch       chan interface{}
+	// the connection to send it over, the one of the client if nil
+	wsConn *WsConnection
+	ch     chan interface{}
 }
 
 // addParam add param with key/value to query string
@@ -96,6 +109,243 @@ func (r *request) validate() (err error) {
 	return nil
 }
 
+// orderCount return the number of orders placed by the request, counted by
+// the ORDERS rate limits of the spot API
+func (r *request) orderCount() int {
+	if r.method != http.MethodPost {
+		return 0
+	}
+	// the orders of an order list are counted one by one
+	switch r.endpoint {
+	case "/api/v3/order", "/api/v3/order/cancelReplace", "/api/v3/sor/order":
+		return 1
+	case "/api/v3/order/oco", "/api/v3/orderList/oco", "/api/v3/orderList/oto":
+		return 2
+	case "/api/v3/orderList/otoco":
+		return 3
+	}
+	return 0
+}
+
+// weight return the request weight of the request, counted by the
+// REQUEST_WEIGHT rate limits. The endpoints which are not known weigh 1.
+func (r *request) weight() int {
+	hasSymbol := r.param("symbol") != ""
+	switch r.method + " " + r.endpoint {
+	case "GET /api/v3/depth":
+		limit := r.intParam("limit", 100)
+		switch {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		}
+		return 250
+	case "GET /api/v3/trades", "GET /api/v3/historicalTrades":
+		return 25
+	case "GET /api/v3/aggTrades", "GET /api/v3/order", "GET /api/v3/orderList":
+		return 4
+	case "GET /api/v3/klines", "GET /api/v3/uiKlines", "GET /api/v3/avgPrice":
+		return 2
+	case "GET /api/v3/ticker/24hr":
+		n := r.paramLen("symbols")
+		switch {
+		case hasSymbol || n > 0 && n <= 20:
+			return 2
+		case n > 0 && n <= 100:
+			return 40
+		}
+		return 80
+	case "GET /api/v3/ticker/price", "GET /api/v3/ticker/bookTicker":
+		if hasSymbol {
+			return 2
+		}
+		return 4
+	case "GET /api/v3/ticker":
+		n := r.paramLen("symbols")
+		if hasSymbol {
+			n = 1
+		}
+		if n > 50 {
+			return 200
+		}
+		return 4 * n
+	case "GET /api/v3/openOrders":
+		if hasSymbol {
+			return 6
+		}
+		return 80
+	case "GET /api/v3/openOrderList":
+		return 6
+	case "GET /api/v3/exchangeInfo", "GET /api/v3/allOrders", "GET /api/v3/allOrderList",
+		"GET /api/v3/account", "GET /api/v3/account/commission":
+		return 20
+	case "GET /api/v3/myTrades":
+		if r.param("orderId") != "" {
+			return 5
+		}
+		return 20
+	case "GET /api/v3/rateLimit/order":
+		return 40
+	case "POST /api/v3/userDataStream", "PUT /api/v3/userDataStream", "DELETE /api/v3/userDataStream":
+		return 2
+	}
+	return 1
+}
+
+// intParam return the value of an integer param, def if it is not set
+func (r *request) intParam(key string, def int) int {
+	if v, err := strconv.Atoi(r.param(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// paramLen return the number of items of a JSON array param, 0 if it is not
+// set
+func (r *request) paramLen(key string) int {
+	var items []jsoniter.RawMessage
+	if err := json.Unmarshal([]byte(r.param(key)), &items); err != nil {
+		return 0
+	}
+	return len(items)
+}
+
+// idempotent return true if the request can be sent again safely: the GET
+// requests and the keepalive of a listen key. The other PUT and DELETE
+// requests, e.g. modifying or canceling an order, are not retried.
+func (r *request) idempotent() bool {
+	if r.method == http.MethodGet {
+		return true
+	}
+	return r.method == http.MethodPut && isListenKeyEndpoint(r.endpoint)
+}
+
+// isListenKeyEndpoint return true for the endpoints of the user data stream
+func isListenKeyEndpoint(endpoint string) bool {
+	return strings.HasSuffix(endpoint, "/listenKey") || strings.Contains(endpoint, "/userDataStream")
+}
+
+// param return the value of a query or form param
+func (r *request) param(key string) string {
+	if v := r.query.Get(key); v != "" {
+		return v
+	}
+	return r.form.Get(key)
+}
+
+// clone return a copy of the request which can be sent again
+func (r *request) clone() *request {
+	c := *r
+	c.query = cloneValues(r.query)
+	c.form = cloneValues(r.form)
+	c.header = r.header.Clone()
+	if r.wsParams != nil {
+		c.wsParams = make(params, len(r.wsParams))
+		for k, v := range r.wsParams {
+			c.wsParams[k] = v
+		}
+	}
+	return &c
+}
+
+// decode unmarshal the response data of the request into v, the error
+// carries the request
+func (r *request) decode(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return r.decodeError(err)
+	}
+	return nil
+}
+
+// decodeJSON parse the response data of the request, the error carries the
+// request
+func (r *request) decodeJSON(data []byte) (*simplejson.Json, error) {
+	j, err := newJSON(data)
+	if err != nil {
+		return nil, r.decodeError(err)
+	}
+	return j, nil
+}
+
+// decodeError wrap the error of decoding the response of the request
+func (r *request) decodeError(err error) error {
+	method := r.method
+	if r.ws {
+		method = r.wsMethod
+	}
+	return &common.DecodeError{Method: method, Endpoint: r.endpoint, StatusCode: r.statusCode, Err: err}
+}
+
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+	c := make(url.Values, len(values))
+	for k, v := range values {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// apiRequest return the request seen by the interceptors
+func (r *request) apiRequest(ws bool) *common.APIRequest {
+	if r.query == nil {
+		r.query = url.Values{}
+	}
+	if r.form == nil {
+		r.form = url.Values{}
+	}
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	return &common.APIRequest{
+		Method:     r.method,
+		Endpoint:   r.endpoint,
+		WsMethod:   r.wsMethod,
+		WebSocket:  ws,
+		SecType:    r.secType.apiSecType(),
+		Query:      r.query,
+		Form:       r.form,
+		Header:     r.header,
+		RecvWindow: r.recvWindow,
+	}
+}
+
+// setAPIRequest apply the changes of the interceptors to the request
+func (r *request) setAPIRequest(req *common.APIRequest) {
+	r.method = req.Method
+	r.endpoint = req.Endpoint
+	r.wsMethod = req.WsMethod
+	r.secType = newSecType(req.SecType)
+	r.query = req.Query
+	r.form = req.Form
+	r.header = req.Header
+	r.recvWindow = req.RecvWindow
+}
+
+func (t secType) apiSecType() common.SecType {
+	switch t {
+	case secTypeAPIKey:
+		return common.SecTypeAPIKey
+	case secTypeSigned:
+		return common.SecTypeSigned
+	}
+	return common.SecTypeNone
+}
+
+func newSecType(t common.SecType) secType {
+	switch t {
+	case common.SecTypeAPIKey:
+		return secTypeAPIKey
+	case common.SecTypeSigned:
+		return secTypeSigned
+	}
+	return secTypeNone
+}
+
 // RequestOption define option type for request
 type RequestOption func(*request)
 
@@ -0,0 +1,96 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      *request
+		weight int
+	}{
+		{
+			name:   "depth default limit",
+			r:      &request{method: http.MethodGet, endpoint: "/api/v3/depth"},
+			weight: 5,
+		},
+		{
+			name:   "depth limit 5000",
+			r:      (&request{method: http.MethodGet, endpoint: "/api/v3/depth"}).setParam("limit", 5000),
+			weight: 250,
+		},
+		{
+			name:   "exchange info",
+			r:      &request{method: http.MethodGet, endpoint: "/api/v3/exchangeInfo"},
+			weight: 20,
+		},
+		{
+			name:   "all orders",
+			r:      (&request{method: http.MethodGet, endpoint: "/api/v3/allOrders"}).setParam("symbol", "BTCUSDT"),
+			weight: 20,
+		},
+		{
+			name:   "ticker of symbols",
+			r:      (&request{method: http.MethodGet, endpoint: "/api/v3/ticker"}).setParam("symbols", `["BTCUSDT","ETHUSDT"]`),
+			weight: 8,
+		},
+		{
+			name:   "unknown endpoint",
+			r:      &request{method: http.MethodGet, endpoint: "/api/v3/time"},
+			weight: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.weight, tt.r.weight())
+		})
+	}
+}
+
+func TestRequestOrderCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      *request
+		orders int
+	}{
+		{
+			name:   "order",
+			r:      &request{method: http.MethodPost, endpoint: "/api/v3/order"},
+			orders: 1,
+		},
+		{
+			name:   "test order",
+			r:      &request{method: http.MethodPost, endpoint: "/api/v3/order/test"},
+			orders: 0,
+		},
+		{
+			name:   "oco",
+			r:      &request{method: http.MethodPost, endpoint: "/api/v3/orderList/oco"},
+			orders: 2,
+		},
+		{
+			name:   "otoco",
+			r:      &request{method: http.MethodPost, endpoint: "/api/v3/orderList/otoco"},
+			orders: 3,
+		},
+		{
+			name:   "cancel order",
+			r:      &request{method: http.MethodDelete, endpoint: "/api/v3/order"},
+			orders: 0,
+		},
+		{
+			name:   "algo order",
+			r:      &request{method: http.MethodPost, endpoint: "/sapi/v1/algo/spot/newOrderTwap"},
+			orders: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.orders, tt.r.orderCount())
+		})
+	}
+}
@@ -64,7 +64,7 @@ func (s *ListSavingsFlexibleProductsService) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	var res []*SavingsFlexibleProduct
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +124,7 @@ func (s *PurchaseSavingsFlexibleProductService) Do(ctx context.Context, opts ...
 	}
 
 	var res *PurchaseSavingsFlexibleProductResponse
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return 0, err
 	}
 
@@ -269,7 +269,7 @@ func (s *ListSavingsFixedAndActivityProductsService) Do(ctx context.Context, opt
 		return nil, err
 	}
 	var res []*SavingsFixedProduct
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -324,7 +324,7 @@ func (s *SavingFlexibleProductPositionsService) Do(ctx context.Context, opts ...
 		return nil, err
 	}
 	var res []*SavingFlexibleProductPosition
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -397,7 +397,7 @@ func (s *SavingFixedProjectPositionsService) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	var res []*SavingFixedProjectPosition
-	if err = json.Unmarshal(data, &res); err != nil {
+	if err = r.decode(data, &res); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -0,0 +1,118 @@
+package binance
+
+import (
+	"context"
+	"errors"
+)
+
+const sessionLogonMethod = "session.logon"
+
+// ErrWsAPINotConnected is returned by services that require the WebSocket API connection
+var ErrWsAPINotConnected = errors.New("websocket api is not connected")
+
+// SessionLogonService authenticate the WebSocket API connection with the API key.
+// Signed requests sent afterwards over the connection don't need apiKey and
+// signature anymore, and the session is logged on again after reconnecting.
+// Binance only accepts Ed25519 keys for session.logon, see common.NewEd25519Signer.
+type SessionLogonService struct {
+	c *Client
+}
+
+// Do send request
+func (s *SessionLogonService) Do(ctx context.Context, opts ...RequestOption) (res *SessionStatus, err error) {
+	conn := s.c.wsConn()
+	if conn == nil {
+		return nil, ErrWsAPINotConnected
+	}
+	res, err = s.c.logonSession(ctx, conn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.c.setWsSession(true)
+	return res, nil
+}
+
+// SessionStatusService query the authentication status of the WebSocket API connection
+type SessionStatusService struct {
+	c *Client
+}
+
+// Do send request
+func (s *SessionStatusService) Do(ctx context.Context, opts ...RequestOption) (res *SessionStatus, err error) {
+	conn := s.c.wsConn()
+	if conn == nil {
+		return nil, ErrWsAPINotConnected
+	}
+	r := &request{
+		wsMethod: "session.status",
+	}
+	return s.c.callSessionAPI(ctx, conn, r, opts...)
+}
+
+// SessionLogoutService forget the API key authenticated by session.logon
+type SessionLogoutService struct {
+	c *Client
+}
+
+// Do send request
+func (s *SessionLogoutService) Do(ctx context.Context, opts ...RequestOption) (res *SessionStatus, err error) {
+	conn := s.c.wsConn()
+	if conn == nil {
+		return nil, ErrWsAPINotConnected
+	}
+	r := &request{
+		wsMethod: "session.logout",
+	}
+	res, err = s.c.callSessionAPI(ctx, conn, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	conn.setLoggedOn(false)
+	s.c.setWsSession(false)
+	return res, nil
+}
+
+// logonSession authenticate the connection, it is also used to restore the
+// session after reconnecting
+func (c *Client) logonSession(ctx context.Context, conn *WsConnection, opts ...RequestOption) (*SessionStatus, error) {
+	r := &request{
+		secType:  secTypeSigned,
+		wsMethod: sessionLogonMethod,
+	}
+	res, err := c.callSessionAPI(ctx, conn, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	conn.setLoggedOn(true)
+	return res, nil
+}
+
+func (c *Client) setWsSession(wsSession bool) {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	c.wsSession = wsSession
+}
+
+func (c *Client) callSessionAPI(ctx context.Context, conn *WsConnection, r *request, opts ...RequestOption) (*SessionStatus, error) {
+	r.wsConn = conn
+	data, _, err := c.invoke(ctx, r, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SessionStatus)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SessionStatus define the authentication status of the WebSocket API connection
+type SessionStatus struct {
+	APIKey           string `json:"apiKey"`
+	AuthorizedSince  int64  `json:"authorizedSince"`
+	ConnectedSince   int64  `json:"connectedSince"`
+	ReturnRateLimits bool   `json:"returnRateLimits"`
+	ServerTime       int64  `json:"serverTime"`
+	UserDataStream   bool   `json:"userDataStream"`
+}
@@ -0,0 +1,129 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type sessionServiceTestSuite struct {
+	suite.Suite
+	server *wsAPIServer
+	client *Client
+}
+
+func TestSessionService(t *testing.T) {
+	suite.Run(t, new(sessionServiceTestSuite))
+}
+
+func (s *sessionServiceTestSuite) SetupTest() {
+	s.server = newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		switch req.Method {
+		case "session.logon", "session.status":
+			return wsAPIResult(`{
+				"apiKey": "dummyAPIKey",
+				"authorizedSince": 1649729878532,
+				"connectedSince": 1649729873021,
+				"returnRateLimits": false,
+				"serverTime": 1649729878630,
+				"userDataStream": false
+			}`)
+		case "session.logout":
+			return wsAPIResult(`{
+				"apiKey": null,
+				"authorizedSince": null,
+				"connectedSince": 1649729873021,
+				"returnRateLimits": false,
+				"serverTime": 1649730611671,
+				"userDataStream": false
+			}`)
+		}
+		return wsAPIResult(`{}`)
+	})
+	s.client = newWsAPIClient(s.server)
+	s.Require().True(s.client.WsConnected())
+}
+
+func (s *sessionServiceTestSuite) TearDownTest() {
+	s.client.Close()
+	s.server.Close()
+}
+
+func (s *sessionServiceTestSuite) TestLogonAndLogout() {
+	r := s.Require()
+
+	res, err := s.client.NewSessionLogonService().Do(newContext())
+	r.NoError(err)
+	r.Equal(&SessionStatus{
+		APIKey:          "dummyAPIKey",
+		AuthorizedSince: 1649729878532,
+		ConnectedSince:  1649729873021,
+		ServerTime:      1649729878630,
+	}, res)
+	req := s.server.lastRequest()
+	r.Equal("session.logon", req.Method)
+	r.Equal("dummyAPIKey", req.Params[apiKey])
+	r.NotEmpty(req.Params[signatureKey])
+	r.NotEmpty(req.Params[timestampKey])
+	r.True(s.client.WsConn.LoggedOn())
+
+	// signed requests are not signed again on an authenticated session
+	_, err = s.client.NewGetAccountService().Do(newContext())
+	r.NoError(err)
+	req = s.server.lastRequest()
+	r.Equal("account.status", req.Method)
+	r.NotContains(req.Params, apiKey)
+	r.NotContains(req.Params, signatureKey)
+	r.NotEmpty(req.Params[timestampKey])
+
+	res, err = s.client.NewSessionStatusService().Do(newContext())
+	r.NoError(err)
+	r.Equal("dummyAPIKey", res.APIKey)
+	r.Equal("session.status", s.server.lastRequest().Method)
+
+	res, err = s.client.NewSessionLogoutService().Do(newContext())
+	r.NoError(err)
+	r.Empty(res.APIKey)
+	r.False(s.client.WsConn.LoggedOn())
+
+	_, err = s.client.NewGetAccountService().Do(newContext())
+	r.NoError(err)
+	req = s.server.lastRequest()
+	r.Equal("dummyAPIKey", req.Params[apiKey])
+	r.NotEmpty(req.Params[signatureKey])
+}
+
+func (s *sessionServiceTestSuite) TestLogonError() {
+	s.server.setHandle(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIError(http.StatusUnauthorized, -1022, "Signature for this request is not valid.")
+	})
+	_, err := s.client.NewSessionLogonService().Do(newContext())
+	s.Require().Error(err)
+	s.Require().False(s.client.WsConn.LoggedOn())
+}
+
+func (s *sessionServiceTestSuite) TestNotConnected() {
+	s.client.Close()
+	_, err := s.client.NewSessionLogonService().Do(newContext())
+	s.Require().Equal(ErrWsAPINotConnected, err)
+}
+
+func (s *sessionServiceTestSuite) TestSessionIntercepted() {
+	var methods []string
+	s.client.Interceptors = []common.Interceptor{func(ctx context.Context, req *common.APIRequest, next common.Invoker) (*common.APIResponse, error) {
+		s.Require().True(req.WebSocket)
+		methods = append(methods, req.WsMethod)
+		return next(ctx, req)
+	}}
+
+	_, err := s.client.NewSessionLogonService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewSessionStatusService().Do(newContext())
+	s.Require().NoError(err)
+	_, err = s.client.NewSessionLogoutService().Do(newContext())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"session.logon", "session.status", "session.logout"}, methods)
+}
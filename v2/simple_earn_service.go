@@ -23,7 +23,7 @@ func (s *SimpleEarnAccountService) Do(ctx context.Context) (res *SimpleEarnAccou
 		return
 	}
 	res = new(SimpleEarnAccountResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -88,7 +88,7 @@ func (s *ListSimpleEarnFlexibleService) Do(ctx context.Context) (res *SimpleEarn
 		return
 	}
 	res = new(SimpleEarnFlexibleList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -164,7 +164,7 @@ func (s *ListSimpleEarnLockedService) Do(ctx context.Context) (res *SimpleEarnLo
 		return
 	}
 	res = new(SimpleEarnLockedList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -230,7 +230,7 @@ func (s *SubscribeSimpleEarnFlexibleService) Do(ctx context.Context) (res *Subsc
 		return
 	}
 	res = new(SubscribeSimpleEarnFlexibleResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -278,7 +278,7 @@ func (s *SubscribeSimpleEarnLockedService) Do(ctx context.Context) (res *Subscri
 		return
 	}
 	res = new(SubscribeSimpleEarnLockedResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -331,7 +331,7 @@ func (s *RedeemSimpleEarnFlexibleService) Do(ctx context.Context) (res *RedeemSi
 		return
 	}
 	res = new(RedeemSimpleEarnFlexibleResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -371,7 +371,7 @@ func (s *RedeemSimpleEarnLockedService) Do(ctx context.Context) (res *RedeemSimp
 		return
 	}
 	res = new(RedeemSimpleEarnLockedResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -433,7 +433,7 @@ func (s *GetSimpleEarnFlexiblePositionService) Do(ctx context.Context) (res *Get
 		return
 	}
 	res = new(GetSimpleEarnFlexiblePositionResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -509,7 +509,7 @@ func (s *GetSimpleEarnLockedPositionService) Do(ctx context.Context) (res *GetSi
 		return
 	}
 	res = new(GetSimpleEarnLockedPositionResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -590,7 +590,7 @@ func (s *ListSimpleEarnFlexibleRateHistoryService) Do(ctx context.Context) (res
 		return
 	}
 	res = new(ListSimpleEarnFlexibleRateHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -22,7 +22,7 @@ func (s *SolStakingAccountService) Do(ctx context.Context) (*SolStakingAccountRe
 		return nil, err
 	}
 	res := new(SolStakingAccountResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +93,7 @@ func (s *SolStakingHistoryService) Do(ctx context.Context) (*SolStakingHistoryRe
 		return nil, err
 	}
 	res := new(SolStakingHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -171,7 +171,7 @@ func (s *SolStakingRewardsHistoryService) Do(ctx context.Context) (*SolStakingRe
 		return nil, err
 	}
 	res := new(SolStakingRewardsHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +248,7 @@ func (s *SolStakingRedemptionHistoryService) Do(ctx context.Context) (*SolStakin
 		return nil, err
 	}
 	res := new(SolStakingRedemptionHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -296,7 +296,7 @@ func (s *SolStakingService) Do(ctx context.Context) (res *SolStakingResponse, er
 		return
 	}
 	res = new(SolStakingResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -336,7 +336,7 @@ func (s *SolRedeemService) Do(ctx context.Context) (res *SolRedeemResponse, err
 		return
 	}
 	res = new(SolRedeemResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
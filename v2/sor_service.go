@@ -0,0 +1,225 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// ErrEmptySOROrderResponse is returned when the WS API returns no order
+var ErrEmptySOROrderResponse = errors.New("empty sor order response")
+
+// CreateSOROrderService create an order with Smart Order Routing, which may
+// be filled in the order books of several symbols of the same base asset
+type CreateSOROrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	timeInForce             *TimeInForceType
+	quantity                *string
+	price                   *string
+	newClientOrderID        *string
+	strategyID              *int64
+	strategyType            *int
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+}
+
+// Symbol set symbol
+func (s *CreateSOROrderService) Symbol(symbol string) *CreateSOROrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateSOROrderService) Side(side SideType) *CreateSOROrderService {
+	s.side = side
+	return s
+}
+
+// Type set type, LIMIT or MARKET
+func (s *CreateSOROrderService) Type(orderType OrderType) *CreateSOROrderService {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CreateSOROrderService) TimeInForce(timeInForce TimeInForceType) *CreateSOROrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateSOROrderService) Quantity(quantity string) *CreateSOROrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// Price set price
+func (s *CreateSOROrderService) Price(price string) *CreateSOROrderService {
+	s.price = &price
+	return s
+}
+
+// NewClientOrderID set newClientOrderId
+func (s *CreateSOROrderService) NewClientOrderID(newClientOrderID string) *CreateSOROrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StrategyID set strategyId
+func (s *CreateSOROrderService) StrategyID(strategyID int64) *CreateSOROrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StrategyType set strategyType
+func (s *CreateSOROrderService) StrategyType(strategyType int) *CreateSOROrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// IcebergQuantity set icebergQuantity
+func (s *CreateSOROrderService) IcebergQuantity(icebergQuantity string) *CreateSOROrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateSOROrderService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateSOROrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateSOROrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateSOROrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// createOrder send the order, and return the request to tell whether it was
+// sent over the WS API
+func (s *CreateSOROrderService) createOrder(ctx context.Context, endpoint, wsMethod string, opts ...RequestOption) (data []byte, r *request, err error) {
+	if s.c.OrderValidator != nil {
+		err = s.c.OrderValidator.Validate(&common.OrderParams{
+			Symbol:   s.symbol,
+			Side:     string(s.side),
+			Market:   s.price == nil,
+			Price:    s.price,
+			Quantity: s.quantity,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	r = &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+		wsMethod: wsMethod,
+	}
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+		"type":   s.orderType,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	data, _, err = s.c.callAPI(ctx, r, opts...)
+	return data, r, err
+}
+
+// Do send request
+func (s *CreateSOROrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateSOROrderResponse, err error) {
+	data, r, err := s.createOrder(ctx, "/api/v3/sor/order", "sor.order.place", opts...)
+	if err != nil {
+		return nil, err
+	}
+	// the WS API returns the order in an array
+	if r.ws {
+		orders := make([]*CreateSOROrderResponse, 0)
+		err = r.decode(data, &orders)
+		if err != nil {
+			return nil, err
+		}
+		if len(orders) == 0 {
+			return nil, ErrEmptySOROrderResponse
+		}
+		return orders[0], nil
+	}
+	res = new(CreateSOROrderResponse)
+	err = r.decode(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Test send test api to check if the request is valid
+func (s *CreateSOROrderService) Test(ctx context.Context, opts ...RequestOption) (err error) {
+	_, _, err = s.createOrder(ctx, "/api/v3/sor/order/test", "sor.order.test", opts...)
+	return err
+}
+
+// CreateSOROrderResponse define create SOR order response
+type CreateSOROrderResponse struct {
+	Symbol                   string          `json:"symbol"`
+	OrderID                  int64           `json:"orderId"`
+	OrderListID              int64           `json:"orderListId"`
+	ClientOrderID            string          `json:"clientOrderId"`
+	TransactTime             int64           `json:"transactTime"`
+	Price                    string          `json:"price"`
+	OrigQuantity             string          `json:"origQty"`
+	ExecutedQuantity         string          `json:"executedQty"`
+	CummulativeQuoteQuantity string          `json:"cummulativeQuoteQty"`
+	Status                   OrderStatusType `json:"status"`
+	TimeInForce              TimeInForceType `json:"timeInForce"`
+	Type                     OrderType       `json:"type"`
+	Side                     SideType        `json:"side"`
+	WorkingTime              int64           `json:"workingTime"`
+	Fills                    []*SORFill      `json:"fills"`
+	WorkingFloor             string          `json:"workingFloor"`
+	SelfTradePreventionMode  string          `json:"selfTradePreventionMode"`
+	UsedSor                  bool            `json:"usedSor"`
+}
+
+// SORFill may be returned in an array of fills in a CreateSOROrderResponse
+type SORFill struct {
+	MatchType       string `json:"matchType"`
+	Price           string `json:"price"`
+	Quantity        string `json:"qty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	TradeID         int64  `json:"tradeId"`
+	AllocID         int64  `json:"allocId"`
+}
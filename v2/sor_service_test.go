@@ -0,0 +1,160 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type sorServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestSORService(t *testing.T) {
+	suite.Run(t, new(sorServiceTestSuite))
+}
+
+func (s *sorServiceTestSuite) TestCreateSOROrder() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 2,
+		"orderListId": -1,
+		"clientOrderId": "sBI1KM6nNtOfj5tccZSKly",
+		"transactTime": 1689149087774,
+		"price": "31000.00000000",
+		"origQty": "0.50000000",
+		"executedQty": "0.50000000",
+		"cummulativeQuoteQty": "14000.00000000",
+		"status": "FILLED",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "BUY",
+		"workingTime": 1689149087774,
+		"fills": [
+			{
+				"matchType": "ONE_PARTY_TRADE_REPORT",
+				"price": "28000.00000000",
+				"qty": "0.50000000",
+				"commission": "0.00000000",
+				"commissionAsset": "BTC",
+				"tradeId": -1,
+				"allocId": 0
+			}
+		],
+		"workingFloor": "SOR",
+		"selfTradePreventionMode": "NONE",
+		"usedSor": true
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeBuy,
+			"type":                    OrderTypeLimit,
+			"timeInForce":             TimeInForceTypeGTC,
+			"quantity":                "0.5",
+			"price":                   "31000",
+			"selfTradePreventionMode": SelfTradePreventionModeTypeNone,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.5").Price("31000").
+		SelfTradePreventionMode(SelfTradePreventionModeTypeNone).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CreateSOROrderResponse{
+		Symbol:                   "BTCUSDT",
+		OrderID:                  2,
+		OrderListID:              -1,
+		ClientOrderID:            "sBI1KM6nNtOfj5tccZSKly",
+		TransactTime:             1689149087774,
+		Price:                    "31000.00000000",
+		OrigQuantity:             "0.50000000",
+		ExecutedQuantity:         "0.50000000",
+		CummulativeQuoteQuantity: "14000.00000000",
+		Status:                   OrderStatusTypeFilled,
+		TimeInForce:              TimeInForceTypeGTC,
+		Type:                     OrderTypeLimit,
+		Side:                     SideTypeBuy,
+		WorkingTime:              1689149087774,
+		Fills: []*SORFill{{
+			MatchType:       "ONE_PARTY_TRADE_REPORT",
+			Price:           "28000.00000000",
+			Quantity:        "0.50000000",
+			Commission:      "0.00000000",
+			CommissionAsset: "BTC",
+			TradeID:         -1,
+		}},
+		WorkingFloor:            "SOR",
+		SelfTradePreventionMode: "NONE",
+		UsedSor:                 true,
+	}, res)
+}
+
+func (s *sorServiceTestSuite) TestTestSOROrder() {
+	s.mockDo([]byte(`{}`), nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":   "BTCUSDT",
+			"side":     SideTypeSell,
+			"type":     OrderTypeMarket,
+			"quantity": "0.5",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	err := s.client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeMarket).Quantity("0.5").Test(newContext())
+	s.r().NoError(err)
+}
+
+func (s *sorServiceTestSuite) TestCreateSOROrderRetryFound() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1007, "msg": "Timeout waiting for response from backend server."}`), http.StatusBadRequest), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 2,
+		"orderListId": -1,
+		"clientOrderId": "sBI1KM6nNtOfj5tccZSKly",
+		"price": "31000.00000000",
+		"origQty": "0.50000000",
+		"executedQty": "0.50000000",
+		"cummulativeQuoteQty": "14000.00000000",
+		"status": "FILLED",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "BUY",
+		"time": 1689149001117,
+		"updateTime": 1689149001117
+	}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	res, err := s.client.NewCreateSOROrderService().Symbol("BTCUSDT").
+		Side(SideTypeBuy).Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).
+		Quantity("0.5").Price("31000").NewClientOrderID("sBI1KM6nNtOfj5tccZSKly").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&CreateSOROrderResponse{
+		Symbol:                   "BTCUSDT",
+		OrderID:                  2,
+		OrderListID:              -1,
+		ClientOrderID:            "sBI1KM6nNtOfj5tccZSKly",
+		TransactTime:             1689149001117,
+		Price:                    "31000.00000000",
+		OrigQuantity:             "0.50000000",
+		ExecutedQuantity:         "0.50000000",
+		CummulativeQuoteQuantity: "14000.00000000",
+		Status:                   OrderStatusTypeFilled,
+		TimeInForce:              TimeInForceTypeGTC,
+		Type:                     OrderTypeLimit,
+		Side:                     SideTypeBuy,
+		Fills:                    []*SORFill{},
+		UsedSor:                  true,
+	}, res)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
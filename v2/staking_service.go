@@ -70,7 +70,7 @@ func (s *StakingProductPositionService) Do(ctx context.Context) (*StakingProduct
 		return nil, err
 	}
 	res := new(StakingProductPositions)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +193,7 @@ func (s *StakingHistoryService) Do(ctx context.Context) (*StakingHistory, error)
 		return nil, err
 	}
 	res := new(StakingHistory)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +22,7 @@ func (s *EthStakingAccountService) Do(ctx context.Context) (*EthStakingAccountRe
 		return nil, err
 	}
 	res := new(EthStakingAccountResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func (s *EthStakingHistoryService) Do(ctx context.Context) (*EthStakingHistoryRe
 		return nil, err
 	}
 	res := new(EthStakingHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +177,7 @@ func (s *EthStakingRewardsHistoryService) Do(ctx context.Context) (*EthStakingRe
 		return nil, err
 	}
 	res := new(EthStakingRewardsHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +254,7 @@ func (s *EthStakingRedemptionHistoryService) Do(ctx context.Context) (*EthStakin
 		return nil, err
 	}
 	res := new(EthStakingRedemptionHistoryResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -302,7 +302,7 @@ func (s *EthStakingService) Do(ctx context.Context) (res *EthStakingResponse, er
 		return
 	}
 	res = new(EthStakingResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -342,7 +342,7 @@ func (s *EthWrappingService) Do(ctx context.Context) (res *EthWrappingResponse,
 		return
 	}
 	res = new(EthWrappingResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -390,7 +390,7 @@ func (s *EthRedeemService) Do(ctx context.Context) (res *EthRedeemResponse, err
 		return
 	}
 	res = new(EthRedeemResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,159 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// StreamManager subscribe the market streams of a client over a few
+// connections to its combined streams endpoint. The streams can be added
+// and removed without reconnecting, by their names, e.g. btcusdt@aggTrade.
+type StreamManager struct {
+	*common.StreamManager
+}
+
+// NewStreamManager create a stream manager of the combined streams endpoint
+// of the client, the connections are dialed by the first subscriptions
+func (c *Client) NewStreamManager() *StreamManager {
+	endpoint := strings.TrimSuffix(c.combinedEndpoint(), "?streams=")
+	return &StreamManager{&common.StreamManager{
+		Dial: func(ctx context.Context) (common.StreamConn, error) {
+			return dialStream(ctx, endpoint)
+		},
+		ErrHandler: func(err error) {
+			websocketLogger().Error("stream manager error", "endpoint", endpoint, "error", err)
+		},
+	}}
+}
+
+// SetErrHandler set the handler of the errors of the connections, a
+// *common.StreamsDroppedError when one drops
+func (m *StreamManager) SetErrHandler(errHandler ErrHandler) *StreamManager {
+	m.ErrHandler = errHandler
+	return m
+}
+
+// symbolStreams return the names of the stream of the symbols
+func symbolStreams(symbols []string, format string, args ...interface{}) []string {
+	suffix := fmt.Sprintf(format, args...)
+	streams := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		streams[i] = strings.ToLower(symbol) + suffix
+	}
+	return streams
+}
+
+// streamErrHandler log the errors of the streams without error handler
+func streamErrHandler(errHandler ErrHandler) ErrHandler {
+	if errHandler != nil {
+		return errHandler
+	}
+	return func(err error) {
+		websocketLogger().Error("stream error", "error", err)
+	}
+}
+
+// SubscribeAggTrade subscribe the <symbol>@aggTrade streams
+func (m *StreamManager) SubscribeAggTrade(ctx context.Context, symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsAggTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@aggTrade")...)
+}
+
+// SubscribeTrade subscribe the <symbol>@trade streams
+func (m *StreamManager) SubscribeTrade(ctx context.Context, symbols []string, handler WsTradeHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsTradeEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@trade")...)
+}
+
+// SubscribeKline subscribe the <symbol>@kline_<interval> streams
+func (m *StreamManager) SubscribeKline(ctx context.Context, symbols []string, interval string, handler WsKlineHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsKlineEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@kline_%s", interval)...)
+}
+
+// SubscribeBookTicker subscribe the <symbol>@bookTicker streams
+func (m *StreamManager) SubscribeBookTicker(ctx context.Context, symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsBookTickerEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@bookTicker")...)
+}
+
+// SubscribeMarketStat subscribe the <symbol>@ticker streams
+func (m *StreamManager) SubscribeMarketStat(ctx context.Context, symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		event := new(WsMarketStatEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}, symbolStreams(symbols, "@ticker")...)
+}
+
+// SubscribeDepth subscribe the <symbol>@depth streams, updated every second
+func (m *StreamManager) SubscribeDepth(ctx context.Context, symbols []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth"), handler, errHandler)
+}
+
+// SubscribeDepth100Ms subscribe the <symbol>@depth@100ms streams
+func (m *StreamManager) SubscribeDepth100Ms(ctx context.Context, symbols []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	return m.subscribeDepth(ctx, symbolStreams(symbols, "@depth@100ms"), handler, errHandler)
+}
+
+func (m *StreamManager) subscribeDepth(ctx context.Context, streams []string, handler WsDepthHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		j, err := newJSON(data)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(newWsDepthEvent(j))
+	}, streams...)
+}
+
+// SubscribePartialDepth subscribe the <symbol>@depth<levels> streams, the
+// levels are 5, 10 or 20
+func (m *StreamManager) SubscribePartialDepth(ctx context.Context, symbols []string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) error {
+	errHandler = streamErrHandler(errHandler)
+	return m.Subscribe(ctx, func(stream string, data []byte) {
+		j, err := newJSON(data)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		symbol := strings.ToUpper(strings.Split(stream, "@")[0])
+		handler(newWsPartialDepthEvent(j, symbol))
+	}, symbolStreams(symbols, "@depth%s", levels)...)
+}
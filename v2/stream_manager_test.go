@@ -0,0 +1,160 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStreamConn acknowledge the methods, the streams are pushed with push
+type fakeStreamConn struct {
+	readC  chan []byte
+	closeC chan struct{}
+	once   sync.Once
+	params [][]string
+}
+
+func newFakeStreamConn() *fakeStreamConn {
+	return &fakeStreamConn{readC: make(chan []byte, 10), closeC: make(chan struct{})}
+}
+
+func (c *fakeStreamConn) ReadMessage() (int, []byte, error) {
+	select {
+	case message := <-c.readC:
+		return 1, message, nil
+	case <-c.closeC:
+		return 0, nil, errors.New("connection closed")
+	}
+}
+
+func (c *fakeStreamConn) WriteMessage(messageType int, data []byte) error {
+	var req struct {
+		Params []string `json:"params"`
+		ID     int64    `json:"id"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	c.params = append(c.params, req.Params)
+	c.readC <- []byte(fmt.Sprintf(`{"result":null,"id":%d}`, req.ID))
+	return nil
+}
+
+func (c *fakeStreamConn) Close() error {
+	c.once.Do(func() { close(c.closeC) })
+	return nil
+}
+
+func (c *fakeStreamConn) push(stream, data string) {
+	c.readC <- []byte(fmt.Sprintf(`{"stream":%q,"data":%s}`, stream, data))
+}
+
+func newTestStreamManager(conn *fakeStreamConn) *StreamManager {
+	m := NewClient("apiKey", "secretKey").NewStreamManager()
+	m.Dial = func(ctx context.Context) (common.StreamConn, error) {
+		return conn, nil
+	}
+	return m
+}
+
+func TestStreamManagerSubscribeAggTrade(t *testing.T) {
+	conn := newFakeStreamConn()
+	m := newTestStreamManager(conn)
+	defer m.Close()
+
+	eventC := make(chan *WsAggTradeEvent, 1)
+	err := m.SubscribeAggTrade(context.Background(), []string{"BTCUSDT"}, func(event *WsAggTradeEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@aggTrade"}}, conn.params)
+
+	conn.push("btcusdt@aggTrade", `{"e":"aggTrade","E":1672515782136,"s":"BTCUSDT","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":1672515782136,"m":true,"M":true}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSDT", event.Symbol)
+		assert.Equal(t, int64(12345), event.AggTradeID)
+		assert.Equal(t, "0.001", event.Price)
+		assert.True(t, event.IsBuyerMaker)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerSubscribeKline(t *testing.T) {
+	conn := newFakeStreamConn()
+	m := newTestStreamManager(conn)
+	defer m.Close()
+
+	eventC := make(chan *WsKlineEvent, 1)
+	err := m.SubscribeKline(context.Background(), []string{"BTCUSDT", "ETHUSDT"}, "1m", func(event *WsKlineEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@kline_1m", "ethusdt@kline_1m"}}, conn.params)
+
+	conn.push("ethusdt@kline_1m", `{"e":"kline","E":1672515782136,"s":"ETHUSDT","k":{"t":1672515780000,"T":1672515839999,"s":"ETHUSDT","i":"1m","o":"1200.1","c":"1201.5","x":false}}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "ETHUSDT", event.Symbol)
+		assert.Equal(t, "1m", event.Kline.Interval)
+		assert.Equal(t, "1201.5", event.Kline.Close)
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerSubscribePartialDepth(t *testing.T) {
+	conn := newFakeStreamConn()
+	m := newTestStreamManager(conn)
+	defer m.Close()
+
+	eventC := make(chan *WsPartialDepthEvent, 1)
+	err := m.SubscribePartialDepth(context.Background(), []string{"BTCUSDT"}, "5", func(event *WsPartialDepthEvent) {
+		eventC <- event
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"btcusdt@depth5"}}, conn.params)
+
+	conn.push("btcusdt@depth5", `{"lastUpdateId":160,"bids":[["0.0024","10"]],"asks":[["0.0026","100"]]}`)
+	select {
+	case event := <-eventC:
+		assert.Equal(t, "BTCUSDT", event.Symbol, "the symbol is taken from the stream name")
+		assert.Equal(t, int64(160), event.LastUpdateID)
+		require.Len(t, event.Bids, 1)
+		assert.Equal(t, Bid{Price: "0.0024", Quantity: "10"}, event.Bids[0])
+		require.Len(t, event.Asks, 1)
+		assert.Equal(t, Ask{Price: "0.0026", Quantity: "100"}, event.Asks[0])
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+}
+
+func TestStreamManagerDecodeError(t *testing.T) {
+	conn := newFakeStreamConn()
+	m := newTestStreamManager(conn)
+	defer m.Close()
+
+	errC := make(chan error, 1)
+	err := m.SubscribeBookTicker(context.Background(), []string{"BTCUSDT"}, func(event *WsBookTickerEvent) {
+		t.Error("an invalid event is not handled")
+	}, func(err error) {
+		errC <- err
+	})
+	require.NoError(t, err)
+
+	conn.push("btcusdt@bookTicker", `{"u":"invalid"}`)
+	select {
+	case err := <-errC:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the error was not received")
+	}
+}
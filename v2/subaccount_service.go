@@ -31,7 +31,7 @@ func (s *TransferToSubAccountService) Amount(amount string) *TransferToSubAccoun
 	return s
 }
 
-func (s *TransferToSubAccountService) transferToSubaccount(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+func (s *TransferToSubAccountService) transferToSubaccount(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, *request, error) {
 	r := &request{
 		method:   "POST",
 		endpoint: endpoint,
@@ -45,19 +45,19 @@ func (s *TransferToSubAccountService) transferToSubaccount(ctx context.Context,
 	r.setParams(m)
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *TransferToSubAccountService) Do(ctx context.Context, opts ...RequestOption) (res *TransferToSubAccountResponse, err error) {
-	data, err := s.transferToSubaccount(ctx, "/sapi/v1/sub-account/transfer/subToSub", opts...)
+	data, r, err := s.transferToSubaccount(ctx, "/sapi/v1/sub-account/transfer/subToSub", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = &TransferToSubAccountResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func (s *SubaccountDepositAddressService) Network(network string) *SubaccountDep
 	return s
 }
 
-func (s *SubaccountDepositAddressService) subaccountDepositAddress(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+func (s *SubaccountDepositAddressService) subaccountDepositAddress(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, *request, error) {
 	r := &request{
 		method:   "GET",
 		endpoint: endpoint,
@@ -108,19 +108,19 @@ func (s *SubaccountDepositAddressService) subaccountDepositAddress(ctx context.C
 	r.setParams(m)
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *SubaccountDepositAddressService) Do(ctx context.Context, opts ...RequestOption) (res *SubaccountDepositAddressResponse, err error) {
-	data, err := s.subaccountDepositAddress(ctx, "/sapi/v1/capital/deposit/subAddress", opts...)
+	data, r, err := s.subaccountDepositAddress(ctx, "/sapi/v1/capital/deposit/subAddress", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = &SubaccountDepositAddressResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +145,7 @@ func (s *SubaccountAssetsService) Email(email string) *SubaccountAssetsService {
 	return s
 }
 
-func (s *SubaccountAssetsService) subaccountAssets(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+func (s *SubaccountAssetsService) subaccountAssets(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, *request, error) {
 	r := &request{
 		method:   "GET",
 		endpoint: endpoint,
@@ -157,19 +157,19 @@ func (s *SubaccountAssetsService) subaccountAssets(ctx context.Context, endpoint
 	r.setParams(m)
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *SubaccountAssetsService) Do(ctx context.Context, opts ...RequestOption) (res *SubaccountAssetsResponse, err error) {
-	data, err := s.subaccountAssets(ctx, "/sapi/v3/sub-account/assets", opts...)
+	data, r, err := s.subaccountAssets(ctx, "/sapi/v3/sub-account/assets", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = &SubaccountAssetsResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +210,7 @@ func (s *SubaccountSpotSummaryService) Size(size int32) *SubaccountSpotSummarySe
 	return s
 }
 
-func (s *SubaccountSpotSummaryService) subaccountSpotSummary(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, error) {
+func (s *SubaccountSpotSummaryService) subaccountSpotSummary(ctx context.Context, endpoint string, opts ...RequestOption) ([]byte, *request, error) {
 	r := &request{
 		method:   "GET",
 		endpoint: endpoint,
@@ -230,19 +230,19 @@ func (s *SubaccountSpotSummaryService) subaccountSpotSummary(ctx context.Context
 	}
 	data, _, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, r, err
 	}
-	return data, nil
+	return data, r, nil
 }
 
 // Do send request
 func (s *SubaccountSpotSummaryService) Do(ctx context.Context, opts ...RequestOption) (res *SubaccountSpotSummaryResponse, err error) {
-	data, err := s.subaccountSpotSummary(ctx, "/sapi/v1/sub-account/spotSummary", opts...)
+	data, r, err := s.subaccountSpotSummary(ctx, "/sapi/v1/sub-account/spotSummary", opts...)
 	if err != nil {
 		return nil, err
 	}
 	res = &SubaccountSpotSummaryResponse{}
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +319,7 @@ func (s *SubAccountListService) Do(ctx context.Context, opts ...RequestOption) (
 		return nil, err
 	}
 	res = new(SubAccountList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -385,7 +385,7 @@ func (s *ManagedSubAccountDepositService) Do(ctx context.Context, opts ...Reques
 	}
 
 	res := &ManagedSubAccountDepositResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -448,7 +448,7 @@ func (s *ManagedSubAccountWithdrawalService) Do(ctx context.Context, opts ...Req
 	}
 
 	res := &ManagedSubAccountWithdrawalResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -492,7 +492,7 @@ func (s *ManagedSubAccountAssetsService) Do(ctx context.Context, opts ...Request
 	}
 
 	res := make([]*ManagedSubAccountAsset, 0)
-	if err := json.Unmarshal(data, &res); err != nil {
+	if err := r.decode(data, &res); err != nil {
 		return nil, err
 	}
 
@@ -525,7 +525,7 @@ func (s *SubAccountFuturesAccountService) Do(ctx context.Context, opts ...Reques
 		return nil, err
 	}
 	res = new(SubAccountFuturesAccount)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -581,7 +581,7 @@ func (s *SubAccountFuturesSummaryV1Service) Do(ctx context.Context, opts ...Requ
 		return nil, err
 	}
 	res = new(SubAccountFuturesSummaryV1)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -663,7 +663,7 @@ func (s *SubAccountFuturesTransferV1Service) Do(ctx context.Context, opts ...Req
 		return nil, err
 	}
 	res = new(SubAccountFuturesTransferResponse)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -705,7 +705,7 @@ func (s *SubAccountFuturesPositionRiskService) Do(ctx context.Context, opts ...R
 		return nil, err
 	}
 	res = make([]*SubAccountFuturesPositionRiskEntry, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
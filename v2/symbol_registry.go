@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// SymbolRegistry caches the spot symbols of the exchange info. See
+// common.SymbolRegistry.
+type SymbolRegistry struct {
+	*common.SymbolRegistry
+}
+
+// NewSymbolRegistry create a registry of the spot symbols, they are loaded
+// by Start or Refresh. The filters are set on Client.OrderValidator, the one
+// set at the time of each refresh, if any.
+func (c *Client) NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{&common.SymbolRegistry{
+		Fetch: func(ctx context.Context) ([]*common.RegistrySymbol, error) {
+			res, err := c.NewExchangeInfoService().Do(ctx)
+			if err != nil {
+				return nil, err
+			}
+			symbols := make([]*common.RegistrySymbol, 0, len(res.Symbols))
+			for i := range res.Symbols {
+				s := &res.Symbols[i]
+				symbols = append(symbols, &common.RegistrySymbol{
+					Symbol:     s.Symbol,
+					BaseAsset:  s.BaseAsset,
+					QuoteAsset: s.QuoteAsset,
+					Status:     s.Status,
+					Filters:    s.OrderFilters(),
+					Info:       s,
+				})
+			}
+			return symbols, nil
+		},
+		OrderValidator: func() *common.OrderValidator {
+			return c.OrderValidator
+		},
+	}}
+}
+
+// ExchangeSymbol return the symbol as returned by the exchange info service,
+// nil if unknown
+func (r *SymbolRegistry) ExchangeSymbol(symbol string) *Symbol {
+	if s := r.Symbol(symbol); s != nil {
+		return s.Info.(*Symbol)
+	}
+	return nil
+}
@@ -45,7 +45,7 @@ func (s *ListBookTickersService) Do(ctx context.Context, opts ...RequestOption)
 		return []*BookTicker{}, err
 	}
 	res = make([]*BookTicker, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*BookTicker{}, err
 	}
@@ -99,7 +99,7 @@ func (s *ListPricesService) Do(ctx context.Context, opts ...RequestOption) (res
 	}
 	data = common.ToJSONList(data)
 	res = make([]*SymbolPrice, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*SymbolPrice{}, err
 	}
@@ -151,7 +151,7 @@ func (s *ListPriceChangeStatsService) Do(ctx context.Context, opts ...RequestOpt
 	}
 	data = common.ToJSONList(data)
 	res = make([]*PriceChangeStats, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +208,7 @@ func (s *AveragePriceService) Do(ctx context.Context, opts ...RequestOption) (re
 		return res, err
 	}
 	res = new(AvgPrice)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return nil, err
 	}
@@ -297,7 +297,7 @@ func (s *ListSymbolTickerService) Do(ctx context.Context, opts ...RequestOption)
 		return []*SymbolTicker{}, err
 	}
 	res = make([]*SymbolTicker, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*SymbolTicker{}, err
 	}
@@ -34,7 +34,7 @@ func (s *TradeFeeService) Do(ctx context.Context) (res []*TradeFeeDetails, err e
 		return res, err
 	}
 	res = make([]*TradeFeeDetails, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return nil, err
 	}
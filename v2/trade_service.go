@@ -81,7 +81,7 @@ func (s *ListTradesService) Do(ctx context.Context, opts ...RequestOption) (res
 		return []*TradeV3{}, err
 	}
 	res = make([]*TradeV3, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*TradeV3{}, err
 	}
@@ -135,7 +135,7 @@ func (s *HistoricalTradesService) Do(ctx context.Context, opts ...RequestOption)
 		return
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -237,7 +237,7 @@ func (s *AggTradesService) Do(ctx context.Context, opts ...RequestOption) (res [
 		return []*AggTrade{}, err
 	}
 	res = make([]*AggTrade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*AggTrade{}, err
 	}
@@ -291,7 +291,7 @@ func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (re
 		return []*Trade{}, err
 	}
 	res = make([]*Trade, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return []*Trade{}, err
 	}
@@ -0,0 +1,337 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/delivery"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/adshao/go-binance/v2/options"
+	"github.com/adshao/go-binance/v2/portfolio"
+)
+
+// UnifiedClient is the facade of the clients of an account. The clients are
+// built from the same credentials, and share the HTTP client, the time sync
+// and the logger. Spot and Margin are the same client, with the same rate
+// limit governor.
+type UnifiedClient struct {
+	Spot      *Client
+	Margin    *Client
+	USDM      *futures.Client
+	COINM     *delivery.Client
+	Options   *options.Client
+	Portfolio *portfolio.Client
+
+	// TimeSync gives the time offset of all the clients, it samples the
+	// server time of the spot API unless another one was given. It must be
+	// started to sync the time in background.
+	TimeSync *common.TimeSync
+}
+
+type unifiedConfig struct {
+	httpClient      *http.Client
+	signer          common.Signer
+	logger          common.Logger
+	timeSync        *common.TimeSync
+	rateLimiter     *common.RateLimitGovernor
+	retryPolicy     *common.RetryPolicy
+	interceptors    []common.Interceptor
+	instrumentation common.Instrumentation
+	testnet         bool
+	spotEnv         *Environment
+	usdmEnv         *futures.Environment
+	coinmEnv        *delivery.Environment
+	pmEnv           *portfolio.Environment
+
+	spot      []func(*Client)
+	usdm      []func(*futures.Client)
+	coinm     []func(*delivery.Client)
+	options   []func(*options.Client)
+	portfolio []func(*portfolio.Client)
+}
+
+// UnifiedOption configure the clients of a UnifiedClient
+type UnifiedOption func(*unifiedConfig)
+
+// WithHTTPClient set the HTTP client of all the clients
+func WithHTTPClient(client *http.Client) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithSigner set the signer of the SIGNED requests of all the clients, e.g.
+// for an Ed25519 or RSA key
+func WithSigner(signer common.Signer) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.signer = signer
+	}
+}
+
+// WithLogger set the structured logger of all the clients
+func WithLogger(logger common.Logger) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.logger = logger
+	}
+}
+
+// WithTimeSync replace the time sync of the spot API by the given one
+func WithTimeSync(timeSync *common.TimeSync) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.timeSync = timeSync
+	}
+}
+
+// WithRateLimiter make all the clients share the governor, instead of a
+// governor per API. The calls of all the products are then counted
+// together.
+func WithRateLimiter(governor *common.RateLimitGovernor) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.rateLimiter = governor
+	}
+}
+
+// WithRetryPolicy set the retry policy of all the clients
+func WithRetryPolicy(policy *common.RetryPolicy) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithInterceptors append interceptors to all the clients
+func WithInterceptors(interceptors ...common.Interceptor) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
+
+// WithInstrumentation set the instrumentation of all the clients
+func WithInstrumentation(instrumentation common.Instrumentation) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.instrumentation = instrumentation
+	}
+}
+
+// WithTestnet point the spot, USD-M and COIN-M clients to the testnets,
+// whatever the UseTestnet flags, unless their environments are given.
+// Options and portfolio have no testnet.
+func WithTestnet() UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.testnet = true
+	}
+}
+
+// WithSpotEnvironment set the endpoints of the spot and margin client
+func WithSpotEnvironment(env Environment) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.spotEnv = &env
+	}
+}
+
+// WithUSDMEnvironment set the endpoints of the USD-M futures client
+func WithUSDMEnvironment(env futures.Environment) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.usdmEnv = &env
+	}
+}
+
+// WithCOINMEnvironment set the endpoints of the COIN-M futures client
+func WithCOINMEnvironment(env delivery.Environment) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.coinmEnv = &env
+	}
+}
+
+// WithPortfolioEnvironment set the endpoints of the portfolio margin client
+func WithPortfolioEnvironment(env portfolio.Environment) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.pmEnv = &env
+	}
+}
+
+// WithSpotConfig configure the spot and margin client, after the shared
+// configuration
+func WithSpotConfig(f func(c *Client)) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.spot = append(c.spot, f)
+	}
+}
+
+// WithUSDMConfig configure the USD-M futures client, after the shared
+// configuration
+func WithUSDMConfig(f func(c *futures.Client)) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.usdm = append(c.usdm, f)
+	}
+}
+
+// WithCOINMConfig configure the COIN-M futures client, after the shared
+// configuration
+func WithCOINMConfig(f func(c *delivery.Client)) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.coinm = append(c.coinm, f)
+	}
+}
+
+// WithOptionsConfig configure the options client, after the shared
+// configuration
+func WithOptionsConfig(f func(c *options.Client)) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.options = append(c.options, f)
+	}
+}
+
+// WithPortfolioConfig configure the portfolio margin client, after the
+// shared configuration
+func WithPortfolioConfig(f func(c *portfolio.Client)) UnifiedOption {
+	return func(c *unifiedConfig) {
+		c.portfolio = append(c.portfolio, f)
+	}
+}
+
+// governor return the shared governor, or the one seeded by seed with the
+// limits of the exchange info of an API. The governor learns the limits from
+// the responses if the exchange info cannot be fetched.
+func (c *unifiedConfig) governor(seed func(ctx context.Context) (*common.RateLimitGovernor, error)) *common.RateLimitGovernor {
+	if c.rateLimiter != nil {
+		return c.rateLimiter
+	}
+	if seed != nil {
+		if governor, err := seed(context.Background()); err == nil {
+			return governor
+		}
+	}
+	return common.NewRateLimitGovernor()
+}
+
+// NewUnifiedClient initialize the clients of all the products of an account
+// with API key and secret key. Each API has its own rate limit governor,
+// seeded with the limits of its exchange info, unless WithRateLimiter is
+// given. The WebSocket API connections are dialed once the clients are
+// configured.
+func NewUnifiedClient(apiKey, secretKey string, opts ...UnifiedOption) *UnifiedClient {
+	cfg := &unifiedConfig{httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	spotEnv, usdmEnv, coinmEnv, pmEnv := DefaultEnvironment(), futures.DefaultEnvironment(), delivery.DefaultEnvironment(), portfolio.MainEnvironment()
+	if cfg.testnet {
+		spotEnv, usdmEnv, coinmEnv = TestnetEnvironment(), futures.TestnetEnvironment(), delivery.TestnetEnvironment()
+	}
+	if cfg.spotEnv != nil {
+		spotEnv = *cfg.spotEnv
+	}
+	if cfg.usdmEnv != nil {
+		usdmEnv = *cfg.usdmEnv
+	}
+	if cfg.coinmEnv != nil {
+		coinmEnv = *cfg.coinmEnv
+	}
+	if cfg.pmEnv != nil {
+		pmEnv = *cfg.pmEnv
+	}
+	// the clients are built without their WebSocket API endpoint so that
+	// they are not dialed before being configured
+	spotWsURL, usdmWsURL, coinmWsURL := spotEnv.WsAPIURL, usdmEnv.WsAPIURL, coinmEnv.WsAPIURL
+	spotEnv.WsAPIURL, usdmEnv.WsAPIURL, coinmEnv.WsAPIURL = "", "", ""
+	spot := NewClientWithEnvironment(apiKey, secretKey, spotEnv)
+	usdm := futures.NewClientWithEnvironment(apiKey, secretKey, usdmEnv)
+	coinm := delivery.NewClientWithEnvironment(apiKey, secretKey, coinmEnv)
+	opt := options.NewClient(apiKey, secretKey)
+	pm := portfolio.NewClientWithEnvironment(apiKey, secretKey, pmEnv)
+	spot.WsURL, usdm.WsURL, coinm.WsURL = spotWsURL, usdmWsURL, coinmWsURL
+
+	timeSync := cfg.timeSync
+	if timeSync == nil {
+		timeSync = spot.NewTimeSync()
+	}
+
+	spot.HTTPClient, spot.Signer, spot.StructuredLogger, spot.TimeSync = cfg.httpClient, cfg.signer, cfg.logger, timeSync
+	spot.RetryPolicy, spot.Instrumentation = cfg.retryPolicy, cfg.instrumentation
+	spot.Interceptors = append(spot.Interceptors, cfg.interceptors...)
+	spot.RateLimiter = cfg.governor(func(ctx context.Context) (*common.RateLimitGovernor, error) {
+		info, err := spot.NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return NewRateLimitGovernor(info), nil
+	})
+	for _, f := range cfg.spot {
+		f(spot)
+	}
+
+	usdm.HTTPClient, usdm.Signer, usdm.StructuredLogger, usdm.TimeSync = cfg.httpClient, cfg.signer, cfg.logger, timeSync
+	usdm.RetryPolicy, usdm.Instrumentation = cfg.retryPolicy, cfg.instrumentation
+	usdm.Interceptors = append(usdm.Interceptors, cfg.interceptors...)
+	usdm.RateLimiter = cfg.governor(func(ctx context.Context) (*common.RateLimitGovernor, error) {
+		info, err := usdm.NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return futures.NewRateLimitGovernor(info), nil
+	})
+	for _, f := range cfg.usdm {
+		f(usdm)
+	}
+
+	coinm.HTTPClient, coinm.Signer, coinm.StructuredLogger, coinm.TimeSync = cfg.httpClient, cfg.signer, cfg.logger, timeSync
+	coinm.RetryPolicy, coinm.Instrumentation = cfg.retryPolicy, cfg.instrumentation
+	coinm.Interceptors = append(coinm.Interceptors, cfg.interceptors...)
+	coinm.RateLimiter = cfg.governor(func(ctx context.Context) (*common.RateLimitGovernor, error) {
+		info, err := coinm.NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return delivery.NewRateLimitGovernor(info), nil
+	})
+	for _, f := range cfg.coinm {
+		f(coinm)
+	}
+
+	opt.HTTPClient, opt.Signer, opt.StructuredLogger, opt.TimeSync = cfg.httpClient, cfg.signer, cfg.logger, timeSync
+	opt.RetryPolicy, opt.Instrumentation = cfg.retryPolicy, cfg.instrumentation
+	opt.Interceptors = append(opt.Interceptors, cfg.interceptors...)
+	opt.RateLimiter = cfg.governor(func(ctx context.Context) (*common.RateLimitGovernor, error) {
+		info, err := opt.NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return options.NewRateLimitGovernor(info), nil
+	})
+	for _, f := range cfg.options {
+		f(opt)
+	}
+
+	// the portfolio margin API has no exchange info
+	pm.HTTPClient, pm.Signer, pm.StructuredLogger, pm.TimeSync = cfg.httpClient, cfg.signer, cfg.logger, timeSync
+	pm.RateLimiter, pm.RetryPolicy, pm.Instrumentation = cfg.governor(nil), cfg.retryPolicy, cfg.instrumentation
+	pm.Interceptors = append(pm.Interceptors, cfg.interceptors...)
+	for _, f := range cfg.portfolio {
+		f(pm)
+	}
+
+	// the errors of the connections are logged by the loggers of the clients
+	spot.Connect()
+	usdm.Connect()
+	coinm.Connect()
+
+	return &UnifiedClient{
+		Spot:      spot,
+		Margin:    spot,
+		USDM:      usdm,
+		COINM:     coinm,
+		Options:   opt,
+		Portfolio: pm,
+		TimeSync:  timeSync,
+	}
+}
+
+// Close stop the time sync and close the WebSocket API connections
+func (c *UnifiedClient) Close() {
+	c.TimeSync.Stop()
+	c.Spot.Close()
+	c.USDM.Close()
+	c.COINM.Close()
+}
@@ -0,0 +1,174 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/adshao/go-binance/v2/delivery"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newExchangeInfoHTTPClient return an HTTP client answering the exchange
+// info of each API with a request weight limit per minute of limits[path]
+func newExchangeInfoHTTPClient(limits map[string]int) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		limit, ok := limits[req.URL.Path]
+		if !ok {
+			return newHTTPResponse([]byte(`{"code": -1000, "msg": "unknown"}`), http.StatusNotFound), nil
+		}
+		return newHTTPResponse([]byte(fmt.Sprintf(`{"rateLimits": [{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": %d}]}`, limit)), http.StatusOK), nil
+	})}
+}
+
+func TestNewUnifiedClient(t *testing.T) {
+	httpClient := newExchangeInfoHTTPClient(map[string]int{
+		"/api/v3/exchangeInfo":  6000,
+		"/fapi/v1/exchangeInfo": 2400,
+		"/dapi/v1/exchangeInfo": 1200,
+		"/eapi/v1/exchangeInfo": 400,
+	})
+	logger := common.DefaultLogger(common.LogLevelError)
+	c := NewUnifiedClient("apiKey", "secretKey",
+		WithHTTPClient(httpClient),
+		WithLogger(logger),
+		WithSpotEnvironment(Environment{BaseURL: "https://api.example.com"}),
+		WithUSDMEnvironment(futures.Environment{BaseURL: "https://fapi.example.com"}),
+		WithCOINMEnvironment(delivery.Environment{BaseURL: "https://dapi.example.com"}),
+		WithUSDMConfig(func(c *futures.Client) { c.UserAgent = "usdm" }),
+	)
+	defer c.Close()
+
+	assert.Same(t, c.Spot, c.Margin)
+	assert.Same(t, httpClient, c.Spot.HTTPClient)
+	assert.Same(t, httpClient, c.USDM.HTTPClient)
+	assert.Same(t, httpClient, c.COINM.HTTPClient)
+	assert.Same(t, httpClient, c.Options.HTTPClient)
+	assert.Same(t, httpClient, c.Portfolio.HTTPClient)
+
+	assert.NotNil(t, c.TimeSync)
+	assert.Same(t, c.TimeSync, c.Spot.TimeSync)
+	assert.Same(t, c.TimeSync, c.USDM.TimeSync)
+	assert.Same(t, c.TimeSync, c.COINM.TimeSync)
+	assert.Same(t, c.TimeSync, c.Options.TimeSync)
+	assert.Same(t, c.TimeSync, c.Portfolio.TimeSync)
+
+	assert.Equal(t, logger, c.Portfolio.StructuredLogger)
+	assert.Equal(t, "usdm", c.USDM.UserAgent)
+	assert.Equal(t, "apiKey", c.COINM.APIKey)
+}
+
+func TestNewUnifiedClientGovernors(t *testing.T) {
+	c := NewUnifiedClient("apiKey", "secretKey",
+		WithHTTPClient(newExchangeInfoHTTPClient(map[string]int{
+			"/api/v3/exchangeInfo":  6000,
+			"/fapi/v1/exchangeInfo": 2400,
+			"/dapi/v1/exchangeInfo": 1200,
+			"/eapi/v1/exchangeInfo": 400,
+		})),
+		WithSpotEnvironment(Environment{BaseURL: "https://api.example.com"}),
+		WithUSDMEnvironment(futures.Environment{BaseURL: "https://fapi.example.com"}),
+		WithCOINMEnvironment(delivery.Environment{BaseURL: "https://dapi.example.com"}),
+		WithTimeSync(&common.TimeSync{}),
+	)
+	defer c.Close()
+
+	limit := func(governor *common.RateLimitGovernor) int {
+		usage := governor.Usage()
+		if len(usage) == 0 {
+			return 0
+		}
+		return usage[0].Limit
+	}
+	assert.Same(t, c.Spot.RateLimiter, c.Margin.RateLimiter)
+	assert.Equal(t, 6000, limit(c.Spot.RateLimiter))
+	assert.Equal(t, 2400, limit(c.USDM.RateLimiter))
+	assert.Equal(t, 1200, limit(c.COINM.RateLimiter))
+	assert.Equal(t, 400, limit(c.Options.RateLimiter))
+	assert.NotNil(t, c.Portfolio.RateLimiter)
+	assert.Empty(t, c.Portfolio.RateLimiter.Usage())
+}
+
+func TestNewUnifiedClientGovernorsWithoutExchangeInfo(t *testing.T) {
+	c := NewUnifiedClient("apiKey", "secretKey",
+		WithHTTPClient(newExchangeInfoHTTPClient(nil)),
+		WithSpotEnvironment(Environment{BaseURL: "https://api.example.com"}),
+		WithUSDMEnvironment(futures.Environment{BaseURL: "https://fapi.example.com"}),
+		WithCOINMEnvironment(delivery.Environment{BaseURL: "https://dapi.example.com"}),
+		WithTimeSync(&common.TimeSync{}),
+	)
+	defer c.Close()
+
+	assert.NotNil(t, c.Spot.RateLimiter)
+	assert.Empty(t, c.Spot.RateLimiter.Usage())
+	assert.NotSame(t, c.Spot.RateLimiter, c.USDM.RateLimiter)
+}
+
+func TestNewUnifiedClientShared(t *testing.T) {
+	governor := common.NewRateLimitGovernor()
+	timeSync := &common.TimeSync{}
+	c := NewUnifiedClient("apiKey", "secretKey",
+		WithRateLimiter(governor),
+		WithTimeSync(timeSync),
+		WithTestnet(),
+		WithCOINMConfig(func(c *delivery.Client) { c.RateLimiter = nil }),
+	)
+	defer c.Close()
+
+	assert.Same(t, timeSync, c.TimeSync)
+	assert.Same(t, governor, c.Spot.RateLimiter)
+	assert.Same(t, governor, c.Portfolio.RateLimiter)
+	assert.Nil(t, c.COINM.RateLimiter)
+	assert.Equal(t, BaseAPITestnetURL, c.Spot.BaseURL)
+	assert.Equal(t, futures.BaseAPITestnetURL, c.USDM.BaseURL)
+	assert.Equal(t, delivery.BaseAPITestnetURL, c.COINM.BaseURL)
+}
+
+func TestUnifiedClientClose(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	dialed := true
+
+	c := NewUnifiedClient("apiKey", "secretKey",
+		WithSpotEnvironment(Environment{BaseURL: server.URL, WsAPIURL: wsURL}),
+		WithUSDMEnvironment(futures.Environment{BaseURL: server.URL, WsAPIURL: wsURL}),
+		WithCOINMEnvironment(delivery.Environment{BaseURL: server.URL, WsAPIURL: wsURL}),
+		WithTimeSync(&common.TimeSync{}),
+		WithSpotConfig(func(c *Client) { dialed = c.WsConnected() }),
+	)
+	assert.False(t, dialed)
+	assert.True(t, c.Spot.WsConnected())
+	assert.True(t, c.USDM.WsConnected())
+	assert.True(t, c.COINM.WsConnected())
+
+	c.Close()
+	assert.Eventually(t, func() bool {
+		return !c.Spot.WsConnected() && !c.USDM.WsConnected() && !c.COINM.WsConnected()
+	}, time.Second, 10*time.Millisecond)
+}
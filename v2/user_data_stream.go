@@ -0,0 +1,86 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// UserDataStream is a managed user data stream. It owns the listen key, keeps
+// it alive, reconnects when the connection drops and renews the listen key
+// on listenKeyExpired events. All events are delivered to the handler.
+//
+// KeepaliveInterval, MaxConnectionAge and ReconnectPolicy of the embedded
+// ListenKeyStream may be changed before Start. The ReconnectPolicy is the
+// stream's own, its callbacks are not shared with the WebSocket API one.
+type UserDataStream struct {
+	*common.ListenKeyStream
+}
+
+// NewUserDataStream create a managed spot user data stream
+func (c *Client) NewUserDataStream(handler WsUserDataHandler, errHandler ErrHandler) *UserDataStream {
+	return c.newUserDataStream(
+		func(ctx context.Context) (string, error) {
+			return c.NewStartUserStreamService().Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		handler, errHandler)
+}
+
+// NewMarginUserDataStream create a managed cross margin user data stream
+func (c *Client) NewMarginUserDataStream(handler WsUserDataHandler, errHandler ErrHandler) *UserDataStream {
+	return c.newUserDataStream(
+		func(ctx context.Context) (string, error) {
+			return c.NewStartMarginUserStreamService().Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewKeepaliveMarginUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewCloseMarginUserStreamService().ListenKey(listenKey).Do(ctx)
+		},
+		handler, errHandler)
+}
+
+// NewIsolatedMarginUserDataStream create a managed isolated margin user data stream of the symbol
+func (c *Client) NewIsolatedMarginUserDataStream(symbol string, handler WsUserDataHandler, errHandler ErrHandler) *UserDataStream {
+	return c.newUserDataStream(
+		func(ctx context.Context) (string, error) {
+			return c.NewStartIsolatedMarginUserStreamService().Symbol(symbol).Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewKeepaliveIsolatedMarginUserStreamService().Symbol(symbol).ListenKey(listenKey).Do(ctx)
+		},
+		func(ctx context.Context, listenKey string) error {
+			return c.NewCloseIsolatedMarginUserStreamService().Symbol(symbol).ListenKey(listenKey).Do(ctx)
+		},
+		handler, errHandler)
+}
+
+func (c *Client) newUserDataStream(
+	start func(ctx context.Context) (string, error),
+	keepalive func(ctx context.Context, listenKey string) error,
+	close func(ctx context.Context, listenKey string) error,
+	handler WsUserDataHandler, errHandler ErrHandler,
+) *UserDataStream {
+	return &UserDataStream{&common.ListenKeyStream{
+		StartListenKey:     start,
+		KeepaliveListenKey: keepalive,
+		CloseListenKey:     close,
+		Serve: func(listenKey string, expired func()) (doneC, stopC chan struct{}, err error) {
+			return c.WsUserDataServe(listenKey, func(event *WsUserDataEvent) {
+				if event.Event == UserDataEventTypeListenKeyExpired {
+					expired()
+				}
+				handler(event)
+			}, errHandler)
+		},
+		ReconnectPolicy: common.DefaultReconnectPolicy(),
+		ErrHandler:      errHandler,
+	}}
+}
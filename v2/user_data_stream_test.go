@@ -0,0 +1,30 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type userDataStreamTestSuite struct {
+	baseTestSuite
+}
+
+func TestUserDataStream(t *testing.T) {
+	suite.Run(t, new(userDataStreamTestSuite))
+}
+
+func (s *userDataStreamTestSuite) TestUserDataStreamOwnReconnectPolicy() {
+	policy := common.DefaultReconnectPolicy()
+	policy.OnDisconnect = func(err error) {}
+	s.client.SetReconnectPolicy(policy)
+	for _, stream := range []*UserDataStream{
+		s.client.NewUserDataStream(func(event *WsUserDataEvent) {}, func(err error) {}),
+		s.client.NewMarginUserDataStream(func(event *WsUserDataEvent) {}, func(err error) {}),
+		s.client.NewIsolatedMarginUserDataStream("BTCUSDT", func(event *WsUserDataEvent) {}, func(err error) {}),
+	} {
+		s.r().NotSame(policy, stream.ReconnectPolicy)
+		s.r().Nil(stream.ReconnectPolicy.OnDisconnect)
+	}
+}
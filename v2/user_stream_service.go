@@ -3,9 +3,7 @@ package binance
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"os"
 )
 
 // StartUserStreamService create listen key for user stream service
@@ -24,7 +22,7 @@ func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return "", err
 	}
-	j, err := newJSON(data)
+	j, err := r.decodeJSON(data)
 	if err != nil {
 		return "", err
 	}
@@ -81,43 +79,57 @@ func (s *CloseUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 }
 
 func NewDataStreamClient(oc *Client, handler WsUserDataHandler, errHandler ErrHandler) (*Client, error) {
-	c := makeConn(handler, errHandler)
-	if c == nil {
-		return nil, fmt.Errorf("error to establish websocket connnetion")
+	c, err := makeConn(oc.WsURL, handler, errHandler, oc.logger)
+	if err != nil {
+		return nil, fmt.Errorf("error to establish websocket connnetion: %w", err)
 	}
 
 	client := &Client{
-		APIKey:     oc.APIKey,
-		SecretKey:  oc.SecretKey,
-		BaseURL:    getAPIEndpoint(),
-		UserAgent:  "Binance/golang",
-		HTTPClient: http.DefaultClient,
-		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
-		WsURL:      getWsAPIEndpoint(),
-		WsConn:     c,
-		wsState:    WsConnected,
+		APIKey:            oc.APIKey,
+		SecretKey:         oc.SecretKey,
+		Signer:            oc.Signer,
+		BaseURL:           oc.BaseURL,
+		UserAgent:         oc.UserAgent,
+		HTTPClient:        oc.HTTPClient,
+		Logger:            oc.Logger,
+		StructuredLogger:  oc.StructuredLogger,
+		WsURL:             oc.WsURL,
+		WsStreamURL:       oc.WsStreamURL,
+		CombinedStreamURL: oc.CombinedStreamURL,
+		WsConn:            c,
+		wsState:           WsConnected,
+		wsStopC:           make(chan struct{}),
+		reconnectPolicy:   oc.getReconnectPolicy(),
 	}
-	client.handleDisconnected(c.Done, handler, errHandler)
 
-	r := &request{
-		secType:  secTypeSigned,
-		wsMethod: "userDataStream.subscribe.signature",
-	}
-	data, _, err := client.callWsAPI(context.TODO(), r)
-	if err != nil {
-		return nil, err
-	}
-	res := new(_SubscriptionResponse)
-	err = json.Unmarshal(data, res)
+	err = client.subscribeUserDataStream(context.TODO(), c)
 	if err != nil {
+		close(c.Stop)
 		return nil, err
 	}
+	client.handleDisconnected(c, handler, errHandler)
 
 	//client.Logger.Printf("userdata stream %d\n", res.SubscriptionId)
 
 	return client, nil
 }
 
+// subscribeUserDataStream subscribe the user data stream on the connection,
+// it is also used to restore the subscription after reconnecting
+func (c *Client) subscribeUserDataStream(ctx context.Context, conn *WsConnection) error {
+	r := &request{
+		secType:  secTypeSigned,
+		wsMethod: "userDataStream.subscribe.signature",
+	}
+	r.wsConn = conn
+	data, _, err := c.invoke(ctx, r, true)
+	if err != nil {
+		return err
+	}
+	res := new(_SubscriptionResponse)
+	return r.decode(data, res)
+}
+
 type _SubscriptionResponse struct {
 	SubscriptionId int `json:"subscriptionId"`
 }
@@ -1,8 +1,11 @@
 package binance
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -57,3 +60,14 @@ func (s *userStreamServiceTestSuite) TestCloseUserStream() {
 	err := s.client.NewCloseUserStreamService().ListenKey(listenKey).Do(newContext())
 	s.r().NoError(err)
 }
+
+func (s *userStreamServiceTestSuite) TestKeepaliveUserStreamRetry() {
+	s.client.Client.do = s.client.do
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{"code": -1001, "msg": "Internal error; unable to process your request. Please try again."}`), http.StatusInternalServerError), nil).Once()
+	s.client.On("do", anyHTTPRequest()).Return(newHTTPResponse([]byte(`{}`), http.StatusOK), nil).Once()
+	s.client.RetryPolicy = &common.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}
+
+	err := s.client.NewKeepaliveUserStreamService().ListenKey("dummy").Do(newContext())
+	s.r().NoError(err)
+	s.client.AssertNumberOfCalls(s.T(), "do", 2)
+}
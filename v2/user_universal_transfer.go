@@ -79,7 +79,7 @@ func (s *CreateUserUniversalTransferService) Do(ctx context.Context) (*CreateUse
 	}
 
 	res := &CreateUserUniversalTransferResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -35,7 +35,7 @@ func (s *ListVipLoanableCoinService) Do(ctx context.Context) (res *VipLoanableCo
 		return
 	}
 	res = new(VipLoanableCoinList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -88,7 +88,7 @@ func (s *ListVipCollateralCoinService) Do(ctx context.Context) (res *VipCollater
 		return
 	}
 	res = new(VipCollateralCoinList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
@@ -177,7 +177,7 @@ func (s *VipLoanBorrowService) Do(ctx context.Context) (res *VipLoanBorrowRespon
 		return
 	}
 	res = new(VipLoanBorrowResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -230,7 +230,7 @@ func (s *VipLoanRepayService) Do(ctx context.Context) (res *VipLoanRepayResponse
 		return
 	}
 	res = new(VipLoanRepayResponse)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -287,7 +287,7 @@ func (s *ListVipLoanService) Do(ctx context.Context) (res *VipLoanOrderList, err
 		return
 	}
 	res = new(VipLoanOrderList)
-	err = json.Unmarshal(data, res)
+	err = r.decode(data, res)
 	if err != nil {
 		return
 	}
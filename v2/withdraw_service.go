@@ -100,7 +100,7 @@ func (s *CreateWithdrawService) Do(ctx context.Context) (*CreateWithdrawResponse
 	}
 
 	res := &CreateWithdrawResponse{}
-	if err := json.Unmarshal(data, res); err != nil {
+	if err := r.decode(data, res); err != nil {
 		return nil, err
 	}
 
@@ -203,7 +203,7 @@ func (s *ListWithdrawsService) Do(ctx context.Context) (res []*Withdraw, err err
 		return
 	}
 	res = make([]*Withdraw, 0)
-	err = json.Unmarshal(data, &res)
+	err = r.decode(data, &res)
 	if err != nil {
 		return
 	}
@@ -2,8 +2,6 @@ package binance
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"fmt"
 	"github.com/adshao/go-binance/v2/common"
 	"github.com/google/uuid"
@@ -12,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -30,9 +29,15 @@ var (
 	WsAPITestnetURL = "wss://testnet.binance.vision/ws-api/v3"
 )
 
+// _ResponseMap holds the pending requests of a WebSocket API connection
 type _ResponseMap struct {
-	lock sync.Mutex
-	d    map[string]chan *WsApiResponse
+	lock   sync.Mutex
+	d      map[string]chan *WsApiResponse
+	closed bool
+}
+
+func newResponseMap() *_ResponseMap {
+	return &_ResponseMap{d: make(map[string]chan *WsApiResponse)}
 }
 
 func (m *_ResponseMap) LoadAndDelete(id string) chan *WsApiResponse {
@@ -45,13 +50,27 @@ func (m *_ResponseMap) LoadAndDelete(id string) chan *WsApiResponse {
 	return nil
 }
 
-func (m *_ResponseMap) Set(id string, ch chan *WsApiResponse) {
+// Set register a pending request, return false if the connection is closed already
+func (m *_ResponseMap) Set(id string, ch chan *WsApiResponse) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	if m.closed {
+		return false
+	}
 	m.d[id] = ch
+	return true
 }
 
-var apiResponses = _ResponseMap{d: make(map[string]chan *WsApiResponse)}
+// Close fail all the pending requests by closing their channels
+func (m *_ResponseMap) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.closed = true
+	for id, ch := range m.d {
+		close(ch)
+		delete(m.d, id)
+	}
+}
 
 type WsApiResponse struct {
 	Id     string `json:"id"`
@@ -59,6 +78,9 @@ type WsApiResponse struct {
 	Error  struct {
 		Code int64  `json:"code"`
 		Msg  string `json:"msg"`
+		// Data is the detail of the error, e.g. the retryAfter of a 429 or
+		// 418 response, or the results of a cancel-replace
+		Data ObjectType `json:"data"`
 	} `json:"error"`
 	Result     ObjectType `json:"result"`
 	RateLimits []struct {
@@ -80,18 +102,40 @@ func (o *ObjectType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// getWsAPIEndpoint return the base endpoint of the WebSocket API according the UseTestnet flag
-func getWsAPIEndpoint() string {
-	if UseTestnet {
-		return WsAPITestnetURL
-	}
-	return WsAPIMainURL
-}
-
 type WsConnection struct {
 	*websocket.Conn
 	Done chan struct{}
 	Stop chan struct{}
+	// responses of the pending requests, by request id
+	responses *_ResponseMap
+	// err is the read error which closed the connection
+	err error
+	// loggedOn is set to 1 once session.logon succeeded on the connection
+	loggedOn int32
+}
+
+// LoggedOn return true if the connection is authenticated by session.logon
+func (c *WsConnection) LoggedOn() bool {
+	return atomic.LoadInt32(&c.loggedOn) == 1
+}
+
+// Err return the error which closed the connection after Done is closed,
+// nil if it is closed by Stop
+func (c *WsConnection) Err() error {
+	select {
+	case <-c.Done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *WsConnection) setLoggedOn(loggedOn bool) {
+	var v int32
+	if loggedOn {
+		v = 1
+	}
+	atomic.StoreInt32(&c.loggedOn, v)
 }
 
 type _subscription struct {
@@ -99,16 +143,16 @@ type _subscription struct {
 	Event          ObjectType `json:"event"`
 }
 
-func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
+func makeConn(endpoint string, handler WsUserDataHandler, errHandler ErrHandler, logger func() common.Logger) (*WsConnection, error) {
 	Dialer := websocket.Dialer{
 		Proxy:             http.ProxyFromEnvironment,
 		HandshakeTimeout:  45 * time.Second,
 		EnableCompression: true, // important for huge size message
 	}
 
-	c, _, err0 := Dialer.Dial(getWsAPIEndpoint(), nil)
+	c, _, err0 := Dialer.Dial(endpoint, nil)
 	if err0 != nil {
-		return nil
+		return nil, err0
 	}
 	c.SetReadLimit(wsReadLimit)
 	doneC := make(chan struct{})
@@ -116,7 +160,7 @@ func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
 
 	if errHandler == nil {
 		errHandler = func(err error) {
-			fmt.Printf("error websocket %s, %v\n", getWsAPIEndpoint(), err)
+			logger().Error("websocket error", "endpoint", endpoint, "error", err)
 		}
 	}
 
@@ -163,10 +207,19 @@ func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
 		handler(event)
 	}
 
+	conn := &WsConnection{
+		Conn:      c,
+		Done:      doneC,
+		Stop:      stopC,
+		responses: newResponseMap(),
+	}
+
 	go func() {
 		// This function will exit either on error from
 		// websocket.Conn.ReadMessage or when the stopC channel is
 		// closed by the client.
+		// The pending requests are failed once doneC is closed.
+		defer conn.responses.Close()
 		defer close(doneC)
 		if WebsocketKeepalive {
 			keepAlive(c, WebsocketTimeout)
@@ -188,6 +241,7 @@ func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
 			_, message, err := c.ReadMessage()
 			if err != nil {
 				if !adminForced {
+					conn.err = err
 					errHandler(err)
 				}
 				return
@@ -198,11 +252,8 @@ func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
 			if err != nil {
 				return
 			}
-			if a := apiResponses.LoadAndDelete(res.Id); a != nil {
-				a <- res
-				close(a)
-			} else {
-				// handle it as a subscription event then
+			if res.Id == "" {
+				// no request id, handle it as a subscription event then
 				if handler != nil {
 					var subs _subscription
 					err = json.Unmarshal(message, &subs)
@@ -211,59 +262,172 @@ func makeConn(handler WsUserDataHandler, errHandler ErrHandler) *WsConnection {
 					}
 					wsHandler([]byte(subs.Event))
 				}
+				continue
+			}
+			if a := conn.responses.LoadAndDelete(res.Id); a != nil {
+				a <- res
+				close(a)
+			} else {
+				errHandler(fmt.Errorf("unexpected response id %s, the request may be timed out", res.Id))
 			}
 		}
 	}()
 
-	return &WsConnection{
-		c, doneC, stopC,
+	return conn, nil
+}
+
+// SetReconnectPolicy set how the WebSocket API connection is re-established after it drops
+func (c *Client) SetReconnectPolicy(policy *common.ReconnectPolicy) *Client {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	c.reconnectPolicy = policy
+	return c
+}
+
+func (c *Client) getReconnectPolicy() *common.ReconnectPolicy {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.reconnectPolicy == nil {
+		return common.DefaultReconnectPolicy()
+	}
+	return c.reconnectPolicy
+}
+
+// wsConn return the WebSocket API connection, nil if it is not connected
+func (c *Client) wsConn() *WsConnection {
+	c.wsLock.RLock()
+	defer c.wsLock.RUnlock()
+	if c.wsState != WsConnected {
+		return nil
 	}
+	return c.WsConn
 }
 
-func (c *Client) handleDisconnected(ch chan struct{}, eventHandler WsUserDataHandler, errHandler ErrHandler) {
+func (c *Client) handleDisconnected(conn *WsConnection, eventHandler WsUserDataHandler, errHandler ErrHandler) {
 	go func() {
-		select {
-		case <-ch:
-		}
+		<-conn.Done
+
+		c.wsLock.Lock()
 		// if it is triggered by AdminClose, just ignore
-		if c.wsState == WsAdminClosing {
+		if c.wsState != WsConnected || c.WsConn != conn {
+			c.wsLock.Unlock()
 			return
 		}
 		c.wsState = WsConnecting
-		c.debug("disconnected, try reconnecting later...")
-
-		ticker := time.NewTicker(10 * time.Second)
-		defer ticker.Stop()
+		stopC := c.wsStopC
+		c.wsLock.Unlock()
+
+		policy := c.getReconnectPolicy()
+		c.logger().Warn("websocket api disconnected, try reconnecting later", "endpoint", c.WsURL, "error", conn.Err())
+		c.instrumentation().WsDisconnect(c.WsURL, conn.Err())
+		if policy.OnDisconnect != nil {
+			policy.OnDisconnect(conn.Err())
+		}
 
-		for {
-			// 使用 select 语句同时等待超时和上下文完成
-			select {
-			//case <-sm.ctx.Done():
-			//	// 上下文已完成，可能是超时或取消
-			//	log.Info("%s stream, context terminated...", sm.name)
-			//	return
-			case <-ticker.C:
-				conn := makeConn(nil, nil)
-				if conn != nil {
-					c.WsConn = conn
-					c.wsState = WsConnected
-					// well done, break the loop
-					c.handleDisconnected(conn.Done, eventHandler, errHandler)
-
-					c.debug("reconnected with %s", c.BaseURL)
-					return
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = policy.Wait(attempt, stopC)
+			if err != nil {
+				break
+			}
+			err = c.reconnect(eventHandler, errHandler)
+			if err != common.ErrReconnectStopped {
+				c.instrumentation().WsReconnect(c.WsURL, attempt, err)
+			}
+			if err == nil {
+				c.logger().Info("websocket api reconnected", "endpoint", c.WsURL, "attempt", attempt)
+				if policy.OnReconnect != nil {
+					policy.OnReconnect(attempt)
 				}
-				c.debug("failed to connect to %s, retrying later...", c.BaseURL)
+				return
+			}
+			if err == common.ErrReconnectStopped {
+				break
+			}
+			c.logger().Warn("failed to reconnect websocket api", "endpoint", c.WsURL, "attempt", attempt, "error", err)
+			if !policy.ShouldRetry(attempt) {
+				break
 			}
 		}
+
+		if policy.Stopped(err) {
+			c.logger().Info("stop reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+			return
+		}
+		c.logger().Error("give up reconnecting websocket api", "endpoint", c.WsURL, "error", err)
+		if policy.OnGiveUp != nil {
+			policy.OnGiveUp(err)
+		}
 	}()
 }
 
+// reconnect dial a new connection and restore the session and the user data
+// subscription of the dropped one before using it
+func (c *Client) reconnect(eventHandler WsUserDataHandler, errHandler ErrHandler) error {
+	conn, err := makeConn(c.WsURL, eventHandler, errHandler, c.logger)
+	if err != nil {
+		return err
+	}
+	c.wsLock.RLock()
+	wsSession := c.wsSession
+	c.wsLock.RUnlock()
+	if wsSession {
+		_, err = c.logonSession(context.Background(), conn)
+	}
+	if err == nil && eventHandler != nil {
+		err = c.subscribeUserDataStream(context.Background(), conn)
+	}
+	if err != nil {
+		close(conn.Stop)
+		return err
+	}
+
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.wsState != WsConnecting {
+		close(conn.Stop)
+		return common.ErrReconnectStopped
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn, eventHandler, errHandler)
+	return nil
+}
+
+// Connect dial the WebSocket API connection of the client, if it has an
+// endpoint and is not connected yet
+func (c *Client) Connect() error {
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	if c.WsURL == "" || c.wsState != WsInit {
+		return nil
+	}
+	conn, err := makeConn(c.WsURL, nil, nil, c.logger)
+	if err != nil {
+		return err
+	}
+	if c.wsStopC == nil {
+		c.wsStopC = make(chan struct{})
+	}
+	c.WsConn = conn
+	c.wsState = WsConnected
+	c.handleDisconnected(conn, nil, nil)
+	return nil
+}
+
+// Close close the WebSocket API connection and stop reconnecting
 func (c *Client) Close() {
-	if c.wsState == WsConnected {
-		c.wsState = WsAdminClosing
+	c.wsLock.Lock()
+	defer c.wsLock.Unlock()
+	switch c.wsState {
+	case WsConnected:
 		close(c.WsConn.Stop)
+	case WsConnecting:
+	default:
+		return
 	}
+	c.wsState = WsAdminClosing
+	close(c.wsStopC)
 }
 
 // Encode encodes the values into “URL encoded” form
@@ -290,7 +454,7 @@ func (v params) Encode() string {
 	return buf.String()
 }
 
-func (c *Client) parseWsRequest(r *request, opts ...RequestOption) (err error) {
+func (c *Client) parseWsRequest(conn *WsConnection, r *request, opts ...RequestOption) (err error) {
 	// set request options from user
 	for _, opt := range opts {
 		opt(r)
@@ -310,25 +474,39 @@ func (c *Client) parseWsRequest(r *request, opts ...RequestOption) (err error) {
 	}
 
 	if r.secType == secTypeSigned {
-		r.wsParams[timestampKey] = currentTimestamp() - c.TimeOffset
+		r.wsParams[timestampKey] = currentTimestamp() - c.timeOffset()
+	}
+	// requests on an authenticated session are signed by session.logon already
+	if r.secType == secTypeSigned && (r.wsMethod == sessionLogonMethod || !conn.LoggedOn()) {
 		r.wsParams[apiKey] = c.APIKey
 		raw := r.wsParams.Encode()
 
-		mac := hmac.New(sha256.New, []byte(c.SecretKey))
-		_, err = mac.Write([]byte(raw))
+		sig, err := c.signer().Sign([]byte(raw))
 		if err != nil {
 			return err
 		}
-		r.wsParams[signatureKey] = fmt.Sprintf("%x", mac.Sum(nil))
+		r.wsParams[signatureKey] = sig
 	}
 
-	c.debug("ws-method: %s, params: %v", r.wsMethod, r.wsParams)
+	c.logger().Debug("ws request params", "method", r.wsMethod, "params", r.wsParams)
 
 	return nil
 }
 
 func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
-	err := c.parseWsRequest(r, opts...)
+	conn := r.wsConn
+	if conn == nil {
+		conn = c.wsConn()
+	}
+	if conn == nil {
+		return nil, nil, ErrWsAPINotConnected
+	}
+	return c.callWsConnAPI(ctx, conn, r, opts...)
+}
+
+// callWsConnAPI send the request over the given WebSocket API connection
+func (c *Client) callWsConnAPI(ctx context.Context, conn *WsConnection, r *request, opts ...RequestOption) ([]byte, *RateLimits, error) {
+	err := c.parseWsRequest(conn, r, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -344,11 +522,13 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 		req["params"] = r.wsParams
 	}
 
-	c.debug("request: %#v", req)
+	c.logger().Debug("ws request", "request", req)
 
-	apiResponses.Set(id, ch)
+	if !conn.responses.Set(id, ch) {
+		return nil, nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+	}
 	c.Lock()
-	err = c.WsConn.WriteJSON(req)
+	err = conn.WriteJSON(req)
 	c.Unlock()
 
 	//f := c.do
@@ -357,7 +537,8 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 	//}
 	//res, err := f(req)
 	if err != nil {
-		return nil, nil, err
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: err}
 	}
 
 	// timeout context
@@ -366,22 +547,75 @@ func (c *Client) callWsAPI(ctx context.Context, r *request, opts ...RequestOptio
 
 	select {
 	case <-ctx.Done():
-		return nil, nil, ctx.Err()
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx.Err()}
 
 	case <-ctx2.Done():
-		return nil, nil, ctx2.Err()
+		conn.responses.LoadAndDelete(id)
+		return nil, nil, &common.RequestError{Method: r.wsMethod, Endpoint: r.endpoint, Err: ctx2.Err()}
 
-	case res := <-ch:
-		c.debug("response status code: %d", res.Status)
-		c.debug("response raw: %s", string(res.Result))
-		c.debug("response: %#v", res.Error)
+	case res, ok := <-ch:
+		if !ok {
+			return nil, nil, &common.WsConnectionClosedError{Method: r.wsMethod, Err: conn.Err()}
+		}
+		c.logger().Debug("ws response", "status", res.Status, "result", string(res.Result), "error", res.Error)
 
+		r.statusCode = res.Status
+		r.rateLimits = res.rateLimits()
+		if c.RateLimiter != nil {
+			c.updateWsRateLimits(res)
+		}
 		if res.Status >= http.StatusBadRequest {
 			apiErr := new(common.APIError)
 			apiErr.Code = res.Error.Code
 			apiErr.Message = res.Error.Msg
+			if res.Error.Data != "" {
+				apiErr.Data = []byte(res.Error.Data)
+			}
+			apiErr.StatusCode = res.Status
+			apiErr.Method = r.wsMethod
+			apiErr.Endpoint = r.endpoint
+			apiErr.RateLimits = r.rateLimits
+			if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+				apiErr.RetryAfter = res.retryAfter()
+			}
 			return nil, nil, apiErr
 		}
 		return []byte(res.Result), RateLimitsFromWsResponse(res), nil
 	}
 }
+
+// updateWsRateLimits update the rate limiter with the usage and the backoff
+// of a WebSocket API response
+func (c *Client) updateWsRateLimits(res *WsApiResponse) {
+	c.RateLimiter.Update(res.rateLimits()...)
+	if res.Status == http.StatusTooManyRequests || res.Status == http.StatusTeapot {
+		c.RateLimiter.Backoff(res.retryAfter())
+	}
+}
+
+// rateLimits return the usage reported by the response
+func (res *WsApiResponse) rateLimits() []common.RateLimit {
+	limits := make([]common.RateLimit, 0, len(res.RateLimits))
+	for _, l := range res.RateLimits {
+		limits = append(limits, common.RateLimit{
+			Type:     l.RateLimitType,
+			Interval: common.RateLimitInterval(l.Interval, l.IntervalNum),
+			Limit:    l.Limit,
+			Count:    l.Count,
+		})
+	}
+	return limits
+}
+
+// retryAfter return the backoff required by a 429 or 418 response
+func (res *WsApiResponse) retryAfter() time.Duration {
+	var data struct {
+		// RetryAfter is the time in ms when the backoff ends
+		RetryAfter int64 `json:"retryAfter"`
+	}
+	if res.Error.Data != "" && json.Unmarshal([]byte(res.Error.Data), &data) == nil && data.RetryAfter > 0 {
+		return time.Until(time.UnixMilli(data.RetryAfter))
+	}
+	return common.DefaultRateLimitBackoff
+}
@@ -0,0 +1,594 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/suite"
+)
+
+type wsAPIReply struct {
+	Id     string              `json:"id"`
+	Status int                 `json:"status"`
+	Result jsoniter.RawMessage `json:"result,omitempty"`
+	Error  *common.APIError    `json:"error,omitempty"`
+}
+
+type wsAPIRequest struct {
+	Id     string                 `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// wsAPIServer is a fake WebSocket API server, the response of each request is
+// generated by handle, nil for no response at all.
+type wsAPIServer struct {
+	*httptest.Server
+	sync.Mutex
+	requests []*wsAPIRequest
+	conns    []*websocket.Conn
+	handle   func(req *wsAPIRequest) *wsAPIReply
+}
+
+func newWsAPIServer(handle func(req *wsAPIRequest) *wsAPIReply) *wsAPIServer {
+	s := &wsAPIServer{handle: handle}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.Lock()
+		s.conns = append(s.conns, conn)
+		s.Unlock()
+		defer conn.Close()
+		for {
+			req := new(wsAPIRequest)
+			if err := conn.ReadJSON(req); err != nil {
+				return
+			}
+			s.Lock()
+			s.requests = append(s.requests, req)
+			handle := s.handle
+			s.Unlock()
+			if res := handle(req); res != nil {
+				res.Id = req.Id
+				data, _ := json.Marshal(res)
+				s.Lock()
+				err := conn.WriteMessage(websocket.TextMessage, data)
+				s.Unlock()
+				if err != nil {
+					return
+				}
+			}
+		}
+	}))
+	return s
+}
+
+func (s *wsAPIServer) setHandle(handle func(req *wsAPIRequest) *wsAPIReply) {
+	s.Lock()
+	defer s.Unlock()
+	s.handle = handle
+}
+
+// wsURL return the websocket url of the server
+func (s *wsAPIServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+func (s *wsAPIServer) lastRequest() *wsAPIRequest {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.requests) == 0 {
+		return nil
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// push send the message to all the connections
+func (s *wsAPIServer) push(message string) {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(message))
+	}
+}
+
+// dropConnections close all the connections accepted so far
+func (s *wsAPIServer) dropConnections() {
+	s.Lock()
+	defer s.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func wsAPIResult(result string) *wsAPIReply {
+	return &wsAPIReply{Status: http.StatusOK, Result: jsoniter.RawMessage(result)}
+}
+
+func wsAPIError(status int, code int64, msg string) *wsAPIReply {
+	return &wsAPIReply{Status: status, Error: &common.APIError{Code: code, Message: msg}}
+}
+
+// newWsAPIClient create a client connected to the fake WebSocket API server
+func newWsAPIClient(s *wsAPIServer) *Client {
+	url := WsAPIMainURL
+	defer func() { WsAPIMainURL = url }()
+	WsAPIMainURL = s.wsURL()
+	return NewClient("dummyAPIKey", "dummySecretKey")
+}
+
+type wsClientTestSuite struct {
+	suite.Suite
+}
+
+func TestWsClient(t *testing.T) {
+	suite.Run(t, new(wsClientTestSuite))
+}
+
+func (s *wsClientTestSuite) TestClientsOwnTheirRequests() {
+	r := s.Require()
+	newServer := func(serverTime int64) *wsAPIServer {
+		return newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+			return wsAPIResult(fmt.Sprintf(`{"serverTime": %d}`, serverTime))
+		})
+	}
+	server1, server2 := newServer(1), newServer(2)
+	defer server1.Close()
+	defer server2.Close()
+	client1, client2 := newWsAPIClient(server1), newWsAPIClient(server2)
+	defer client1.Close()
+	defer client2.Close()
+	r.NotSame(client1.WsConn.responses, client2.WsConn.responses)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			serverTime, err := client1.NewServerTimeService().Do(newContext())
+			s.NoError(err)
+			s.Equal(int64(1), serverTime)
+		}()
+		go func() {
+			defer wg.Done()
+			serverTime, err := client2.NewServerTimeService().Do(newContext())
+			s.NoError(err)
+			s.Equal(int64(2), serverTime)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *wsClientTestSuite) TestPendingRequestFailedOnDisconnect() {
+	r := s.Require()
+	received := make(chan struct{})
+	var once sync.Once
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		once.Do(func() { close(received) })
+		return nil
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+	disconnected := make(chan error, 1)
+	policy := testReconnectPolicy()
+	policy.InitialBackoff = time.Hour
+	policy.OnDisconnect = func(err error) { disconnected <- err }
+	client.SetReconnectPolicy(policy)
+
+	go func() {
+		<-received
+		server.dropConnections()
+	}()
+	start := time.Now()
+	_, _, err := client.callWsAPI(newContext(), &request{wsMethod: "time"})
+	r.Less(time.Since(start), 5*time.Second)
+	r.True(common.IsWsConnectionClosedError(err))
+	var closedErr *common.WsConnectionClosedError
+	r.True(errors.As(err, &closedErr))
+	r.Equal("time", closedErr.Method)
+	r.Error(closedErr.Err)
+
+	<-disconnected
+	// no more requests until reconnected
+	_, _, err = client.callWsAPI(newContext(), &request{wsMethod: "time"})
+	r.Equal(ErrWsAPINotConnected, err)
+}
+
+func (s *wsClientTestSuite) TestUnknownResponseID() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"subscriptionId": 0}`)
+	})
+	defer server.Close()
+	oc := newWsAPIClient(server)
+	oc.HTTPClient = &http.Client{}
+	defer oc.Close()
+
+	events := make(chan *WsUserDataEvent, 1)
+	errs := make(chan error, 1)
+	client, err := NewDataStreamClient(oc, func(event *WsUserDataEvent) {
+		events <- event
+	}, func(err error) {
+		errs <- err
+	})
+	r.NoError(err)
+	defer client.Close()
+	r.Same(oc.HTTPClient, client.HTTPClient)
+
+	server.push(`{"id": "a1b2c3", "status": 200, "result": {}}`)
+	select {
+	case err := <-errs:
+		r.Contains(err.Error(), "a1b2c3")
+	case <-time.After(time.Second):
+		r.Fail("no error for the unknown response id")
+	}
+	r.Empty(events)
+
+	server.push(`{"subscriptionId": 0, "event": {"e": "balanceUpdate", "E": 1573200697110, "a": "BTC", "d": "100.00000000", "T": 1573200697068}}`)
+	select {
+	case event := <-events:
+		r.Equal(UserDataEventTypeBalanceUpdate, event.Event)
+		r.Equal("BTC", event.BalanceUpdate.Asset)
+	case <-time.After(time.Second):
+		r.Fail("no event received")
+	}
+}
+
+func testReconnectPolicy() *common.ReconnectPolicy {
+	return &common.ReconnectPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func (s *wsClientTestSuite) TestReconnect() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		if req.Method == "time" {
+			return wsAPIResult(`{"serverTime": 1499827319559}`)
+		}
+		return wsAPIResult(`{"apiKey": "dummyAPIKey"}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	disconnected, reconnected := make(chan error, 1), make(chan int, 1)
+	policy := testReconnectPolicy()
+	policy.OnDisconnect = func(err error) { disconnected <- err }
+	policy.OnReconnect = func(attempts int) { reconnected <- attempts }
+	client.SetReconnectPolicy(policy)
+	_, err := client.NewSessionLogonService().Do(newContext())
+	r.NoError(err)
+
+	server.dropConnections()
+	select {
+	case err := <-disconnected:
+		r.Error(err)
+	case <-time.After(time.Second):
+		r.FailNow("OnDisconnect not called")
+	}
+	select {
+	case attempts := <-reconnected:
+		r.Equal(1, attempts)
+	case <-time.After(time.Second):
+		r.FailNow("OnReconnect not called")
+	}
+	r.True(client.WsConnected())
+	// the session is logged on again before the connection is used
+	r.Equal(sessionLogonMethod, server.lastRequest().Method)
+	r.True(client.WsConn.LoggedOn())
+
+	serverTime, err := client.NewServerTimeService().Do(newContext())
+	r.NoError(err)
+	r.Equal(int64(1499827319559), serverTime)
+}
+
+func (s *wsClientTestSuite) TestReconnectUserDataStream() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{"subscriptionId": 0}`)
+	})
+	defer server.Close()
+	oc := newWsAPIClient(server)
+	defer oc.Close()
+	reconnected := make(chan int, 1)
+	policy := testReconnectPolicy()
+	policy.OnReconnect = func(attempts int) { reconnected <- attempts }
+	oc.SetReconnectPolicy(policy)
+
+	events := make(chan *WsUserDataEvent, 1)
+	client, err := NewDataStreamClient(oc, func(event *WsUserDataEvent) {
+		events <- event
+	}, func(err error) {})
+	r.NoError(err)
+	defer client.Close()
+
+	server.dropConnections()
+	select {
+	case <-reconnected:
+	case <-time.After(time.Second):
+		r.FailNow("OnReconnect not called")
+	}
+	// the user data stream is subscribed again with the original handler
+	r.Equal("userDataStream.subscribe.signature", server.lastRequest().Method)
+	server.push(`{"subscriptionId": 0, "event": {"e": "balanceUpdate", "E": 1573200697110, "a": "BTC", "d": "100.00000000", "T": 1573200697068}}`)
+	select {
+	case event := <-events:
+		r.Equal("BTC", event.BalanceUpdate.Asset)
+	case <-time.After(time.Second):
+		r.Fail("no event received after reconnecting")
+	}
+}
+
+func (s *wsClientTestSuite) TestGiveUpReconnecting() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{}`)
+	})
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	gaveUp := make(chan error, 1)
+	policy := testReconnectPolicy()
+	policy.MaxAttempts = 2
+	policy.OnGiveUp = func(err error) { gaveUp <- err }
+	client.SetReconnectPolicy(policy)
+
+	server.dropConnections()
+	server.Close()
+	select {
+	case err := <-gaveUp:
+		r.Error(err)
+	case <-time.After(time.Second):
+		r.FailNow("OnGiveUp not called")
+	}
+	r.False(client.WsConnected())
+	_, _, err := client.callWsAPI(newContext(), &request{wsMethod: "time"})
+	r.Equal(ErrWsAPINotConnected, err)
+}
+
+func (s *wsClientTestSuite) TestStopReconnecting() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{}`)
+	})
+	defer server.Close()
+
+	for _, stop := range []func(client *Client, cancel context.CancelFunc){
+		func(client *Client, cancel context.CancelFunc) { cancel() },
+		func(client *Client, cancel context.CancelFunc) { client.Close() },
+	} {
+		client := newWsAPIClient(server)
+		ctx, cancel := context.WithCancel(context.Background())
+		disconnected, gaveUp := make(chan error, 1), make(chan error, 1)
+		policy := testReconnectPolicy()
+		policy.InitialBackoff = time.Hour
+		policy.Context = ctx
+		policy.OnDisconnect = func(err error) { disconnected <- err }
+		policy.OnGiveUp = func(err error) { gaveUp <- err }
+		client.SetReconnectPolicy(policy)
+
+		server.dropConnections()
+		<-disconnected
+		stop(client, cancel)
+		// stopping on purpose is not giving up
+		select {
+		case err := <-gaveUp:
+			r.FailNow("OnGiveUp called after stopping", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+		r.False(client.WsConnected())
+		client.Close()
+		cancel()
+	}
+}
+
+func (s *wsClientTestSuite) TestCreateSOROrder() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`[
+			{
+				"symbol": "BTCUSDT",
+				"orderId": 2,
+				"orderListId": -1,
+				"clientOrderId": "sBI1KM6nNtOfj5tccZSKly",
+				"transactTime": 1689149087774,
+				"price": "31000.00000000",
+				"origQty": "0.50000000",
+				"executedQty": "0.50000000",
+				"cummulativeQuoteQty": "14000.00000000",
+				"status": "FILLED",
+				"timeInForce": "GTC",
+				"type": "LIMIT",
+				"side": "BUY",
+				"workingTime": 1689149087774,
+				"fills": [
+					{
+						"matchType": "ONE_PARTY_TRADE_REPORT",
+						"price": "28000.00000000",
+						"qty": "0.50000000",
+						"commission": "0.00000000",
+						"commissionAsset": "BTC",
+						"tradeId": -1,
+						"allocId": 0
+					}
+				],
+				"workingFloor": "SOR",
+				"selfTradePreventionMode": "NONE",
+				"usedSor": true
+			}
+		]`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	res, err := client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).TimeInForce(TimeInForceTypeGTC).Quantity("0.5").Price("31000").
+		Do(newContext())
+	r.NoError(err)
+	req := server.lastRequest()
+	r.Equal("sor.order.place", req.Method)
+	r.Equal("BTCUSDT", req.Params["symbol"])
+	r.Equal("0.5", req.Params["quantity"])
+	r.Equal(int64(2), res.OrderID)
+	r.Equal(OrderStatusTypeFilled, res.Status)
+	r.True(res.UsedSor)
+	r.Len(res.Fills, 1)
+	r.Equal("28000.00000000", res.Fills[0].Price)
+}
+
+func (s *wsClientTestSuite) TestCreateSOROrderEmptyResult() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`[]`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	_, err := client.NewCreateSOROrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("0.5").Do(newContext())
+	r.Equal(ErrEmptySOROrderResponse, err)
+}
+
+func (s *wsClientTestSuite) TestCancelReplaceOrder() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{
+			"cancelResult": "SUCCESS",
+			"newOrderResult": "SUCCESS",
+			"cancelResponse": {
+				"symbol": "BTCUSDT",
+				"orderId": 9,
+				"status": "CANCELED",
+				"side": "SELL"
+			},
+			"newOrderResponse": {
+				"symbol": "BTCUSDT",
+				"orderId": 10,
+				"origQty": "0.040000",
+				"status": "NEW",
+				"side": "BUY"
+			}
+		}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	res, err := client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).CancelReplaceMode(CancelReplaceModeTypeStopOnFailure).
+		TimeInForce(TimeInForceTypeGTC).Quantity("0.04").Price("0.02").CancelOrderID(9).
+		Do(newContext())
+	r.NoError(err)
+	req := server.lastRequest()
+	r.Equal("order.cancelReplace", req.Method)
+	r.Equal("STOP_ON_FAILURE", req.Params["cancelReplaceMode"])
+	r.Equal("9", req.Params["cancelOrderId"])
+	r.Equal(int64(9), res.CancelResponse.OrderID)
+	r.Equal(int64(10), res.NewOrderResponse.OrderID)
+}
+
+func (s *wsClientTestSuite) TestCancelReplaceOrderPartialFailure() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return &wsAPIReply{Status: http.StatusConflict, Error: &common.APIError{
+			Code:    -2021,
+			Message: "Order cancel-replace partially failed.",
+			Data: []byte(`{
+				"cancelResult": "SUCCESS",
+				"newOrderResult": "FAILURE",
+				"cancelResponse": {"symbol": "BTCUSDT", "orderId": 3, "status": "CANCELED"},
+				"newOrderResponse": {"code": -2010, "msg": "Order would immediately match and take."}
+			}`),
+		}}
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	res, err := client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimitMaker).CancelReplaceMode(CancelReplaceModeTypeAllowFailure).
+		Quantity("0.04").Price("0.02").CancelOrderID(3).Do(newContext())
+	r.Error(err)
+	r.NotNil(res)
+	r.Equal(CancelReplaceResultTypeFailure, res.NewOrderResult)
+	r.Equal(int64(-2010), res.NewOrderResponse.Code)
+}
+
+func (s *wsClientTestSuite) TestCreateOrderLists() {
+	r := s.Require()
+	server := newWsAPIServer(func(req *wsAPIRequest) *wsAPIReply {
+		return wsAPIResult(`{
+			"orderListId": 1,
+			"contingencyType": "OTO",
+			"listStatusType": "EXEC_STARTED",
+			"listOrderStatus": "EXECUTING",
+			"listClientOrderId": "RumwQpBaDctlUu5jyG5rs0",
+			"transactionTime": 1712291372842,
+			"symbol": "LTCBTC",
+			"orders": [
+				{"symbol": "LTCBTC", "orderId": 6, "clientOrderId": "fM9Y4m23IFJVCQmIrlUmMK"},
+				{"symbol": "LTCBTC", "orderId": 7, "clientOrderId": "6pcQbFIzTXGZQ1e2MkGDq4"}
+			]
+		}`)
+	})
+	defer server.Close()
+	client := newWsAPIClient(server)
+	defer client.Close()
+
+	for _, c := range []struct {
+		method string
+		param  string
+		value  string
+		do     func() (*CreateOrderListResponse, error)
+	}{
+		{"orderList.place.oco", "aboveType", "LIMIT_MAKER", func() (*CreateOrderListResponse, error) {
+			return client.NewCreateOrderListOCOService().Symbol("LTCBTC").Side(SideTypeSell).Quantity("1").
+				AboveType(OrderTypeLimitMaker).AbovePrice("1.5").
+				BelowType(OrderTypeStopLoss).BelowStopPrice("0.5").Do(newContext())
+		}},
+		{"orderList.place.oto", "pendingType", "MARKET", func() (*CreateOrderListResponse, error) {
+			return client.NewCreateOrderListOTOService().Symbol("LTCBTC").
+				WorkingType(OrderTypeLimit).WorkingSide(SideTypeSell).WorkingPrice("1").WorkingQuantity("1").
+				WorkingTimeInForce(TimeInForceTypeGTC).
+				PendingType(OrderTypeMarket).PendingSide(SideTypeBuy).PendingQuantity("1").Do(newContext())
+		}},
+		{"orderList.place.otoco", "pendingBelowType", "LIMIT_MAKER", func() (*CreateOrderListResponse, error) {
+			return client.NewCreateOrderListOTOCOService().Symbol("LTCBTC").
+				WorkingType(OrderTypeLimit).WorkingSide(SideTypeSell).WorkingPrice("1.5").WorkingQuantity("1").
+				WorkingTimeInForce(TimeInForceTypeGTC).
+				PendingSide(SideTypeBuy).PendingQuantity("5").
+				PendingAboveType(OrderTypeStopLossLimit).PendingAbovePrice("0.5").PendingAboveStopPrice("0.5").
+				PendingAboveTimeInForce(TimeInForceTypeGTC).
+				PendingBelowType(OrderTypeLimitMaker).PendingBelowPrice("0.3").Do(newContext())
+		}},
+	} {
+		res, err := c.do()
+		r.NoError(err, c.method)
+		req := server.lastRequest()
+		r.Equal(c.method, req.Method)
+		r.Equal("LTCBTC", req.Params["symbol"])
+		r.Equal(c.value, req.Params[c.param], c.method)
+		r.Equal(int64(1), res.OrderListID)
+		r.Equal(int64(7), res.Orders[1].OrderID)
+	}
+}